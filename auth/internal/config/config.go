@@ -17,6 +17,7 @@ type Config struct {
 	Params       Params        `yaml:"params"`
 	CodeExp      time.Duration `yaml:"code_exp" env-default:"5m"`
 	Kafka        Kafka         `yaml:"kafka" env-required:"true"`
+	Locales      Locales       `yaml:"locales"`
 }
 
 type Postgres struct {
@@ -43,6 +44,12 @@ type MinMaxLen struct {
 	Max int `yaml:"max" env-required:"true"`
 }
 
+// Locales are the languages the emails are sent in, the users registered without one get the default.
+type Locales struct {
+	Supported []string `yaml:"supported" env-default:"ru,en"`
+	Default   string   `yaml:"default" env-default:"ru"`
+}
+
 type Kafka struct {
 	Brokers           []string `yaml:"brokers" env-required:"true"`
 	VerificationTopic string   `yaml:"topic" env-required:"true"`
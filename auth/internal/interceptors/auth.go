@@ -17,7 +17,8 @@ import (
 
 func AuthUnaryInterceptor(authService service.AuthService, log *slog.Logger) grpc.UnaryServerInterceptor {
 	authRequiredMethods := map[string]bool{
-		"/auth.AuthService/ChangeEmail": true,
+		"/auth.AuthService/ChangeEmail":  true,
+		"/auth.AuthService/ChangeLocale": true,
 	}
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
 		if !authRequiredMethods[info.FullMethod] {
@@ -24,11 +24,12 @@ func NewProducer(cfg *config.Config) *Producer {
 	}
 }
 
-func (p *Producer) SendMessage(ctx context.Context, topic string, key, value []byte) error {
+func (p *Producer) SendMessage(ctx context.Context, topic string, key, value []byte, headers ...kafka.Header) error {
 	return p.writer.WriteMessages(ctx, kafka.Message{
-		Topic: topic,
-		Key:   key,
-		Value: value,
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
 	})
 }
 
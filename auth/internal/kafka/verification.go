@@ -2,12 +2,31 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/Novip1906/tasks-grpc/auth/internal/models"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+
+	messagesPb "github.com/Novip1906/tasks-grpc/auth/internal/messages_gen"
+)
+
+// The values are protobuf messages from notifications/api/proto/messages.proto, the consumers read
+// the values without these headers as JSON sent by the older versions.
+const (
+	headerContentType   = "content-type"
+	headerSchemaVersion = "schema-version"
+	contentTypeProtobuf = "application/x-protobuf"
+	schemaVersion       = "1"
 )
 
+func protobufHeaders() []kafka.Header {
+	return []kafka.Header{
+		{Key: headerContentType, Value: []byte(contentTypeProtobuf)},
+		{Key: headerSchemaVersion, Value: []byte(schemaVersion)},
+	}
+}
+
 type EmailProducer struct {
 	producer          *Producer
 	verificationTopic string
@@ -21,7 +40,13 @@ func NewEmailProducer(producer *Producer, verificationTopic string) *EmailProduc
 }
 
 func (e *EmailProducer) SendVerificationEmail(ctx context.Context, message *models.EmailVerificationMessage) error {
-	jsonData, err := json.Marshal(message)
+	data, err := proto.Marshal(&messagesPb.EmailVerificationMessage{
+		Email:    message.Email,
+		Code:     message.Code,
+		Username: message.Username,
+		EventId:  message.EventId,
+		Locale:   message.Locale,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal email verification message: %w", err)
 	}
@@ -30,7 +55,8 @@ func (e *EmailProducer) SendVerificationEmail(ctx context.Context, message *mode
 		ctx,
 		e.verificationTopic,
 		[]byte(message.Email),
-		jsonData,
+		data,
+		protobufHeaders()...,
 	)
 
 	if err != nil {
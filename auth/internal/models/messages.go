@@ -4,4 +4,6 @@ type EmailVerificationMessage struct {
 	Email    string `json:"email"`
 	Code     string `json:"code"`
 	Username string `json:"username"`
+	EventId  string `json:"event_id"`
+	Locale   string `json:"locale"`
 }
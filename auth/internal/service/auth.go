@@ -20,9 +20,12 @@ import (
 
 type UserStorage interface {
 	CheckUsernamePassword(username, password string) (userId int64, email string, err error)
+	GetUserByUsername(username string) (userId int64, email string, err error)
+	GetUserById(userId int64) (username, email, locale string, err error)
 	CheckEmailExists(email string) (bool, error)
-	AddUser(username, password, email string) (int64, error)
+	AddUser(username, password, email, locale string) (int64, error)
 	SetEmail(userId int64, email string) error
+	SetLocale(userId int64, locale string) error
 }
 
 type CodeStorage interface {
@@ -87,7 +90,7 @@ func (s *AuthService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 }
 
 func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
-	username, email, pass := req.GetUsername(), req.GetEmail(), req.GetPassword()
+	username, email, pass, locale := req.GetUsername(), req.GetEmail(), req.GetPassword(), req.GetLocale()
 
 	log := contextkeys.GetLogger(ctx)
 
@@ -107,6 +110,14 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		return nil, status.Error(codes.InvalidArgument, "Email is invalid")
 	}
 
+	if locale == "" {
+		locale = s.cfg.Locales.Default
+	}
+	if !utils.LocaleIsValid(locale, s.cfg) {
+		log.Error("invalid locale", "locale", locale)
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidLocaleMessage)
+	}
+
 	if _, _, err := s.codeDb.GetCode(ctx, email); err == nil {
 		log.Error("email in codesDB", "email", email)
 		return nil, status.Error(codes.AlreadyExists, "Email is already exists")
@@ -121,7 +132,7 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		return nil, status.Error(codes.AlreadyExists, "Email is already exists")
 	}
 
-	userId, err := s.userDb.AddUser(username, pass, "")
+	userId, err := s.userDb.AddUser(username, pass, "", locale)
 	if errors.Is(err, storage.ErrUserAlreadyExists) {
 		log.Error(err.Error())
 		return nil, status.Error(codes.AlreadyExists, "User is already exists")
@@ -150,6 +161,8 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		Email:    email,
 		Code:     code,
 		Username: username,
+		EventId:  utils.GenerateEventId(),
+		Locale:   locale,
 	})
 
 	if err != nil {
@@ -191,6 +204,63 @@ func (s *AuthService) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 	}, nil
 }
 
+func (s *AuthService) GetUserByUsername(ctx context.Context, req *pb.GetUserByUsernameRequest) (*pb.GetUserByUsernameResponse, error) {
+	log := contextkeys.GetLogger(ctx)
+	username := req.GetUsername()
+
+	log.Debug("get user by username attempt", "username", username)
+
+	if username == "" {
+		log.Error("username empty")
+		return nil, status.Error(codes.InvalidArgument, "Username is empty")
+	}
+
+	userId, email, err := s.userDb.GetUserByUsername(username)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		log.Error("user not found", "username", username)
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		log.Error("userDB error", logging.DbErr("GetUserByUsername", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	return &pb.GetUserByUsernameResponse{
+		UserId:   userId,
+		Username: username,
+		Email:    email,
+	}, nil
+}
+
+func (s *AuthService) GetUserById(ctx context.Context, req *pb.GetUserByIdRequest) (*pb.GetUserByIdResponse, error) {
+	log := contextkeys.GetLogger(ctx)
+	userId := req.GetUserId()
+
+	log.Debug("get user by id attempt", "user_id", userId)
+
+	if userId <= 0 {
+		log.Error("user id invalid")
+		return nil, status.Error(codes.InvalidArgument, "User id is invalid")
+	}
+
+	username, email, locale, err := s.userDb.GetUserById(userId)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		log.Error("user not found", "user_id", userId)
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		log.Error("userDB error", logging.DbErr("GetUserById", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	return &pb.GetUserByIdResponse{
+		UserId:   userId,
+		Username: username,
+		Email:    email,
+		Locale:   locale,
+	}, nil
+}
+
 func (s *AuthService) ValidateVerificationCode(ctx context.Context, req *pb.ValidateCodeRequest) (*pb.ValidateCodeResponse, error) {
 	log := contextkeys.GetLogger(ctx)
 	email := req.GetEmail()
@@ -254,8 +324,14 @@ func (s *AuthService) ChangeEmail(ctx context.Context, req *pb.ChangeEmailReques
 		return nil, status.Error(codes.AlreadyExists, "Email is already exists")
 	}
 
+	_, _, locale, err := s.userDb.GetUserById(userId)
+	if err != nil {
+		log.Error("userDB error", logging.DbErr("GetUserById", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
 	code := utils.GenerateVerificationCode()
-	err := s.codeDb.SetCode(ctx, newEmail, code, userId)
+	err = s.codeDb.SetCode(ctx, newEmail, code, userId)
 	if err != nil {
 		log.Error("codesDB error", logging.DbErr("SetCode", err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
@@ -268,6 +344,8 @@ func (s *AuthService) ChangeEmail(ctx context.Context, req *pb.ChangeEmailReques
 		Email:    newEmail,
 		Code:     code,
 		Username: username,
+		EventId:  utils.GenerateEventId(),
+		Locale:   locale,
 	})
 
 	if err != nil {
@@ -278,3 +356,33 @@ func (s *AuthService) ChangeEmail(ctx context.Context, req *pb.ChangeEmailReques
 
 	return &pb.ChangeEmailResponse{}, nil
 }
+
+func (s *AuthService) ChangeLocale(ctx context.Context, req *pb.ChangeLocaleRequest) (*pb.ChangeLocaleResponse, error) {
+	log := contextkeys.GetLogger(ctx)
+
+	claims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		log.Error("token claims parse error")
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	locale := req.GetLocale()
+	if !utils.LocaleIsValid(locale, s.cfg) {
+		log.Error("invalid locale", "locale", locale)
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidLocaleMessage)
+	}
+
+	err := s.userDb.SetLocale(claims.UserId, locale)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		log.Error("user not found", "user_id", claims.UserId)
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		log.Error("userDB error", logging.DbErr("SetLocale", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("locale changed", "locale", locale)
+
+	return &pb.ChangeLocaleResponse{}, nil
+}
@@ -29,13 +29,23 @@ func (m *MockUserStorage) CheckUsernamePassword(username, password string) (int6
 	return args.Get(0).(int64), args.String(1), args.Error(2)
 }
 
+func (m *MockUserStorage) GetUserByUsername(username string) (int64, string, error) {
+	args := m.Called(username)
+	return args.Get(0).(int64), args.String(1), args.Error(2)
+}
+
+func (m *MockUserStorage) GetUserById(userId int64) (string, string, string, error) {
+	args := m.Called(userId)
+	return args.String(0), args.String(1), args.String(2), args.Error(3)
+}
+
 func (m *MockUserStorage) CheckEmailExists(email string) (bool, error) {
 	args := m.Called(email)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserStorage) AddUser(username, password, email string) (int64, error) {
-	args := m.Called(username, password, email)
+func (m *MockUserStorage) AddUser(username, password, email, locale string) (int64, error) {
+	args := m.Called(username, password, email, locale)
 	return args.Get(0).(int64), args.Error(1)
 }
 
@@ -44,6 +54,11 @@ func (m *MockUserStorage) SetEmail(userId int64, email string) error {
 	return args.Error(0)
 }
 
+func (m *MockUserStorage) SetLocale(userId int64, locale string) error {
+	args := m.Called(userId, locale)
+	return args.Error(0)
+}
+
 type MockCodeStorage struct {
 	mock.Mock
 }
@@ -81,6 +96,7 @@ func setupService() (*AuthService, *MockUserStorage, *MockCodeStorage, *MockEmai
 			Username: config.MinMaxLen{Min: 3, Max: 20},
 			Password: config.MinMaxLen{Min: 6, Max: 20},
 		},
+		Locales: config.Locales{Supported: []string{"ru", "en"}, Default: "ru"},
 	}
 	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	userDb := new(MockUserStorage)
@@ -136,9 +152,11 @@ func TestRegister_Success_WithEmail(t *testing.T) {
 
 	mockCode.On("GetCode", ctx, "john@test.com").Return("", int64(0), storage.ErrCodeNotFound)
 	mockUser.On("CheckEmailExists", "john@test.com").Return(false, nil)
-	mockUser.On("AddUser", "john", "secret123", "").Return(int64(1), nil)
+	mockUser.On("AddUser", "john", "secret123", "", "ru").Return(int64(1), nil)
 	mockCode.On("SetCode", ctx, "john@test.com", mock.AnythingOfType("string"), int64(1)).Return(nil)
-	mockEmail.On("SendVerificationEmail", mock.Anything, mock.AnythingOfType("*models.EmailVerificationMessage")).Return(nil)
+	mockEmail.On("SendVerificationEmail", mock.Anything, mock.MatchedBy(func(m *models.EmailVerificationMessage) bool {
+		return m.Locale == "ru"
+	})).Return(nil)
 
 	req := &pb.RegisterRequest{
 		Username: "john",
@@ -154,6 +172,47 @@ func TestRegister_Success_WithEmail(t *testing.T) {
 	mockEmail.AssertExpectations(t)
 }
 
+func TestRegister_WithLocale(t *testing.T) {
+	s, mockUser, mockCode, mockEmail := setupService()
+	ctx := getCtx()
+
+	mockCode.On("GetCode", ctx, "john@test.com").Return("", int64(0), storage.ErrCodeNotFound)
+	mockUser.On("CheckEmailExists", "john@test.com").Return(false, nil)
+	mockUser.On("AddUser", "john", "secret123", "", "en").Return(int64(1), nil)
+	mockCode.On("SetCode", ctx, "john@test.com", mock.AnythingOfType("string"), int64(1)).Return(nil)
+	mockEmail.On("SendVerificationEmail", mock.Anything, mock.MatchedBy(func(m *models.EmailVerificationMessage) bool {
+		return m.Locale == "en"
+	})).Return(nil)
+
+	req := &pb.RegisterRequest{
+		Username: "john",
+		Email:    "john@test.com",
+		Password: "secret123",
+		Locale:   "en",
+	}
+	_, err := s.Register(ctx, req)
+
+	assert.NoError(t, err)
+	mockUser.AssertExpectations(t)
+	mockEmail.AssertExpectations(t)
+}
+
+func TestRegister_UnsupportedLocale(t *testing.T) {
+	s, _, _, _ := setupService()
+	ctx := getCtx()
+
+	req := &pb.RegisterRequest{
+		Username: "john",
+		Email:    "john@test.com",
+		Password: "secret123",
+		Locale:   "xx",
+	}
+	_, err := s.Register(ctx, req)
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
 func TestRegister_InvalidInputs(t *testing.T) {
 	s, _, _, _ := setupService()
 	ctx := getCtx()
@@ -187,6 +246,67 @@ func TestValidateToken_Success(t *testing.T) {
 	assert.Equal(t, "test@test.com", resp.Email)
 }
 
+func TestGetUserByUsername_Success(t *testing.T) {
+	s, mockUser, _, _ := setupService()
+	ctx := getCtx()
+
+	mockUser.On("GetUserByUsername", "john").Return(int64(7), "john@test.com", nil)
+
+	resp, err := s.GetUserByUsername(ctx, &pb.GetUserByUsernameRequest{Username: "john"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), resp.UserId)
+	assert.Equal(t, "john", resp.Username)
+	assert.Equal(t, "john@test.com", resp.Email)
+	mockUser.AssertExpectations(t)
+}
+
+func TestGetUserByUsername_NotFound(t *testing.T) {
+	s, mockUser, _, _ := setupService()
+	ctx := getCtx()
+
+	mockUser.On("GetUserByUsername", "ghost").Return(int64(0), "", storage.ErrUserNotFound)
+
+	resp, err := s.GetUserByUsername(ctx, &pb.GetUserByUsernameRequest{Username: "ghost"})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	mockUser.AssertExpectations(t)
+}
+
+func TestGetUserById_Success(t *testing.T) {
+	s, mockUser, _, _ := setupService()
+	ctx := getCtx()
+
+	mockUser.On("GetUserById", int64(7)).Return("john", "john@test.com", "en", nil)
+
+	resp, err := s.GetUserById(ctx, &pb.GetUserByIdRequest{UserId: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), resp.UserId)
+	assert.Equal(t, "john", resp.Username)
+	assert.Equal(t, "john@test.com", resp.Email)
+	assert.Equal(t, "en", resp.Locale)
+	mockUser.AssertExpectations(t)
+}
+
+func TestGetUserById_NotFound(t *testing.T) {
+	s, mockUser, _, _ := setupService()
+	ctx := getCtx()
+
+	mockUser.On("GetUserById", int64(404)).Return("", "", "", storage.ErrUserNotFound)
+
+	resp, err := s.GetUserById(ctx, &pb.GetUserByIdRequest{UserId: 404})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	mockUser.AssertExpectations(t)
+}
+
 func TestValidateVerificationCode_Success(t *testing.T) {
 	s, mockUser, mockCode, _ := setupService()
 	ctx := getCtx()
@@ -205,7 +325,7 @@ func TestValidateVerificationCode_Success(t *testing.T) {
 }
 
 func TestChangeEmail_Success(t *testing.T) {
-	s, _, mockCode, mockEmail := setupService()
+	s, mockUser, mockCode, mockEmail := setupService()
 	ctx := getCtx()
 
 	// Inject claims into context
@@ -217,8 +337,11 @@ func TestChangeEmail_Success(t *testing.T) {
 	ctx = contextkeys.WithTokenClaims(ctx, claims)
 
 	mockCode.On("GetCode", ctx, "new@test.com").Return("", int64(0), storage.ErrCodeNotFound)
+	mockUser.On("GetUserById", int64(1)).Return("john", "old@test.com", "en", nil)
 	mockCode.On("SetCode", ctx, "new@test.com", mock.AnythingOfType("string"), int64(1)).Return(nil)
-	mockEmail.On("SendVerificationEmail", mock.Anything, mock.AnythingOfType("*models.EmailVerificationMessage")).Return(nil)
+	mockEmail.On("SendVerificationEmail", mock.Anything, mock.MatchedBy(func(m *models.EmailVerificationMessage) bool {
+		return m.Locale == "en"
+	})).Return(nil)
 
 	req := &pb.ChangeEmailRequest{NewEmail: "new@test.com"}
 	resp, err := s.ChangeEmail(ctx, req)
@@ -228,3 +351,20 @@ func TestChangeEmail_Success(t *testing.T) {
 	mockCode.AssertExpectations(t)
 	mockEmail.AssertExpectations(t)
 }
+
+func TestChangeLocale(t *testing.T) {
+	s, mockUser, _, _ := setupService()
+	ctx := contextkeys.WithTokenClaims(getCtx(), &contextkeys.TokenClaims{UserId: 1, Username: "john"})
+
+	mockUser.On("SetLocale", int64(1), "en").Return(nil)
+
+	resp, err := s.ChangeLocale(ctx, &pb.ChangeLocaleRequest{Locale: "en"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockUser.AssertExpectations(t)
+
+	_, err = s.ChangeLocale(ctx, &pb.ChangeLocaleRequest{Locale: "xx"})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
@@ -1,5 +1,6 @@
 package service
 
 var (
-	ErrInternalMessage = "Server internal error"
+	ErrInvalidLocaleMessage = "Locale is invalid"
+	ErrInternalMessage      = "Server internal error"
 )
@@ -51,7 +51,8 @@ func (s *PostgresStorage) init() error {
 		email TEXT,
 		password TEXT NOT NULL,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);`
+	);
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'ru';`
 
 	_, err := s.db.Exec(schema)
 	return err
@@ -77,6 +78,36 @@ func (s *PostgresStorage) CheckUsernamePassword(username, password string) (user
 	return userID, email, nil
 }
 
+func (s *PostgresStorage) GetUserByUsername(username string) (userID int64, email string, err error) {
+	var dbEmail sql.NullString
+
+	query := "SELECT id, email FROM users WHERE username = $1"
+	err = s.db.QueryRow(query, username).Scan(&userID, &dbEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", ErrUserNotFound
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	return userID, dbEmail.String, nil
+}
+
+func (s *PostgresStorage) GetUserById(userId int64) (username, email, locale string, err error) {
+	var dbEmail sql.NullString
+
+	query := "SELECT username, email, locale FROM users WHERE id = $1"
+	err = s.db.QueryRow(query, userId).Scan(&username, &dbEmail, &locale)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return username, dbEmail.String, locale, nil
+}
+
 func (s *PostgresStorage) CheckUsernameExists(username string) (bool, error) {
 	var exists bool
 	query := "SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)"
@@ -97,7 +128,7 @@ func (s *PostgresStorage) CheckEmailExists(email string) (bool, error) {
 	return exists, nil
 }
 
-func (s *PostgresStorage) AddUser(username, password, email string) (int64, error) {
+func (s *PostgresStorage) AddUser(username, password, email, locale string) (int64, error) {
 	usernameExists, err := s.CheckUsernameExists(username)
 	if err != nil {
 		return 0, err
@@ -122,9 +153,9 @@ func (s *PostgresStorage) AddUser(username, password, email string) (int64, erro
 	}
 
 	var userID int64
-	query := "INSERT INTO users (username, password, email) VALUES ($1, $2, $3) RETURNING id"
+	query := "INSERT INTO users (username, password, email, locale) VALUES ($1, $2, $3, $4) RETURNING id"
 
-	err = s.db.QueryRow(query, username, hashedPassword, email).Scan(&userID)
+	err = s.db.QueryRow(query, username, hashedPassword, email, locale).Scan(&userID)
 	if err != nil {
 		return 0, err
 	}
@@ -153,6 +184,26 @@ func (s *PostgresStorage) SetEmail(userID int64, email string) error {
 	return nil
 }
 
+func (s *PostgresStorage) SetLocale(userID int64, locale string) error {
+	query := "UPDATE users SET locale = $1 WHERE id = $2"
+
+	s.log.Debug("setting locale", "user_id", userID, "locale", locale)
+	res, err := s.db.Exec(query, locale, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (s *PostgresStorage) Close() error {
 	if s.db != nil {
 		return s.db.Close()
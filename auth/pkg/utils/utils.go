@@ -1,9 +1,11 @@
 package utils
 
 import (
+	crand "crypto/rand"
 	"fmt"
 	"math/rand"
 	"net/mail"
+	"slices"
 	"time"
 	"unicode/utf8"
 
@@ -55,6 +57,15 @@ func GenerateVerificationCode() string {
 	return fmt.Sprintf("%04d", rand.Intn(10000))
 }
 
+// GenerateEventId returns a random UUID v4 identifying a Kafka message.
+func GenerateEventId() string {
+	var b [16]byte
+	_, _ = crand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 func UsernameIsValid(username string, cfg *config.Config) bool {
 	length := utf8.RuneCountInString(username)
 	return length >= cfg.Params.Username.Min && length <= cfg.Params.Username.Max
@@ -69,3 +80,7 @@ func EmailIsValid(email string) bool {
 	_, err := mail.ParseAddress(email)
 	return err == nil
 }
+
+func LocaleIsValid(locale string, cfg *config.Config) bool {
+	return slices.Contains(cfg.Locales.Supported, locale)
+}
@@ -53,6 +53,12 @@ func TestGenerateVerificationCode(t *testing.T) {
 	assert.NotEqual(t, "", code2)
 }
 
+func TestGenerateEventId(t *testing.T) {
+	id := GenerateEventId()
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	assert.NotEqual(t, id, GenerateEventId())
+}
+
 func TestUsernameIsValid(t *testing.T) {
 	cfg := &config.Config{
 		Params: config.Params{
@@ -16,10 +16,13 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	authpb "github.com/Novip1906/tasks-grpc/gateway/internal/gen/auth"
+	notificationspb "github.com/Novip1906/tasks-grpc/gateway/internal/gen/notifications"
 	taskspb "github.com/Novip1906/tasks-grpc/gateway/internal/gen/tasks"
 )
 
@@ -48,11 +51,23 @@ func NewServer(cfg *config.Config, log *slog.Logger) (*Server, error) {
 				return key, true
 			case "x-request-id", "x-correlation-id":
 				return key, true
+			case "if-match":
+				return key, true
 			}
 			return runtime.DefaultHeaderMatcher(key)
 		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			if strings.ToLower(key) == "etag" {
+				return "ETag", true
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
 		runtime.WithErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 			log.Error("grpc-gateway error", logging.Err(err))
+			// a stale If-Match is a failed precondition in HTTP terms, not a conflict
+			if status.Code(err) == codes.Aborted && r.Header.Get("If-Match") != "" {
+				err = &runtime.HTTPStatusError{HTTPStatus: http.StatusPreconditionFailed, Err: err}
+			}
 			runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
 		}),
 	)
@@ -75,6 +90,20 @@ func NewServer(cfg *config.Config, log *slog.Logger) (*Server, error) {
 		return nil, err
 	}
 
+	if err := taskspb.RegisterProjectsServiceHandlerFromEndpoint(
+		ctx, mux, cfg.TasksAddress, opts,
+	); err != nil {
+		log.Error("failed to register projects", logging.Err(err))
+		return nil, err
+	}
+
+	if err := notificationspb.RegisterNotificationsServiceHandlerFromEndpoint(
+		ctx, mux, cfg.NotificationsAddress, opts,
+	); err != nil {
+		log.Error("failed to register notifications", logging.Err(err))
+		return nil, err
+	}
+
 	rateLimiter := middleware.NewRateLimiter(ctx, log, &cfg.Redis, &cfg.RateLimiter)
 
 	rootMux := http.NewServeMux()
@@ -8,12 +8,13 @@ import (
 )
 
 type Config struct {
-	Address      string      `yaml:"address" env-default:":8080"`
-	Env          string      `yaml:"env" env-default:"dev"`
-	AuthAddress  string      `yaml:"auth-address" env-required:"true"`
-	TasksAddress string      `yaml:"tasks-address" env-required:"true"`
-	Redis        Redis       `yaml:"redis"`
-	RateLimiter  RateLimiter `yaml:"rate-limiter" env-required:"true"`
+	Address              string      `yaml:"address" env-default:":8080"`
+	Env                  string      `yaml:"env" env-default:"dev"`
+	AuthAddress          string      `yaml:"auth-address" env-required:"true"`
+	TasksAddress         string      `yaml:"tasks-address" env-required:"true"`
+	NotificationsAddress string      `yaml:"notifications-address" env-required:"true"`
+	Redis                Redis       `yaml:"redis"`
+	RateLimiter          RateLimiter `yaml:"rate-limiter" env-required:"true"`
 }
 
 type Redis struct {
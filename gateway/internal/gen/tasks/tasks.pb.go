@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,8 +24,18 @@ const (
 )
 
 type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Text     string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	DueAt    *int64                 `protobuf:"varint,2,opt,name=dueAt,proto3,oneof" json:"dueAt,omitempty"`
+	Priority *int32                 `protobuf:"varint,3,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	// 0 puts the task into the inbox
+	ProjectId int64 `protobuf:"varint,4,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	// creates a subtask of the given task
+	ParentId int64 `protobuf:"varint,5,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	// RRULE like FREQ=WEEKLY;BYDAY=MO, requires dueAt
+	Recurrence string `protobuf:"bytes,6,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// IANA time zone of the recurrence, UTC when empty
+	Timezone      string `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +77,48 @@ func (x *CreateTaskRequest) GetText() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetDueAt() int64 {
+	if x != nil && x.DueAt != nil {
+		return *x.DueAt
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type CreateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -111,8 +164,10 @@ func (x *CreateTaskResponse) GetTask() *Task {
 }
 
 type GetTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	// fill Task.subtasks with the whole subtree
+	WithSubtasks  bool `protobuf:"varint,2,opt,name=withSubtasks,proto3" json:"withSubtasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -154,12 +209,42 @@ func (x *GetTaskRequest) GetTaskId() int64 {
 	return 0
 }
 
+func (x *GetTaskRequest) GetWithSubtasks() bool {
+	if x != nil {
+		return x.WithSubtasks
+	}
+	return false
+}
+
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	AuthorName    string                 `protobuf:"bytes,3,opt,name=authorName,proto3" json:"authorName,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text        string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	AuthorName  string                 `protobuf:"bytes,3,opt,name=authorName,proto3" json:"authorName,omitempty"`
+	CreatedAt   int64                  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Status      string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CompletedAt int64                  `protobuf:"varint,6,opt,name=completedAt,proto3" json:"completedAt,omitempty"`
+	DueAt       int64                  `protobuf:"varint,7,opt,name=dueAt,proto3" json:"dueAt,omitempty"`
+	Priority    int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	Version     int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt   int64                  `protobuf:"varint,10,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	DeletedAt   int64                  `protobuf:"varint,11,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	Tags        []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 0 for the tasks in the inbox
+	ProjectId int64 `protobuf:"varint,13,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	ParentId  int64 `protobuf:"varint,14,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	// share of done checklist items and subtasks, 0-100
+	Progress int32 `protobuf:"varint,15,opt,name=progress,proto3" json:"progress,omitempty"`
+	// only returned by GetTask and checklist RPCs
+	Checklist []*ChecklistItem `protobuf:"bytes,16,rep,name=checklist,proto3" json:"checklist,omitempty"`
+	// only returned by GetTask with withSubtasks
+	Subtasks []*Task `protobuf:"bytes,17,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	// empty when nobody is assigned
+	AssigneeName string `protobuf:"bytes,18,opt,name=assigneeName,proto3" json:"assigneeName,omitempty"`
+	// RRULE of a recurring task, the next occurrence is created when the task is done
+	Recurrence string `protobuf:"bytes,19,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// IANA time zone the recurrence is expanded in
+	Timezone      string `protobuf:"bytes,20,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -222,9 +307,124 @@ func (x *Task) GetCreatedAt() int64 {
 	return 0
 }
 
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *Task) GetDueAt() int64 {
+	if x != nil {
+		return x.DueAt
+	}
+	return 0
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Task) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Task) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Task) GetSubtasks() []*Task {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
+func (x *Task) GetAssigneeName() string {
+	if x != nil {
+		return x.AssigneeName
+	}
+	return ""
+}
+
+func (x *Task) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *Task) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type SearchTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Tags  []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 0 searches the inbox
+	ProjectId     *int64 `protobuf:"varint,3,opt,name=projectId,proto3,oneof" json:"projectId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -266,9 +466,25 @@ func (x *SearchTasksRequest) GetQuery() string {
 	return ""
 }
 
+func (x *SearchTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchTasksRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
 type SearchTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// number of found tasks per tag
+	TagFacets     []*Tag `protobuf:"bytes,2,rep,name=tagFacets,proto3" json:"tagFacets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -310,8 +526,29 @@ func (x *SearchTasksResponse) GetTasks() []*Task {
 	return nil
 }
 
+func (x *SearchTasksResponse) GetTagFacets() []*Tag {
+	if x != nil {
+		return x.TagFacets
+	}
+	return nil
+}
+
 type GetAllTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	SortBy      string                 `protobuf:"bytes,1,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	SortOrder   string                 `protobuf:"bytes,2,opt,name=sortOrder,proto3" json:"sortOrder,omitempty"`
+	Priority    *int32                 `protobuf:"varint,3,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	DueBefore   int64                  `protobuf:"varint,4,opt,name=dueBefore,proto3" json:"dueBefore,omitempty"`
+	DueAfter    int64                  `protobuf:"varint,5,opt,name=dueAfter,proto3" json:"dueAfter,omitempty"`
+	PageSize    int32                  `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken   string                 `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	CreatedFrom int64                  `protobuf:"varint,8,opt,name=createdFrom,proto3" json:"createdFrom,omitempty"`
+	CreatedTo   int64                  `protobuf:"varint,9,opt,name=createdTo,proto3" json:"createdTo,omitempty"`
+	TextPrefix  string                 `protobuf:"bytes,10,opt,name=textPrefix,proto3" json:"textPrefix,omitempty"`
+	// tasks having all of the tags
+	Tags []string `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	// 0 lists the inbox
+	ProjectId     *int64 `protobuf:"varint,12,opt,name=projectId,proto3,oneof" json:"projectId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -346,9 +583,95 @@ func (*GetAllTasksRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_proto_rawDescGZIP(), []int{6}
 }
 
+func (x *GetAllTasksRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetAllTasksRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *GetAllTasksRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetDueBefore() int64 {
+	if x != nil {
+		return x.DueBefore
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetDueAfter() int64 {
+	if x != nil {
+		return x.DueAfter
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAllTasksRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *GetAllTasksRequest) GetTextPrefix() string {
+	if x != nil {
+		return x.TextPrefix
+	}
+	return ""
+}
+
+func (x *GetAllTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetAllTasksRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
 type GetAllTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -390,28 +713,40 @@ func (x *GetAllTasksResponse) GetTasks() []*Task {
 	return nil
 }
 
-type UpdateTaskRequest struct {
+func (x *GetAllTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetAllTasksResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ListOverdueTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
-	NewText       string                 `protobuf:"bytes,2,opt,name=newText,proto3" json:"newText,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskRequest) Reset() {
-	*x = UpdateTaskRequest{}
+func (x *ListOverdueTasksRequest) Reset() {
+	*x = ListOverdueTasksRequest{}
 	mi := &file_tasks_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskRequest) String() string {
+func (x *ListOverdueTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskRequest) ProtoMessage() {}
+func (*ListOverdueTasksRequest) ProtoMessage() {}
 
-func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *ListOverdueTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,45 +758,32 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOverdueTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListOverdueTasksRequest) Descriptor() ([]byte, []int) {
 	return file_tasks_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateTaskRequest) GetTaskId() int64 {
-	if x != nil {
-		return x.TaskId
-	}
-	return 0
-}
-
-func (x *UpdateTaskRequest) GetNewText() string {
-	if x != nil {
-		return x.NewText
-	}
-	return ""
-}
-
-type UpdateTaskResponse struct {
+type ListOverdueTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskResponse) Reset() {
-	*x = UpdateTaskResponse{}
+func (x *ListOverdueTasksResponse) Reset() {
+	*x = ListOverdueTasksResponse{}
 	mi := &file_tasks_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskResponse) String() string {
+func (x *ListOverdueTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskResponse) ProtoMessage() {}
+func (*ListOverdueTasksResponse) ProtoMessage() {}
 
-func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *ListOverdueTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -473,32 +795,51 @@ func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOverdueTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListOverdueTasksResponse) Descriptor() ([]byte, []int) {
 	return file_tasks_proto_rawDescGZIP(), []int{9}
 }
 
-type DeleteTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+func (x *ListOverdueTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TaskId   int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	NewText  string                 `protobuf:"bytes,2,opt,name=newText,proto3" json:"newText,omitempty"`
+	DueAt    *int64                 `protobuf:"varint,3,opt,name=dueAt,proto3,oneof" json:"dueAt,omitempty"`
+	Priority *int32                 `protobuf:"varint,4,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	// paths: newText, dueAt, priority, projectId, recurrence, timezone; when set, only these fields are changed
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	// the update is rejected if the task version differs, the If-Match header is used when empty
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	// 0 moves the task to the inbox
+	ProjectId *int64 `protobuf:"varint,7,opt,name=projectId,proto3,oneof" json:"projectId,omitempty"`
+	// an empty rule stops the recurrence
+	Recurrence    *string `protobuf:"bytes,8,opt,name=recurrence,proto3,oneof" json:"recurrence,omitempty"`
+	Timezone      *string `protobuf:"bytes,9,opt,name=timezone,proto3,oneof" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskRequest) Reset() {
-	*x = DeleteTaskRequest{}
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
 	mi := &file_tasks_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskRequest) String() string {
+func (x *UpdateTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskRequest) ProtoMessage() {}
+func (*UpdateTaskRequest) ProtoMessage() {}
 
-func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tasks_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -510,40 +851,3012 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_tasks_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetNewText() string {
+	if x != nil {
+		return x.NewText
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDueAt() int64 {
+	if x != nil && x.DueAt != nil {
+		return *x.DueAt
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetRecurrence() string {
+	if x != nil && x.Recurrence != nil {
+		return *x.Recurrence
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTimezone() string {
+	if x != nil && x.Timezone != nil {
+		return *x.Timezone
+	}
+	return ""
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type DeleteTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// subtasks moved to the trash along with the task
+	DeletedSubtasks int64 `protobuf:"varint,2,opt,name=deletedSubtasks,proto3" json:"deletedSubtasks,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *DeleteTaskResponse) GetDeletedSubtasks() int64 {
+	if x != nil {
+		return x.DeletedSubtasks
+	}
+	return 0
+}
+
+type SetTaskStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaskStatusRequest) Reset() {
+	*x = SetTaskStatusRequest{}
+	mi := &file_tasks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaskStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaskStatusRequest) ProtoMessage() {}
+
+func (x *SetTaskStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetTaskStatusRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetTaskStatusRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *SetTaskStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SetTaskStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// the next occurrence created when a recurring task is done
+	NextTask      *Task `protobuf:"bytes,2,opt,name=nextTask,proto3" json:"nextTask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaskStatusResponse) Reset() {
+	*x = SetTaskStatusResponse{}
+	mi := &file_tasks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaskStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaskStatusResponse) ProtoMessage() {}
+
+func (x *SetTaskStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetTaskStatusResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetTaskStatusResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SetTaskStatusResponse) GetNextTask() *Task {
+	if x != nil {
+		return x.NextTask
+	}
+	return nil
+}
+
+type ListDeletedTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTasksRequest) Reset() {
+	*x = ListDeletedTasksRequest{}
+	mi := &file_tasks_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTasksRequest) ProtoMessage() {}
+
+func (x *ListDeletedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{16}
+}
+
+type ListDeletedTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTasksResponse) Reset() {
+	*x = ListDeletedTasksResponse{}
+	mi := &file_tasks_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTasksResponse) ProtoMessage() {}
+
+func (x *ListDeletedTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDeletedTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestoreTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type RestoreTaskResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Task             *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	RestoredSubtasks int64                  `protobuf:"varint,2,opt,name=restoredSubtasks,proto3" json:"restoredSubtasks,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreTaskResponse) Reset() {
+	*x = RestoreTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskResponse) ProtoMessage() {}
+
+func (x *RestoreTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *RestoreTaskResponse) GetRestoredSubtasks() int64 {
+	if x != nil {
+		return x.RestoredSubtasks
+	}
+	return 0
+}
+
+type PurgeTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskRequest) Reset() {
+	*x = PurgeTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskRequest) ProtoMessage() {}
+
+func (x *PurgeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PurgeTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type PurgeTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskResponse) Reset() {
+	*x = PurgeTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskResponse) ProtoMessage() {}
+
+func (x *PurgeTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskResponse.ProtoReflect.Descriptor instead.
+func (*PurgeTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type TaskRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	EditorName    string                 `protobuf:"bytes,3,opt,name=editorName,proto3" json:"editorName,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRevision) Reset() {
+	*x = TaskRevision{}
+	mi := &file_tasks_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRevision) ProtoMessage() {}
+
+func (x *TaskRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRevision.ProtoReflect.Descriptor instead.
+func (*TaskRevision) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TaskRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TaskRevision) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TaskRevision) GetEditorName() string {
+	if x != nil {
+		return x.EditorName
+	}
+	return ""
+}
+
+func (x *TaskRevision) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListTaskRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskRevisionsRequest) Reset() {
+	*x = ListTaskRevisionsRequest{}
+	mi := &file_tasks_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskRevisionsRequest) ProtoMessage() {}
+
+func (x *ListTaskRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTaskRevisionsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListTaskRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*TaskRevision        `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskRevisionsResponse) Reset() {
+	*x = ListTaskRevisionsResponse{}
+	mi := &file_tasks_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskRevisionsResponse) ProtoMessage() {}
+
+func (x *ListTaskRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTaskRevisionsResponse) GetRevisions() []*TaskRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type DiffTaskRevisionsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TaskId       int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	FromRevision int32                  `protobuf:"varint,2,opt,name=fromRevision,proto3" json:"fromRevision,omitempty"`
+	// 0 means the latest revision
+	ToRevision    int32 `protobuf:"varint,3,opt,name=toRevision,proto3" json:"toRevision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffTaskRevisionsRequest) Reset() {
+	*x = DiffTaskRevisionsRequest{}
+	mi := &file_tasks_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffTaskRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffTaskRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffTaskRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffTaskRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffTaskRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DiffTaskRevisionsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *DiffTaskRevisionsRequest) GetFromRevision() int32 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+func (x *DiffTaskRevisionsRequest) GetToRevision() int32 {
+	if x != nil {
+		return x.ToRevision
+	}
+	return 0
+}
+
+type DiffLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// equal, insert or delete
+	Op            string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffLine) Reset() {
+	*x = DiffLine{}
+	mi := &file_tasks_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffLine) ProtoMessage() {}
+
+func (x *DiffLine) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffLine.ProtoReflect.Descriptor instead.
+func (*DiffLine) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DiffLine) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *DiffLine) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type DiffTaskRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *TaskRevision          `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *TaskRevision          `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Lines         []*DiffLine            `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffTaskRevisionsResponse) Reset() {
+	*x = DiffTaskRevisionsResponse{}
+	mi := &file_tasks_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffTaskRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffTaskRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffTaskRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffTaskRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffTaskRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DiffTaskRevisionsResponse) GetFrom() *TaskRevision {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DiffTaskRevisionsResponse) GetTo() *TaskRevision {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *DiffTaskRevisionsResponse) GetLines() []*DiffLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type RevertTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Revision        int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevertTaskRequest) Reset() {
+	*x = RevertTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTaskRequest) ProtoMessage() {}
+
+func (x *RevertTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTaskRequest.ProtoReflect.Descriptor instead.
+func (*RevertTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevertTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RevertTaskRequest) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RevertTaskRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RevertTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertTaskResponse) Reset() {
+	*x = RevertTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTaskResponse) ProtoMessage() {}
+
+func (x *RevertTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTaskResponse.ProtoReflect.Descriptor instead.
+func (*RevertTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RevertTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TaskCount     int64                  `protobuf:"varint,2,opt,name=taskCount,proto3" json:"taskCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_tasks_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetTaskCount() int64 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_tasks_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddTagsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsResponse) Reset() {
+	*x = AddTagsResponse{}
+	mi := &file_tasks_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsResponse) ProtoMessage() {}
+
+func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsResponse.ProtoReflect.Descriptor instead.
+func (*AddTagsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AddTagsResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_tasks_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveTagsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsResponse) Reset() {
+	*x = RemoveTagsResponse{}
+	mi := &file_tasks_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsResponse) ProtoMessage() {}
+
+func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RemoveTagsResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_tasks_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{35}
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_tasks_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Project struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// hex colour like #1e90ff
+	Color         string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	Archived      bool   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	Position      int32  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt     int64  `protobuf:"varint,7,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_tasks_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Project) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Project) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Project) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Project) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Project) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_tasks_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_tasks_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_tasks_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetProjectRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+type ListProjectsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeArchived bool                   `protobuf:"varint,1,opt,name=includeArchived,proto3" json:"includeArchived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_tasks_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_tasks_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type UpdateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Color         *string                `protobuf:"bytes,3,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	Archived      *bool                  `protobuf:"varint,4,opt,name=archived,proto3,oneof" json:"archived,omitempty"`
+	Position      *int32                 `protobuf:"varint,5,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_tasks_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateProjectRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetArchived() bool {
+	if x != nil && x.Archived != nil {
+		return *x.Archived
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+type UpdateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectResponse) Reset() {
+	*x = UpdateProjectResponse{}
+	mi := &file_tasks_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectResponse) ProtoMessage() {}
+
+func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int64                  `protobuf:"varint,1,opt,name=projectId,proto3" json:"projectId,omitempty"`
+	// move the project tasks to the trash instead of the inbox
+	DeleteTasks   bool `protobuf:"varint,2,opt,name=deleteTasks,proto3" json:"deleteTasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_tasks_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteProjectRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *DeleteProjectRequest) GetDeleteTasks() bool {
+	if x != nil {
+		return x.DeleteTasks
+	}
+	return false
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	AffectedTasks int64                  `protobuf:"varint,2,opt,name=affectedTasks,proto3" json:"affectedTasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_tasks_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *DeleteProjectResponse) GetAffectedTasks() int64 {
+	if x != nil {
+		return x.AffectedTasks
+	}
+	return 0
+}
+
+type ChecklistItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_tasks_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistItem) ProtoMessage() {}
+
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ChecklistItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChecklistItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ChecklistItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type AddChecklistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddChecklistItemRequest) Reset() {
+	*x = AddChecklistItemRequest{}
+	mi := &file_tasks_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChecklistItemRequest) ProtoMessage() {}
+
+func (x *AddChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*AddChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AddChecklistItemRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddChecklistItemRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type AddChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddChecklistItemResponse) Reset() {
+	*x = AddChecklistItemResponse{}
+	mi := &file_tasks_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChecklistItemResponse) ProtoMessage() {}
+
+func (x *AddChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*AddChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AddChecklistItemResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ToggleChecklistItemRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	ItemId int64                  `protobuf:"varint,2,opt,name=itemId,proto3" json:"itemId,omitempty"`
+	// flips the item when not set
+	Done          *bool `protobuf:"varint,3,opt,name=done,proto3,oneof" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleChecklistItemRequest) Reset() {
+	*x = ToggleChecklistItemRequest{}
+	mi := &file_tasks_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleChecklistItemRequest) ProtoMessage() {}
+
+func (x *ToggleChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*ToggleChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ToggleChecklistItemRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ToggleChecklistItemRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ToggleChecklistItemRequest) GetDone() bool {
+	if x != nil && x.Done != nil {
+		return *x.Done
+	}
+	return false
+}
+
+type ToggleChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleChecklistItemResponse) Reset() {
+	*x = ToggleChecklistItemResponse{}
+	mi := &file_tasks_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleChecklistItemResponse) ProtoMessage() {}
+
+func (x *ToggleChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*ToggleChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ToggleChecklistItemResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ReorderChecklistItemsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	// every item of the checklist in the new order
+	ItemIds       []int64 `protobuf:"varint,2,rep,packed,name=itemIds,proto3" json:"itemIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderChecklistItemsRequest) Reset() {
+	*x = ReorderChecklistItemsRequest{}
+	mi := &file_tasks_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderChecklistItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderChecklistItemsRequest) ProtoMessage() {}
+
+func (x *ReorderChecklistItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderChecklistItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderChecklistItemsRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReorderChecklistItemsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ReorderChecklistItemsRequest) GetItemIds() []int64 {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+type ReorderChecklistItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderChecklistItemsResponse) Reset() {
+	*x = ReorderChecklistItemsResponse{}
+	mi := &file_tasks_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderChecklistItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderChecklistItemsResponse) ProtoMessage() {}
+
+func (x *ReorderChecklistItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderChecklistItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReorderChecklistItemsResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReorderChecklistItemsResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type RemoveChecklistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	ItemId        int64                  `protobuf:"varint,2,opt,name=itemId,proto3" json:"itemId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveChecklistItemRequest) Reset() {
+	*x = RemoveChecklistItemRequest{}
+	mi := &file_tasks_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveChecklistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveChecklistItemRequest) ProtoMessage() {}
+
+func (x *RemoveChecklistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveChecklistItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveChecklistItemRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RemoveChecklistItemRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveChecklistItemRequest) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type RemoveChecklistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveChecklistItemResponse) Reset() {
+	*x = RemoveChecklistItemResponse{}
+	mi := &file_tasks_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveChecklistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveChecklistItemResponse) ProtoMessage() {}
+
+func (x *RemoveChecklistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveChecklistItemResponse.ProtoReflect.Descriptor instead.
+func (*RemoveChecklistItemResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RemoveChecklistItemResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type TaskMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	AddedAt       int64                  `protobuf:"varint,3,opt,name=addedAt,proto3" json:"addedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskMember) Reset() {
+	*x = TaskMember{}
+	mi := &file_tasks_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskMember) ProtoMessage() {}
+
+func (x *TaskMember) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskMember.ProtoReflect.Descriptor instead.
+func (*TaskMember) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TaskMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TaskMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *TaskMember) GetAddedAt() int64 {
+	if x != nil {
+		return x.AddedAt
+	}
+	return 0
+}
+
+type ShareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTaskRequest) Reset() {
+	*x = ShareTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTaskRequest) ProtoMessage() {}
+
+func (x *ShareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTaskRequest.ProtoReflect.Descriptor instead.
+func (*ShareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ShareTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ShareTaskRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ShareTaskRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ShareTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *TaskMember            `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTaskResponse) Reset() {
+	*x = ShareTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTaskResponse) ProtoMessage() {}
+
+func (x *ShareTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTaskResponse.ProtoReflect.Descriptor instead.
+func (*ShareTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ShareTaskResponse) GetMember() *TaskMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type UnshareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskRequest) Reset() {
+	*x = UnshareTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskRequest) ProtoMessage() {}
+
+func (x *UnshareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnshareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *UnshareTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *UnshareTaskRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type UnshareTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskResponse) Reset() {
+	*x = UnshareTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskResponse) ProtoMessage() {}
+
+func (x *UnshareTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskResponse.ProtoReflect.Descriptor instead.
+func (*UnshareTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{60}
+}
+
+type ListTaskMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskMembersRequest) Reset() {
+	*x = ListTaskMembersRequest{}
+	mi := &file_tasks_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskMembersRequest) ProtoMessage() {}
+
+func (x *ListTaskMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskMembersRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListTaskMembersRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListTaskMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*TaskMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskMembersResponse) Reset() {
+	*x = ListTaskMembersResponse{}
+	mi := &file_tasks_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskMembersResponse) ProtoMessage() {}
+
+func (x *ListTaskMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskMembersResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListTaskMembersResponse) GetMembers() []*TaskMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AssignTaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	// an empty username removes the assignee. The editors can pick a member, only the owners can
+	// assign other users, the editor role given by the assignment is taken back when the user is unassigned
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_tasks_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AssignTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type AssignTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskResponse) Reset() {
+	*x = AssignTaskResponse{}
+	mi := &file_tasks_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskResponse) ProtoMessage() {}
+
+func (x *AssignTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskResponse.ProtoReflect.Descriptor instead.
+func (*AssignTaskResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AssignTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListAssignedTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// done and cancelled tasks are skipped unless set
+	IncludeClosed bool `protobuf:"varint,1,opt,name=includeClosed,proto3" json:"includeClosed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignedTasksRequest) Reset() {
+	*x = ListAssignedTasksRequest{}
+	mi := &file_tasks_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignedTasksRequest) ProtoMessage() {}
+
+func (x *ListAssignedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignedTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListAssignedTasksRequest) GetIncludeClosed() bool {
+	if x != nil {
+		return x.IncludeClosed
+	}
+	return false
+}
+
+type ListAssignedTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignedTasksResponse) Reset() {
+	*x = ListAssignedTasksResponse{}
+	mi := &file_tasks_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignedTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignedTasksResponse) ProtoMessage() {}
+
+func (x *ListAssignedTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignedTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignedTasksResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *DeleteTaskRequest) GetTaskId() int64 {
+func (x *ListAssignedTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type Reminder struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RemindAt int64                  `protobuf:"varint,2,opt,name=remindAt,proto3" json:"remindAt,omitempty"`
+	// 0 until the reminder is sent
+	SentAt        int64 `protobuf:"varint,3,opt,name=sentAt,proto3" json:"sentAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_tasks_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminder) ProtoMessage() {}
+
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *Reminder) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reminder) GetRemindAt() int64 {
+	if x != nil {
+		return x.RemindAt
+	}
+	return 0
+}
+
+func (x *Reminder) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
+}
+
+type AddReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	RemindAt      int64                  `protobuf:"varint,2,opt,name=remindAt,proto3" json:"remindAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReminderRequest) Reset() {
+	*x = AddReminderRequest{}
+	mi := &file_tasks_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReminderRequest) ProtoMessage() {}
+
+func (x *AddReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReminderRequest.ProtoReflect.Descriptor instead.
+func (*AddReminderRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *AddReminderRequest) GetTaskId() int64 {
 	if x != nil {
 		return x.TaskId
 	}
 	return 0
 }
 
-type DeleteTaskResponse struct {
+func (x *AddReminderRequest) GetRemindAt() int64 {
+	if x != nil {
+		return x.RemindAt
+	}
+	return 0
+}
+
+type AddReminderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Reminder      *Reminder              `protobuf:"bytes,1,opt,name=reminder,proto3" json:"reminder,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskResponse) Reset() {
-	*x = DeleteTaskResponse{}
-	mi := &file_tasks_proto_msgTypes[11]
+func (x *AddReminderResponse) Reset() {
+	*x = AddReminderResponse{}
+	mi := &file_tasks_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskResponse) String() string {
+func (x *AddReminderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskResponse) ProtoMessage() {}
+func (*AddReminderResponse) ProtoMessage() {}
 
-func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tasks_proto_msgTypes[11]
+func (x *AddReminderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,62 +3867,527 @@ func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_tasks_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use AddReminderResponse.ProtoReflect.Descriptor instead.
+func (*AddReminderResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *DeleteTaskResponse) GetTask() *Task {
+func (x *AddReminderResponse) GetReminder() *Reminder {
 	if x != nil {
-		return x.Task
+		return x.Reminder
+	}
+	return nil
+}
+
+type ListRemindersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRemindersRequest) Reset() {
+	*x = ListRemindersRequest{}
+	mi := &file_tasks_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRemindersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRemindersRequest) ProtoMessage() {}
+
+func (x *ListRemindersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRemindersRequest.ProtoReflect.Descriptor instead.
+func (*ListRemindersRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListRemindersRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListRemindersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminders     []*Reminder            `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRemindersResponse) Reset() {
+	*x = ListRemindersResponse{}
+	mi := &file_tasks_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRemindersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRemindersResponse) ProtoMessage() {}
+
+func (x *ListRemindersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRemindersResponse.ProtoReflect.Descriptor instead.
+func (*ListRemindersResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListRemindersResponse) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
 	}
 	return nil
 }
 
+type RemoveReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	ReminderId    int64                  `protobuf:"varint,2,opt,name=reminderId,proto3" json:"reminderId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReminderRequest) Reset() {
+	*x = RemoveReminderRequest{}
+	mi := &file_tasks_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReminderRequest) ProtoMessage() {}
+
+func (x *RemoveReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReminderRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReminderRequest) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RemoveReminderRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RemoveReminderRequest) GetReminderId() int64 {
+	if x != nil {
+		return x.ReminderId
+	}
+	return 0
+}
+
+type RemoveReminderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReminderResponse) Reset() {
+	*x = RemoveReminderResponse{}
+	mi := &file_tasks_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReminderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReminderResponse) ProtoMessage() {}
+
+func (x *RemoveReminderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tasks_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReminderResponse.ProtoReflect.Descriptor instead.
+func (*RemoveReminderResponse) Descriptor() ([]byte, []int) {
+	return file_tasks_proto_rawDescGZIP(), []int{73}
+}
+
 var File_tasks_proto protoreflect.FileDescriptor
 
 const file_tasks_proto_rawDesc = "" +
 	"\n" +
-	"\vtasks.proto\x12\x05tasks\x1a\x1cgoogle/api/annotations.proto\"'\n" +
+	"\vtasks.proto\x12\x05tasks\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\"\xf0\x01\n" +
 	"\x11CreateTaskRequest\x12\x12\n" +
-	"\x04text\x18\x01 \x01(\tR\x04text\"5\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x19\n" +
+	"\x05dueAt\x18\x02 \x01(\x03H\x00R\x05dueAt\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x03 \x01(\x05H\x01R\bpriority\x88\x01\x01\x12\x1c\n" +
+	"\tprojectId\x18\x04 \x01(\x03R\tprojectId\x12\x1a\n" +
+	"\bparentId\x18\x05 \x01(\x03R\bparentId\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x06 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\a \x01(\tR\btimezoneB\b\n" +
+	"\x06_dueAtB\v\n" +
+	"\t_priority\"5\n" +
 	"\x12CreateTaskResponse\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"(\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"L\n" +
 	"\x0eGetTaskRequest\x12\x16\n" +
-	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"h\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\"\n" +
+	"\fwithSubtasks\x18\x02 \x01(\bR\fwithSubtasks\"\xd1\x04\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1e\n" +
 	"\n" +
 	"authorName\x18\x03 \x01(\tR\n" +
 	"authorName\x12\x1c\n" +
-	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\"*\n" +
+	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12 \n" +
+	"\vcompletedAt\x18\x06 \x01(\x03R\vcompletedAt\x12\x14\n" +
+	"\x05dueAt\x18\a \x01(\x03R\x05dueAt\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\x12\x1c\n" +
+	"\tupdatedAt\x18\n" +
+	" \x01(\x03R\tupdatedAt\x12\x1c\n" +
+	"\tdeletedAt\x18\v \x01(\x03R\tdeletedAt\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12\x1c\n" +
+	"\tprojectId\x18\r \x01(\x03R\tprojectId\x12\x1a\n" +
+	"\bparentId\x18\x0e \x01(\x03R\bparentId\x12\x1a\n" +
+	"\bprogress\x18\x0f \x01(\x05R\bprogress\x122\n" +
+	"\tchecklist\x18\x10 \x03(\v2\x14.tasks.ChecklistItemR\tchecklist\x12'\n" +
+	"\bsubtasks\x18\x11 \x03(\v2\v.tasks.TaskR\bsubtasks\x12\"\n" +
+	"\fassigneeName\x18\x12 \x01(\tR\fassigneeName\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x13 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\x14 \x01(\tR\btimezone\"o\n" +
 	"\x12SearchTasksRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\"8\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12!\n" +
+	"\tprojectId\x18\x03 \x01(\x03H\x00R\tprojectId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_projectId\"b\n" +
 	"\x13SearchTasksResponse\x12!\n" +
-	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\"\x14\n" +
-	"\x12GetAllTasksRequest\"8\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\x12(\n" +
+	"\ttagFacets\x18\x02 \x03(\v2\n" +
+	".tasks.TagR\ttagFacets\"\x91\x03\n" +
+	"\x12GetAllTasksRequest\x12\x16\n" +
+	"\x06sortBy\x18\x01 \x01(\tR\x06sortBy\x12\x1c\n" +
+	"\tsortOrder\x18\x02 \x01(\tR\tsortOrder\x12\x1f\n" +
+	"\bpriority\x18\x03 \x01(\x05H\x00R\bpriority\x88\x01\x01\x12\x1c\n" +
+	"\tdueBefore\x18\x04 \x01(\x03R\tdueBefore\x12\x1a\n" +
+	"\bdueAfter\x18\x05 \x01(\x03R\bdueAfter\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\a \x01(\tR\tpageToken\x12 \n" +
+	"\vcreatedFrom\x18\b \x01(\x03R\vcreatedFrom\x12\x1c\n" +
+	"\tcreatedTo\x18\t \x01(\x03R\tcreatedTo\x12\x1e\n" +
+	"\n" +
+	"textPrefix\x18\n" +
+	" \x01(\tR\n" +
+	"textPrefix\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12!\n" +
+	"\tprojectId\x18\f \x01(\x03H\x01R\tprojectId\x88\x01\x01B\v\n" +
+	"\t_priorityB\f\n" +
+	"\n" +
+	"_projectId\"~\n" +
 	"\x13GetAllTasksResponse\x12!\n" +
-	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\"E\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x03 \x01(\x03R\n" +
+	"totalCount\"\x19\n" +
+	"\x17ListOverdueTasksRequest\"=\n" +
+	"\x18ListOverdueTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\"\x91\x03\n" +
 	"\x11UpdateTaskRequest\x12\x16\n" +
 	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x18\n" +
-	"\anewText\x18\x02 \x01(\tR\anewText\"\x14\n" +
-	"\x12UpdateTaskResponse\"+\n" +
+	"\anewText\x18\x02 \x01(\tR\anewText\x12\x19\n" +
+	"\x05dueAt\x18\x03 \x01(\x03H\x00R\x05dueAt\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\x04 \x01(\x05H\x01R\bpriority\x88\x01\x01\x12:\n" +
+	"\n" +
+	"updateMask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12(\n" +
+	"\x0fexpectedVersion\x18\x06 \x01(\x03R\x0fexpectedVersion\x12!\n" +
+	"\tprojectId\x18\a \x01(\x03H\x02R\tprojectId\x88\x01\x01\x12#\n" +
+	"\n" +
+	"recurrence\x18\b \x01(\tH\x03R\n" +
+	"recurrence\x88\x01\x01\x12\x1f\n" +
+	"\btimezone\x18\t \x01(\tH\x04R\btimezone\x88\x01\x01B\b\n" +
+	"\x06_dueAtB\v\n" +
+	"\t_priorityB\f\n" +
+	"\n" +
+	"_projectIdB\r\n" +
+	"\v_recurrenceB\v\n" +
+	"\t_timezone\"5\n" +
+	"\x12UpdateTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"+\n" +
 	"\x11DeleteTaskRequest\x12\x16\n" +
-	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"5\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"_\n" +
 	"\x12DeleteTaskResponse\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task2\x99\x04\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12(\n" +
+	"\x0fdeletedSubtasks\x18\x02 \x01(\x03R\x0fdeletedSubtasks\"F\n" +
+	"\x14SetTaskStatusRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"a\n" +
+	"\x15SetTaskStatusResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12'\n" +
+	"\bnextTask\x18\x02 \x01(\v2\v.tasks.TaskR\bnextTask\"\x19\n" +
+	"\x17ListDeletedTasksRequest\"=\n" +
+	"\x18ListDeletedTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\",\n" +
+	"\x12RestoreTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"b\n" +
+	"\x13RestoreTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\x12*\n" +
+	"\x10restoredSubtasks\x18\x02 \x01(\x03R\x10restoredSubtasks\"*\n" +
+	"\x10PurgeTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"4\n" +
+	"\x11PurgeTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"|\n" +
+	"\fTaskRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1e\n" +
+	"\n" +
+	"editorName\x18\x03 \x01(\tR\n" +
+	"editorName\x12\x1c\n" +
+	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\"2\n" +
+	"\x18ListTaskRevisionsRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"N\n" +
+	"\x19ListTaskRevisionsResponse\x121\n" +
+	"\trevisions\x18\x01 \x03(\v2\x13.tasks.TaskRevisionR\trevisions\"v\n" +
+	"\x18DiffTaskRevisionsRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\"\n" +
+	"\ffromRevision\x18\x02 \x01(\x05R\ffromRevision\x12\x1e\n" +
+	"\n" +
+	"toRevision\x18\x03 \x01(\x05R\n" +
+	"toRevision\".\n" +
+	"\bDiffLine\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\x90\x01\n" +
+	"\x19DiffTaskRevisionsResponse\x12'\n" +
+	"\x04from\x18\x01 \x01(\v2\x13.tasks.TaskRevisionR\x04from\x12#\n" +
+	"\x02to\x18\x02 \x01(\v2\x13.tasks.TaskRevisionR\x02to\x12%\n" +
+	"\x05lines\x18\x03 \x03(\v2\x0f.tasks.DiffLineR\x05lines\"q\n" +
+	"\x11RevertTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x12(\n" +
+	"\x0fexpectedVersion\x18\x03 \x01(\x03R\x0fexpectedVersion\"5\n" +
+	"\x12RevertTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"7\n" +
+	"\x03Tag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\ttaskCount\x18\x02 \x01(\x03R\ttaskCount\"<\n" +
+	"\x0eAddTagsRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"2\n" +
+	"\x0fAddTagsResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"?\n" +
+	"\x11RemoveTagsRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"5\n" +
+	"\x12RemoveTagsResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"\x11\n" +
+	"\x0fListTagsRequest\"2\n" +
+	"\x10ListTagsResponse\x12\x1e\n" +
+	"\x04tags\x18\x01 \x03(\v2\n" +
+	".tasks.TagR\x04tags\"\xb7\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12\x1c\n" +
+	"\tcreatedAt\x18\x06 \x01(\x03R\tcreatedAt\x12\x1c\n" +
+	"\tupdatedAt\x18\a \x01(\x03R\tupdatedAt\"@\n" +
+	"\x14CreateProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\"A\n" +
+	"\x15CreateProjectResponse\x12(\n" +
+	"\aproject\x18\x01 \x01(\v2\x0e.tasks.ProjectR\aproject\"1\n" +
+	"\x11GetProjectRequest\x12\x1c\n" +
+	"\tprojectId\x18\x01 \x01(\x03R\tprojectId\"?\n" +
+	"\x13ListProjectsRequest\x12(\n" +
+	"\x0fincludeArchived\x18\x01 \x01(\bR\x0fincludeArchived\"B\n" +
+	"\x14ListProjectsResponse\x12*\n" +
+	"\bprojects\x18\x01 \x03(\v2\x0e.tasks.ProjectR\bprojects\"\xd7\x01\n" +
+	"\x14UpdateProjectRequest\x12\x1c\n" +
+	"\tprojectId\x18\x01 \x01(\x03R\tprojectId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x03 \x01(\tH\x01R\x05color\x88\x01\x01\x12\x1f\n" +
+	"\barchived\x18\x04 \x01(\bH\x02R\barchived\x88\x01\x01\x12\x1f\n" +
+	"\bposition\x18\x05 \x01(\x05H\x03R\bposition\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_colorB\v\n" +
+	"\t_archivedB\v\n" +
+	"\t_position\"A\n" +
+	"\x15UpdateProjectResponse\x12(\n" +
+	"\aproject\x18\x01 \x01(\v2\x0e.tasks.ProjectR\aproject\"V\n" +
+	"\x14DeleteProjectRequest\x12\x1c\n" +
+	"\tprojectId\x18\x01 \x01(\x03R\tprojectId\x12 \n" +
+	"\vdeleteTasks\x18\x02 \x01(\bR\vdeleteTasks\"g\n" +
+	"\x15DeleteProjectResponse\x12(\n" +
+	"\aproject\x18\x01 \x01(\v2\x0e.tasks.ProjectR\aproject\x12$\n" +
+	"\raffectedTasks\x18\x02 \x01(\x03R\raffectedTasks\"c\n" +
+	"\rChecklistItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\"E\n" +
+	"\x17AddChecklistItemRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\";\n" +
+	"\x18AddChecklistItemResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"n\n" +
+	"\x1aToggleChecklistItemRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06itemId\x18\x02 \x01(\x03R\x06itemId\x12\x17\n" +
+	"\x04done\x18\x03 \x01(\bH\x00R\x04done\x88\x01\x01B\a\n" +
+	"\x05_done\">\n" +
+	"\x1bToggleChecklistItemResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"P\n" +
+	"\x1cReorderChecklistItemsRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x18\n" +
+	"\aitemIds\x18\x02 \x03(\x03R\aitemIds\"@\n" +
+	"\x1dReorderChecklistItemsResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"L\n" +
+	"\x1aRemoveChecklistItemRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06itemId\x18\x02 \x01(\x03R\x06itemId\">\n" +
+	"\x1bRemoveChecklistItemResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"V\n" +
+	"\n" +
+	"TaskMember\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
+	"\aaddedAt\x18\x03 \x01(\x03R\aaddedAt\"Z\n" +
+	"\x10ShareTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\">\n" +
+	"\x11ShareTaskResponse\x12)\n" +
+	"\x06member\x18\x01 \x01(\v2\x11.tasks.TaskMemberR\x06member\"H\n" +
+	"\x12UnshareTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"\x15\n" +
+	"\x13UnshareTaskResponse\"0\n" +
+	"\x16ListTaskMembersRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"F\n" +
+	"\x17ListTaskMembersResponse\x12+\n" +
+	"\amembers\x18\x01 \x03(\v2\x11.tasks.TaskMemberR\amembers\"G\n" +
+	"\x11AssignTaskRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"5\n" +
+	"\x12AssignTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskR\x04task\"@\n" +
+	"\x18ListAssignedTasksRequest\x12$\n" +
+	"\rincludeClosed\x18\x01 \x01(\bR\rincludeClosed\">\n" +
+	"\x19ListAssignedTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.tasks.TaskR\x05tasks\"N\n" +
+	"\bReminder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bremindAt\x18\x02 \x01(\x03R\bremindAt\x12\x16\n" +
+	"\x06sentAt\x18\x03 \x01(\x03R\x06sentAt\"H\n" +
+	"\x12AddReminderRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\bremindAt\x18\x02 \x01(\x03R\bremindAt\"B\n" +
+	"\x13AddReminderResponse\x12+\n" +
+	"\breminder\x18\x01 \x01(\v2\x0f.tasks.ReminderR\breminder\".\n" +
+	"\x14ListRemindersRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\"F\n" +
+	"\x15ListRemindersResponse\x12-\n" +
+	"\treminders\x18\x01 \x03(\v2\x0f.tasks.ReminderR\treminders\"O\n" +
+	"\x15RemoveReminderRequest\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x03R\x06taskId\x12\x1e\n" +
+	"\n" +
+	"reminderId\x18\x02 \x01(\x03R\n" +
+	"reminderId\"\x18\n" +
+	"\x16RemoveReminderResponse2\xc0\x18\n" +
 	"\fTasksService\x12T\n" +
 	"\n" +
 	"CreateTask\x12\x18.tasks.CreateTaskRequest\x1a\x19.tasks.CreateTaskResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/tasks\x12F\n" +
 	"\aGetTask\x12\x15.tasks.GetTaskRequest\x1a\v.tasks.Task\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/tasks/{taskId}\x12Z\n" +
 	"\n" +
 	"SearchTask\x12\x19.tasks.SearchTasksRequest\x1a\x1a.tasks.SearchTasksResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/tasks/search\x12T\n" +
-	"\vGetAllTasks\x12\x19.tasks.GetAllTasksRequest\x1a\x1a.tasks.GetAllTasksResponse\"\x0e\x82\xd3\xe4\x93\x02\b\x12\x06/tasks\x12]\n" +
+	"\vGetAllTasks\x12\x19.tasks.GetAllTasksRequest\x1a\x1a.tasks.GetAllTasksResponse\"\x0e\x82\xd3\xe4\x93\x02\b\x12\x06/tasks\x12k\n" +
+	"\x10ListOverdueTasks\x12\x1e.tasks.ListOverdueTasksRequest\x1a\x1f.tasks.ListOverdueTasksResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/tasks/overdue\x12]\n" +
 	"\n" +
 	"UpdateTask\x12\x18.tasks.UpdateTaskRequest\x1a\x19.tasks.UpdateTaskResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\x1a\x0f/tasks/{taskId}\x12Z\n" +
 	"\n" +
-	"DeleteTask\x12\x18.tasks.DeleteTaskRequest\x1a\x19.tasks.DeleteTaskResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/tasks/{taskId}B+Z)github.com/Novip1906/tasks-grpc/tasks/genb\x06proto3"
+	"DeleteTask\x12\x18.tasks.DeleteTaskRequest\x1a\x19.tasks.DeleteTaskResponse\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/tasks/{taskId}\x12m\n" +
+	"\rSetTaskStatus\x12\x1b.tasks.SetTaskStatusRequest\x1a\x1c.tasks.SetTaskStatusResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/tasks/{taskId}/status\x12i\n" +
+	"\x10ListDeletedTasks\x12\x1e.tasks.ListDeletedTasksRequest\x1a\x1f.tasks.ListDeletedTasksResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/tasks/trash\x12n\n" +
+	"\vRestoreTask\x12\x19.tasks.RestoreTaskRequest\x1a\x1a.tasks.RestoreTaskResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/tasks/trash/{taskId}/restore\x12]\n" +
+	"\tPurgeTask\x12\x17.tasks.PurgeTaskRequest\x1a\x18.tasks.PurgeTaskResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/tasks/trash/{taskId}\x12y\n" +
+	"\x11ListTaskRevisions\x12\x1f.tasks.ListTaskRevisionsRequest\x1a .tasks.ListTaskRevisionsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/tasks/{taskId}/revisions\x12~\n" +
+	"\x11DiffTaskRevisions\x12\x1f.tasks.DiffTaskRevisionsRequest\x1a .tasks.DiffTaskRevisionsResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/tasks/{taskId}/revisions/diff\x12d\n" +
+	"\n" +
+	"RevertTask\x12\x18.tasks.RevertTaskRequest\x1a\x19.tasks.RevertTaskResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/tasks/{taskId}/revert\x12Y\n" +
+	"\aAddTags\x12\x15.tasks.AddTagsRequest\x1a\x16.tasks.AddTagsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/tasks/{taskId}/tags\x12_\n" +
+	"\n" +
+	"RemoveTags\x12\x18.tasks.RemoveTagsRequest\x1a\x19.tasks.RemoveTagsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/tasks/{taskId}/tags\x12J\n" +
+	"\bListTags\x12\x16.tasks.ListTagsRequest\x1a\x17.tasks.ListTagsResponse\"\r\x82\xd3\xe4\x93\x02\a\x12\x05/tags\x12y\n" +
+	"\x10AddChecklistItem\x12\x1e.tasks.AddChecklistItemRequest\x1a\x1f.tasks.AddChecklistItemResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/tasks/{taskId}/checklist\x12\x92\x01\n" +
+	"\x13ToggleChecklistItem\x12!.tasks.ToggleChecklistItemRequest\x1a\".tasks.ToggleChecklistItemResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/tasks/{taskId}/checklist/{itemId}/toggle\x12\x8e\x01\n" +
+	"\x15ReorderChecklistItems\x12#.tasks.ReorderChecklistItemsRequest\x1a$.tasks.ReorderChecklistItemsResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\x1a\x1f/tasks/{taskId}/checklist/order\x12\x88\x01\n" +
+	"\x13RemoveChecklistItem\x12!.tasks.RemoveChecklistItemRequest\x1a\".tasks.RemoveChecklistItemResponse\"*\x82\xd3\xe4\x93\x02$*\"/tasks/{taskId}/checklist/{itemId}\x12b\n" +
+	"\tShareTask\x12\x17.tasks.ShareTaskRequest\x1a\x18.tasks.ShareTaskResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/tasks/{taskId}/members\x12p\n" +
+	"\vUnshareTask\x12\x19.tasks.UnshareTaskRequest\x1a\x1a.tasks.UnshareTaskResponse\"*\x82\xd3\xe4\x93\x02$*\"/tasks/{taskId}/members/{username}\x12q\n" +
+	"\x0fListTaskMembers\x12\x1d.tasks.ListTaskMembersRequest\x1a\x1e.tasks.ListTaskMembersResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/tasks/{taskId}/members\x12f\n" +
+	"\n" +
+	"AssignTask\x12\x18.tasks.AssignTaskRequest\x1a\x19.tasks.AssignTaskResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/tasks/{taskId}/assignee\x12o\n" +
+	"\x11ListAssignedTasks\x12\x1f.tasks.ListAssignedTasksRequest\x1a .tasks.ListAssignedTasksResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/tasks/assigned\x12j\n" +
+	"\vAddReminder\x12\x19.tasks.AddReminderRequest\x1a\x1a.tasks.AddReminderResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/tasks/{taskId}/reminders\x12m\n" +
+	"\rListReminders\x12\x1b.tasks.ListRemindersRequest\x1a\x1c.tasks.ListRemindersResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/tasks/{taskId}/reminders\x12}\n" +
+	"\x0eRemoveReminder\x12\x1c.tasks.RemoveReminderRequest\x1a\x1d.tasks.RemoveReminderResponse\".\x82\xd3\xe4\x93\x02(*&/tasks/{taskId}/reminders/{reminderId}2\xff\x03\n" +
+	"\x0fProjectsService\x12`\n" +
+	"\rCreateProject\x12\x1b.tasks.CreateProjectRequest\x1a\x1c.tasks.CreateProjectResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/projects\x12U\n" +
+	"\n" +
+	"GetProject\x12\x18.tasks.GetProjectRequest\x1a\x0e.tasks.Project\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/projects/{projectId}\x12Z\n" +
+	"\fListProjects\x12\x1a.tasks.ListProjectsRequest\x1a\x1b.tasks.ListProjectsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/projects\x12l\n" +
+	"\rUpdateProject\x12\x1b.tasks.UpdateProjectRequest\x1a\x1c.tasks.UpdateProjectResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/projects/{projectId}\x12i\n" +
+	"\rDeleteProject\x12\x1b.tasks.DeleteProjectRequest\x1a\x1c.tasks.DeleteProjectResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/projects/{projectId}B+Z)github.com/Novip1906/tasks-grpc/tasks/genb\x06proto3"
 
 var (
 	file_tasks_proto_rawDescOnce sync.Once
@@ -623,43 +4401,195 @@ func file_tasks_proto_rawDescGZIP() []byte {
 	return file_tasks_proto_rawDescData
 }
 
-var file_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_tasks_proto_goTypes = []any{
-	(*CreateTaskRequest)(nil),   // 0: tasks.CreateTaskRequest
-	(*CreateTaskResponse)(nil),  // 1: tasks.CreateTaskResponse
-	(*GetTaskRequest)(nil),      // 2: tasks.GetTaskRequest
-	(*Task)(nil),                // 3: tasks.Task
-	(*SearchTasksRequest)(nil),  // 4: tasks.SearchTasksRequest
-	(*SearchTasksResponse)(nil), // 5: tasks.SearchTasksResponse
-	(*GetAllTasksRequest)(nil),  // 6: tasks.GetAllTasksRequest
-	(*GetAllTasksResponse)(nil), // 7: tasks.GetAllTasksResponse
-	(*UpdateTaskRequest)(nil),   // 8: tasks.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil),  // 9: tasks.UpdateTaskResponse
-	(*DeleteTaskRequest)(nil),   // 10: tasks.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil),  // 11: tasks.DeleteTaskResponse
+	(*CreateTaskRequest)(nil),             // 0: tasks.CreateTaskRequest
+	(*CreateTaskResponse)(nil),            // 1: tasks.CreateTaskResponse
+	(*GetTaskRequest)(nil),                // 2: tasks.GetTaskRequest
+	(*Task)(nil),                          // 3: tasks.Task
+	(*SearchTasksRequest)(nil),            // 4: tasks.SearchTasksRequest
+	(*SearchTasksResponse)(nil),           // 5: tasks.SearchTasksResponse
+	(*GetAllTasksRequest)(nil),            // 6: tasks.GetAllTasksRequest
+	(*GetAllTasksResponse)(nil),           // 7: tasks.GetAllTasksResponse
+	(*ListOverdueTasksRequest)(nil),       // 8: tasks.ListOverdueTasksRequest
+	(*ListOverdueTasksResponse)(nil),      // 9: tasks.ListOverdueTasksResponse
+	(*UpdateTaskRequest)(nil),             // 10: tasks.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),            // 11: tasks.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),             // 12: tasks.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),            // 13: tasks.DeleteTaskResponse
+	(*SetTaskStatusRequest)(nil),          // 14: tasks.SetTaskStatusRequest
+	(*SetTaskStatusResponse)(nil),         // 15: tasks.SetTaskStatusResponse
+	(*ListDeletedTasksRequest)(nil),       // 16: tasks.ListDeletedTasksRequest
+	(*ListDeletedTasksResponse)(nil),      // 17: tasks.ListDeletedTasksResponse
+	(*RestoreTaskRequest)(nil),            // 18: tasks.RestoreTaskRequest
+	(*RestoreTaskResponse)(nil),           // 19: tasks.RestoreTaskResponse
+	(*PurgeTaskRequest)(nil),              // 20: tasks.PurgeTaskRequest
+	(*PurgeTaskResponse)(nil),             // 21: tasks.PurgeTaskResponse
+	(*TaskRevision)(nil),                  // 22: tasks.TaskRevision
+	(*ListTaskRevisionsRequest)(nil),      // 23: tasks.ListTaskRevisionsRequest
+	(*ListTaskRevisionsResponse)(nil),     // 24: tasks.ListTaskRevisionsResponse
+	(*DiffTaskRevisionsRequest)(nil),      // 25: tasks.DiffTaskRevisionsRequest
+	(*DiffLine)(nil),                      // 26: tasks.DiffLine
+	(*DiffTaskRevisionsResponse)(nil),     // 27: tasks.DiffTaskRevisionsResponse
+	(*RevertTaskRequest)(nil),             // 28: tasks.RevertTaskRequest
+	(*RevertTaskResponse)(nil),            // 29: tasks.RevertTaskResponse
+	(*Tag)(nil),                           // 30: tasks.Tag
+	(*AddTagsRequest)(nil),                // 31: tasks.AddTagsRequest
+	(*AddTagsResponse)(nil),               // 32: tasks.AddTagsResponse
+	(*RemoveTagsRequest)(nil),             // 33: tasks.RemoveTagsRequest
+	(*RemoveTagsResponse)(nil),            // 34: tasks.RemoveTagsResponse
+	(*ListTagsRequest)(nil),               // 35: tasks.ListTagsRequest
+	(*ListTagsResponse)(nil),              // 36: tasks.ListTagsResponse
+	(*Project)(nil),                       // 37: tasks.Project
+	(*CreateProjectRequest)(nil),          // 38: tasks.CreateProjectRequest
+	(*CreateProjectResponse)(nil),         // 39: tasks.CreateProjectResponse
+	(*GetProjectRequest)(nil),             // 40: tasks.GetProjectRequest
+	(*ListProjectsRequest)(nil),           // 41: tasks.ListProjectsRequest
+	(*ListProjectsResponse)(nil),          // 42: tasks.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),          // 43: tasks.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),         // 44: tasks.UpdateProjectResponse
+	(*DeleteProjectRequest)(nil),          // 45: tasks.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),         // 46: tasks.DeleteProjectResponse
+	(*ChecklistItem)(nil),                 // 47: tasks.ChecklistItem
+	(*AddChecklistItemRequest)(nil),       // 48: tasks.AddChecklistItemRequest
+	(*AddChecklistItemResponse)(nil),      // 49: tasks.AddChecklistItemResponse
+	(*ToggleChecklistItemRequest)(nil),    // 50: tasks.ToggleChecklistItemRequest
+	(*ToggleChecklistItemResponse)(nil),   // 51: tasks.ToggleChecklistItemResponse
+	(*ReorderChecklistItemsRequest)(nil),  // 52: tasks.ReorderChecklistItemsRequest
+	(*ReorderChecklistItemsResponse)(nil), // 53: tasks.ReorderChecklistItemsResponse
+	(*RemoveChecklistItemRequest)(nil),    // 54: tasks.RemoveChecklistItemRequest
+	(*RemoveChecklistItemResponse)(nil),   // 55: tasks.RemoveChecklistItemResponse
+	(*TaskMember)(nil),                    // 56: tasks.TaskMember
+	(*ShareTaskRequest)(nil),              // 57: tasks.ShareTaskRequest
+	(*ShareTaskResponse)(nil),             // 58: tasks.ShareTaskResponse
+	(*UnshareTaskRequest)(nil),            // 59: tasks.UnshareTaskRequest
+	(*UnshareTaskResponse)(nil),           // 60: tasks.UnshareTaskResponse
+	(*ListTaskMembersRequest)(nil),        // 61: tasks.ListTaskMembersRequest
+	(*ListTaskMembersResponse)(nil),       // 62: tasks.ListTaskMembersResponse
+	(*AssignTaskRequest)(nil),             // 63: tasks.AssignTaskRequest
+	(*AssignTaskResponse)(nil),            // 64: tasks.AssignTaskResponse
+	(*ListAssignedTasksRequest)(nil),      // 65: tasks.ListAssignedTasksRequest
+	(*ListAssignedTasksResponse)(nil),     // 66: tasks.ListAssignedTasksResponse
+	(*Reminder)(nil),                      // 67: tasks.Reminder
+	(*AddReminderRequest)(nil),            // 68: tasks.AddReminderRequest
+	(*AddReminderResponse)(nil),           // 69: tasks.AddReminderResponse
+	(*ListRemindersRequest)(nil),          // 70: tasks.ListRemindersRequest
+	(*ListRemindersResponse)(nil),         // 71: tasks.ListRemindersResponse
+	(*RemoveReminderRequest)(nil),         // 72: tasks.RemoveReminderRequest
+	(*RemoveReminderResponse)(nil),        // 73: tasks.RemoveReminderResponse
+	(*fieldmaskpb.FieldMask)(nil),         // 74: google.protobuf.FieldMask
 }
 var file_tasks_proto_depIdxs = []int32{
 	3,  // 0: tasks.CreateTaskResponse.task:type_name -> tasks.Task
-	3,  // 1: tasks.SearchTasksResponse.tasks:type_name -> tasks.Task
-	3,  // 2: tasks.GetAllTasksResponse.tasks:type_name -> tasks.Task
-	3,  // 3: tasks.DeleteTaskResponse.task:type_name -> tasks.Task
-	0,  // 4: tasks.TasksService.CreateTask:input_type -> tasks.CreateTaskRequest
-	2,  // 5: tasks.TasksService.GetTask:input_type -> tasks.GetTaskRequest
-	4,  // 6: tasks.TasksService.SearchTask:input_type -> tasks.SearchTasksRequest
-	6,  // 7: tasks.TasksService.GetAllTasks:input_type -> tasks.GetAllTasksRequest
-	8,  // 8: tasks.TasksService.UpdateTask:input_type -> tasks.UpdateTaskRequest
-	10, // 9: tasks.TasksService.DeleteTask:input_type -> tasks.DeleteTaskRequest
-	1,  // 10: tasks.TasksService.CreateTask:output_type -> tasks.CreateTaskResponse
-	3,  // 11: tasks.TasksService.GetTask:output_type -> tasks.Task
-	5,  // 12: tasks.TasksService.SearchTask:output_type -> tasks.SearchTasksResponse
-	7,  // 13: tasks.TasksService.GetAllTasks:output_type -> tasks.GetAllTasksResponse
-	9,  // 14: tasks.TasksService.UpdateTask:output_type -> tasks.UpdateTaskResponse
-	11, // 15: tasks.TasksService.DeleteTask:output_type -> tasks.DeleteTaskResponse
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	47, // 1: tasks.Task.checklist:type_name -> tasks.ChecklistItem
+	3,  // 2: tasks.Task.subtasks:type_name -> tasks.Task
+	3,  // 3: tasks.SearchTasksResponse.tasks:type_name -> tasks.Task
+	30, // 4: tasks.SearchTasksResponse.tagFacets:type_name -> tasks.Tag
+	3,  // 5: tasks.GetAllTasksResponse.tasks:type_name -> tasks.Task
+	3,  // 6: tasks.ListOverdueTasksResponse.tasks:type_name -> tasks.Task
+	74, // 7: tasks.UpdateTaskRequest.updateMask:type_name -> google.protobuf.FieldMask
+	3,  // 8: tasks.UpdateTaskResponse.task:type_name -> tasks.Task
+	3,  // 9: tasks.DeleteTaskResponse.task:type_name -> tasks.Task
+	3,  // 10: tasks.SetTaskStatusResponse.task:type_name -> tasks.Task
+	3,  // 11: tasks.SetTaskStatusResponse.nextTask:type_name -> tasks.Task
+	3,  // 12: tasks.ListDeletedTasksResponse.tasks:type_name -> tasks.Task
+	3,  // 13: tasks.RestoreTaskResponse.task:type_name -> tasks.Task
+	3,  // 14: tasks.PurgeTaskResponse.task:type_name -> tasks.Task
+	22, // 15: tasks.ListTaskRevisionsResponse.revisions:type_name -> tasks.TaskRevision
+	22, // 16: tasks.DiffTaskRevisionsResponse.from:type_name -> tasks.TaskRevision
+	22, // 17: tasks.DiffTaskRevisionsResponse.to:type_name -> tasks.TaskRevision
+	26, // 18: tasks.DiffTaskRevisionsResponse.lines:type_name -> tasks.DiffLine
+	3,  // 19: tasks.RevertTaskResponse.task:type_name -> tasks.Task
+	3,  // 20: tasks.AddTagsResponse.task:type_name -> tasks.Task
+	3,  // 21: tasks.RemoveTagsResponse.task:type_name -> tasks.Task
+	30, // 22: tasks.ListTagsResponse.tags:type_name -> tasks.Tag
+	37, // 23: tasks.CreateProjectResponse.project:type_name -> tasks.Project
+	37, // 24: tasks.ListProjectsResponse.projects:type_name -> tasks.Project
+	37, // 25: tasks.UpdateProjectResponse.project:type_name -> tasks.Project
+	37, // 26: tasks.DeleteProjectResponse.project:type_name -> tasks.Project
+	3,  // 27: tasks.AddChecklistItemResponse.task:type_name -> tasks.Task
+	3,  // 28: tasks.ToggleChecklistItemResponse.task:type_name -> tasks.Task
+	3,  // 29: tasks.ReorderChecklistItemsResponse.task:type_name -> tasks.Task
+	3,  // 30: tasks.RemoveChecklistItemResponse.task:type_name -> tasks.Task
+	56, // 31: tasks.ShareTaskResponse.member:type_name -> tasks.TaskMember
+	56, // 32: tasks.ListTaskMembersResponse.members:type_name -> tasks.TaskMember
+	3,  // 33: tasks.AssignTaskResponse.task:type_name -> tasks.Task
+	3,  // 34: tasks.ListAssignedTasksResponse.tasks:type_name -> tasks.Task
+	67, // 35: tasks.AddReminderResponse.reminder:type_name -> tasks.Reminder
+	67, // 36: tasks.ListRemindersResponse.reminders:type_name -> tasks.Reminder
+	0,  // 37: tasks.TasksService.CreateTask:input_type -> tasks.CreateTaskRequest
+	2,  // 38: tasks.TasksService.GetTask:input_type -> tasks.GetTaskRequest
+	4,  // 39: tasks.TasksService.SearchTask:input_type -> tasks.SearchTasksRequest
+	6,  // 40: tasks.TasksService.GetAllTasks:input_type -> tasks.GetAllTasksRequest
+	8,  // 41: tasks.TasksService.ListOverdueTasks:input_type -> tasks.ListOverdueTasksRequest
+	10, // 42: tasks.TasksService.UpdateTask:input_type -> tasks.UpdateTaskRequest
+	12, // 43: tasks.TasksService.DeleteTask:input_type -> tasks.DeleteTaskRequest
+	14, // 44: tasks.TasksService.SetTaskStatus:input_type -> tasks.SetTaskStatusRequest
+	16, // 45: tasks.TasksService.ListDeletedTasks:input_type -> tasks.ListDeletedTasksRequest
+	18, // 46: tasks.TasksService.RestoreTask:input_type -> tasks.RestoreTaskRequest
+	20, // 47: tasks.TasksService.PurgeTask:input_type -> tasks.PurgeTaskRequest
+	23, // 48: tasks.TasksService.ListTaskRevisions:input_type -> tasks.ListTaskRevisionsRequest
+	25, // 49: tasks.TasksService.DiffTaskRevisions:input_type -> tasks.DiffTaskRevisionsRequest
+	28, // 50: tasks.TasksService.RevertTask:input_type -> tasks.RevertTaskRequest
+	31, // 51: tasks.TasksService.AddTags:input_type -> tasks.AddTagsRequest
+	33, // 52: tasks.TasksService.RemoveTags:input_type -> tasks.RemoveTagsRequest
+	35, // 53: tasks.TasksService.ListTags:input_type -> tasks.ListTagsRequest
+	48, // 54: tasks.TasksService.AddChecklistItem:input_type -> tasks.AddChecklistItemRequest
+	50, // 55: tasks.TasksService.ToggleChecklistItem:input_type -> tasks.ToggleChecklistItemRequest
+	52, // 56: tasks.TasksService.ReorderChecklistItems:input_type -> tasks.ReorderChecklistItemsRequest
+	54, // 57: tasks.TasksService.RemoveChecklistItem:input_type -> tasks.RemoveChecklistItemRequest
+	57, // 58: tasks.TasksService.ShareTask:input_type -> tasks.ShareTaskRequest
+	59, // 59: tasks.TasksService.UnshareTask:input_type -> tasks.UnshareTaskRequest
+	61, // 60: tasks.TasksService.ListTaskMembers:input_type -> tasks.ListTaskMembersRequest
+	63, // 61: tasks.TasksService.AssignTask:input_type -> tasks.AssignTaskRequest
+	65, // 62: tasks.TasksService.ListAssignedTasks:input_type -> tasks.ListAssignedTasksRequest
+	68, // 63: tasks.TasksService.AddReminder:input_type -> tasks.AddReminderRequest
+	70, // 64: tasks.TasksService.ListReminders:input_type -> tasks.ListRemindersRequest
+	72, // 65: tasks.TasksService.RemoveReminder:input_type -> tasks.RemoveReminderRequest
+	38, // 66: tasks.ProjectsService.CreateProject:input_type -> tasks.CreateProjectRequest
+	40, // 67: tasks.ProjectsService.GetProject:input_type -> tasks.GetProjectRequest
+	41, // 68: tasks.ProjectsService.ListProjects:input_type -> tasks.ListProjectsRequest
+	43, // 69: tasks.ProjectsService.UpdateProject:input_type -> tasks.UpdateProjectRequest
+	45, // 70: tasks.ProjectsService.DeleteProject:input_type -> tasks.DeleteProjectRequest
+	1,  // 71: tasks.TasksService.CreateTask:output_type -> tasks.CreateTaskResponse
+	3,  // 72: tasks.TasksService.GetTask:output_type -> tasks.Task
+	5,  // 73: tasks.TasksService.SearchTask:output_type -> tasks.SearchTasksResponse
+	7,  // 74: tasks.TasksService.GetAllTasks:output_type -> tasks.GetAllTasksResponse
+	9,  // 75: tasks.TasksService.ListOverdueTasks:output_type -> tasks.ListOverdueTasksResponse
+	11, // 76: tasks.TasksService.UpdateTask:output_type -> tasks.UpdateTaskResponse
+	13, // 77: tasks.TasksService.DeleteTask:output_type -> tasks.DeleteTaskResponse
+	15, // 78: tasks.TasksService.SetTaskStatus:output_type -> tasks.SetTaskStatusResponse
+	17, // 79: tasks.TasksService.ListDeletedTasks:output_type -> tasks.ListDeletedTasksResponse
+	19, // 80: tasks.TasksService.RestoreTask:output_type -> tasks.RestoreTaskResponse
+	21, // 81: tasks.TasksService.PurgeTask:output_type -> tasks.PurgeTaskResponse
+	24, // 82: tasks.TasksService.ListTaskRevisions:output_type -> tasks.ListTaskRevisionsResponse
+	27, // 83: tasks.TasksService.DiffTaskRevisions:output_type -> tasks.DiffTaskRevisionsResponse
+	29, // 84: tasks.TasksService.RevertTask:output_type -> tasks.RevertTaskResponse
+	32, // 85: tasks.TasksService.AddTags:output_type -> tasks.AddTagsResponse
+	34, // 86: tasks.TasksService.RemoveTags:output_type -> tasks.RemoveTagsResponse
+	36, // 87: tasks.TasksService.ListTags:output_type -> tasks.ListTagsResponse
+	49, // 88: tasks.TasksService.AddChecklistItem:output_type -> tasks.AddChecklistItemResponse
+	51, // 89: tasks.TasksService.ToggleChecklistItem:output_type -> tasks.ToggleChecklistItemResponse
+	53, // 90: tasks.TasksService.ReorderChecklistItems:output_type -> tasks.ReorderChecklistItemsResponse
+	55, // 91: tasks.TasksService.RemoveChecklistItem:output_type -> tasks.RemoveChecklistItemResponse
+	58, // 92: tasks.TasksService.ShareTask:output_type -> tasks.ShareTaskResponse
+	60, // 93: tasks.TasksService.UnshareTask:output_type -> tasks.UnshareTaskResponse
+	62, // 94: tasks.TasksService.ListTaskMembers:output_type -> tasks.ListTaskMembersResponse
+	64, // 95: tasks.TasksService.AssignTask:output_type -> tasks.AssignTaskResponse
+	66, // 96: tasks.TasksService.ListAssignedTasks:output_type -> tasks.ListAssignedTasksResponse
+	69, // 97: tasks.TasksService.AddReminder:output_type -> tasks.AddReminderResponse
+	71, // 98: tasks.TasksService.ListReminders:output_type -> tasks.ListRemindersResponse
+	73, // 99: tasks.TasksService.RemoveReminder:output_type -> tasks.RemoveReminderResponse
+	39, // 100: tasks.ProjectsService.CreateProject:output_type -> tasks.CreateProjectResponse
+	37, // 101: tasks.ProjectsService.GetProject:output_type -> tasks.Project
+	42, // 102: tasks.ProjectsService.ListProjects:output_type -> tasks.ListProjectsResponse
+	44, // 103: tasks.ProjectsService.UpdateProject:output_type -> tasks.UpdateProjectResponse
+	46, // 104: tasks.ProjectsService.DeleteProject:output_type -> tasks.DeleteProjectResponse
+	71, // [71:105] is the sub-list for method output_type
+	37, // [37:71] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_tasks_proto_init() }
@@ -667,15 +4597,21 @@ func file_tasks_proto_init() {
 	if File_tasks_proto != nil {
 		return
 	}
+	file_tasks_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tasks_proto_msgTypes[4].OneofWrappers = []any{}
+	file_tasks_proto_msgTypes[6].OneofWrappers = []any{}
+	file_tasks_proto_msgTypes[10].OneofWrappers = []any{}
+	file_tasks_proto_msgTypes[43].OneofWrappers = []any{}
+	file_tasks_proto_msgTypes[50].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tasks_proto_rawDesc), len(file_tasks_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   74,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_tasks_proto_goTypes,
 		DependencyIndexes: file_tasks_proto_depIdxs,
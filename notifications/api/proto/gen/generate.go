@@ -0,0 +1,7 @@
+package gen
+
+// The services producing the Kafka messages keep their own copies of messages.pb.go,
+// they are written together with this one so they never diverge.
+//go:generate protoc -I .. --go_out=. --go_opt=paths=source_relative messages.proto
+//go:generate cp messages.pb.go ../../../../auth/internal/messages_gen/messages.pb.go
+//go:generate cp messages.pb.go ../../../../tasks/internal/messages_gen/messages.pb.go
@@ -0,0 +1,744 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: notifications.proto
+
+package gen
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Channel delivers the notifications of the user. The type is email, webhook, telegram or slack,
+// the target is the email, the webhook URL, the Telegram chat id or the Slack incoming webhook URL.
+type Channel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Channel) Reset() {
+	*x = Channel{}
+	mi := &file_notifications_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Channel) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Channel) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type ListChannelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChannelsRequest) Reset() {
+	*x = ListChannelsRequest{}
+	mi := &file_notifications_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChannelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChannelsRequest) ProtoMessage() {}
+
+func (x *ListChannelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChannelsRequest.ProtoReflect.Descriptor instead.
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{1}
+}
+
+type ListChannelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channels      []*Channel             `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChannelsResponse) Reset() {
+	*x = ListChannelsResponse{}
+	mi := &file_notifications_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChannelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChannelsResponse) ProtoMessage() {}
+
+func (x *ListChannelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChannelsResponse.ProtoReflect.Descriptor instead.
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListChannelsResponse) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type SetChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChannelRequest) Reset() {
+	*x = SetChannelRequest{}
+	mi := &file_notifications_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChannelRequest) ProtoMessage() {}
+
+func (x *SetChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChannelRequest.ProtoReflect.Descriptor instead.
+func (*SetChannelRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetChannelRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SetChannelRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type SetChannelResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Channel *Channel               `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// secret signing the webhook requests, it's only returned when the webhook channel is set
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChannelResponse) Reset() {
+	*x = SetChannelResponse{}
+	mi := &file_notifications_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChannelResponse) ProtoMessage() {}
+
+func (x *SetChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChannelResponse.ProtoReflect.Descriptor instead.
+func (*SetChannelResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetChannelResponse) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *SetChannelResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type DeleteChannelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChannelRequest) Reset() {
+	*x = DeleteChannelRequest{}
+	mi := &file_notifications_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChannelRequest) ProtoMessage() {}
+
+func (x *DeleteChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChannelRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChannelRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteChannelRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type DeleteChannelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChannelResponse) Reset() {
+	*x = DeleteChannelResponse{}
+	mi := &file_notifications_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChannelResponse) ProtoMessage() {}
+
+func (x *DeleteChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChannelResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChannelResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{6}
+}
+
+// Preferences of the user, by default every notification is delivered through every channel at any time.
+type Preferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event types the user isn't notified about: create, update, delete, restore, purge, status, assign, reminder
+	DisabledEvents []string `protobuf:"bytes,1,rep,name=disabledEvents,proto3" json:"disabledEvents,omitempty"`
+	// channel types the notifications aren't delivered through, the email included
+	DisabledChannels []string `protobuf:"bytes,2,rep,name=disabledChannels,proto3" json:"disabledChannels,omitempty"`
+	// the notifications are held back during the quiet hours, unset disables them
+	QuietHours *QuietHours `protobuf:"bytes,3,opt,name=quietHours,proto3" json:"quietHours,omitempty"`
+	// the emails are collected into a digest, unset sends every email right away
+	Digest        *Digest `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_notifications_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Preferences) GetDisabledEvents() []string {
+	if x != nil {
+		return x.DisabledEvents
+	}
+	return nil
+}
+
+func (x *Preferences) GetDisabledChannels() []string {
+	if x != nil {
+		return x.DisabledChannels
+	}
+	return nil
+}
+
+func (x *Preferences) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+func (x *Preferences) GetDigest() *Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+// QuietHours is a daily period crossing midnight when the end is before the start, like 22:00-08:00.
+type QuietHours struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "HH:MM"
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// "HH:MM"
+	End string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// IANA name like Europe/Berlin
+	Timezone      string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	mi := &file_notifications_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *QuietHours) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Digest collects the emails, except for the reminders, into a summary of the changed tasks grouped by day.
+type Digest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// daily or weekly, the weekly digest is sent on Mondays
+	Frequency string `protobuf:"bytes,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// "HH:MM"
+	Time string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// IANA name like Europe/Berlin
+	Timezone      string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Digest) Reset() {
+	*x = Digest{}
+	mi := &file_notifications_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Digest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Digest) ProtoMessage() {}
+
+func (x *Digest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Digest.ProtoReflect.Descriptor instead.
+func (*Digest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Digest) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *Digest) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *Digest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_notifications_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{10}
+}
+
+type UpdatePreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_notifications_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdatePreferencesRequest) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+var File_notifications_proto protoreflect.FileDescriptor
+
+const file_notifications_proto_rawDesc = "" +
+	"\n" +
+	"\x13notifications.proto\x12\rnotifications\x1a\x1cgoogle/api/annotations.proto\"5\n" +
+	"\aChannel\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\"\x15\n" +
+	"\x13ListChannelsRequest\"J\n" +
+	"\x14ListChannelsResponse\x122\n" +
+	"\bchannels\x18\x01 \x03(\v2\x16.notifications.ChannelR\bchannels\"?\n" +
+	"\x11SetChannelRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\"^\n" +
+	"\x12SetChannelResponse\x120\n" +
+	"\achannel\x18\x01 \x01(\v2\x16.notifications.ChannelR\achannel\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"*\n" +
+	"\x14DeleteChannelRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\"\x17\n" +
+	"\x15DeleteChannelResponse\"\xcb\x01\n" +
+	"\vPreferences\x12&\n" +
+	"\x0edisabledEvents\x18\x01 \x03(\tR\x0edisabledEvents\x12*\n" +
+	"\x10disabledChannels\x18\x02 \x03(\tR\x10disabledChannels\x129\n" +
+	"\n" +
+	"quietHours\x18\x03 \x01(\v2\x19.notifications.QuietHoursR\n" +
+	"quietHours\x12-\n" +
+	"\x06digest\x18\x04 \x01(\v2\x15.notifications.DigestR\x06digest\"P\n" +
+	"\n" +
+	"QuietHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\"V\n" +
+	"\x06Digest\x12\x1c\n" +
+	"\tfrequency\x18\x01 \x01(\tR\tfrequency\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\"\x17\n" +
+	"\x15GetPreferencesRequest\"X\n" +
+	"\x18UpdatePreferencesRequest\x12<\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1a.notifications.PreferencesR\vpreferences2\x97\x05\n" +
+	"\x14NotificationsService\x12x\n" +
+	"\fListChannels\x12\".notifications.ListChannelsRequest\x1a#.notifications.ListChannelsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/notifications/channels\x12|\n" +
+	"\n" +
+	"SetChannel\x12 .notifications.SetChannelRequest\x1a!.notifications.SetChannelResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/notifications/channels/{type}\x12\x82\x01\n" +
+	"\rDeleteChannel\x12#.notifications.DeleteChannelRequest\x1a$.notifications.DeleteChannelResponse\"&\x82\xd3\xe4\x93\x02 *\x1e/notifications/channels/{type}\x12v\n" +
+	"\x0eGetPreferences\x12$.notifications.GetPreferencesRequest\x1a\x1a.notifications.Preferences\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/notifications/preferences\x12\x89\x01\n" +
+	"\x11UpdatePreferences\x12'.notifications.UpdatePreferencesRequest\x1a\x1a.notifications.Preferences\"/\x82\xd3\xe4\x93\x02):\vpreferences\x1a\x1a/notifications/preferencesB=Z;github.com/Novip1906/tasks-grpc/notifications/api/proto/genb\x06proto3"
+
+var (
+	file_notifications_proto_rawDescOnce sync.Once
+	file_notifications_proto_rawDescData []byte
+)
+
+func file_notifications_proto_rawDescGZIP() []byte {
+	file_notifications_proto_rawDescOnce.Do(func() {
+		file_notifications_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notifications_proto_rawDesc), len(file_notifications_proto_rawDesc)))
+	})
+	return file_notifications_proto_rawDescData
+}
+
+var file_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_notifications_proto_goTypes = []any{
+	(*Channel)(nil),                  // 0: notifications.Channel
+	(*ListChannelsRequest)(nil),      // 1: notifications.ListChannelsRequest
+	(*ListChannelsResponse)(nil),     // 2: notifications.ListChannelsResponse
+	(*SetChannelRequest)(nil),        // 3: notifications.SetChannelRequest
+	(*SetChannelResponse)(nil),       // 4: notifications.SetChannelResponse
+	(*DeleteChannelRequest)(nil),     // 5: notifications.DeleteChannelRequest
+	(*DeleteChannelResponse)(nil),    // 6: notifications.DeleteChannelResponse
+	(*Preferences)(nil),              // 7: notifications.Preferences
+	(*QuietHours)(nil),               // 8: notifications.QuietHours
+	(*Digest)(nil),                   // 9: notifications.Digest
+	(*GetPreferencesRequest)(nil),    // 10: notifications.GetPreferencesRequest
+	(*UpdatePreferencesRequest)(nil), // 11: notifications.UpdatePreferencesRequest
+}
+var file_notifications_proto_depIdxs = []int32{
+	0,  // 0: notifications.ListChannelsResponse.channels:type_name -> notifications.Channel
+	0,  // 1: notifications.SetChannelResponse.channel:type_name -> notifications.Channel
+	8,  // 2: notifications.Preferences.quietHours:type_name -> notifications.QuietHours
+	9,  // 3: notifications.Preferences.digest:type_name -> notifications.Digest
+	7,  // 4: notifications.UpdatePreferencesRequest.preferences:type_name -> notifications.Preferences
+	1,  // 5: notifications.NotificationsService.ListChannels:input_type -> notifications.ListChannelsRequest
+	3,  // 6: notifications.NotificationsService.SetChannel:input_type -> notifications.SetChannelRequest
+	5,  // 7: notifications.NotificationsService.DeleteChannel:input_type -> notifications.DeleteChannelRequest
+	10, // 8: notifications.NotificationsService.GetPreferences:input_type -> notifications.GetPreferencesRequest
+	11, // 9: notifications.NotificationsService.UpdatePreferences:input_type -> notifications.UpdatePreferencesRequest
+	2,  // 10: notifications.NotificationsService.ListChannels:output_type -> notifications.ListChannelsResponse
+	4,  // 11: notifications.NotificationsService.SetChannel:output_type -> notifications.SetChannelResponse
+	6,  // 12: notifications.NotificationsService.DeleteChannel:output_type -> notifications.DeleteChannelResponse
+	7,  // 13: notifications.NotificationsService.GetPreferences:output_type -> notifications.Preferences
+	7,  // 14: notifications.NotificationsService.UpdatePreferences:output_type -> notifications.Preferences
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_notifications_proto_init() }
+func file_notifications_proto_init() {
+	if File_notifications_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notifications_proto_rawDesc), len(file_notifications_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_notifications_proto_goTypes,
+		DependencyIndexes: file_notifications_proto_depIdxs,
+		MessageInfos:      file_notifications_proto_msgTypes,
+	}.Build()
+	File_notifications_proto = out.File
+	file_notifications_proto_goTypes = nil
+	file_notifications_proto_depIdxs = nil
+}
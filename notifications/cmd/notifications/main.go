@@ -2,12 +2,18 @@ package main
 
 import (
 	"context"
+	"flag"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	// the quiet hours timezones are loaded in the alpine image without zoneinfo
+	_ "time/tzdata"
 
 	"github.com/Novip1906/tasks-grpc/notifications/internal/app"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/kafka"
 	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
 )
 
@@ -18,6 +24,13 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if len(os.Args) > 1 && os.Args[1] == "replay-dlq" {
+		if !replayDLQ(ctx, cfg, log, os.Args[2:]) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	srv, err := app.NewServer(cfg, log)
 
 	if err != nil {
@@ -31,3 +44,28 @@ func main() {
 		return
 	}
 }
+
+// replayDLQ moves the messages of the topic's DLQ back to the topic once the cause of their failure is fixed:
+//
+//	notifications replay-dlq -topic task-events [-limit 100] [-wait 5s]
+func replayDLQ(ctx context.Context, cfg *config.Config, log *slog.Logger, args []string) bool {
+	flags := flag.NewFlagSet("replay-dlq", flag.ExitOnError)
+	topic := flags.String("topic", "", "topic whose DLQ is replayed")
+	limit := flags.Int("limit", 0, "maximum number of messages to replay, 0 replays all of them")
+	wait := flags.Duration("wait", 5*time.Second, "stop when no message arrives for this long")
+	flags.Parse(args)
+
+	if *topic == "" {
+		flags.Usage()
+		return false
+	}
+
+	count, err := kafka.ReplayDLQ(ctx, cfg.Kafka, *topic, *limit, *wait, log)
+	if err != nil {
+		log.Error("DLQ replay error", "topic", *topic, "replayed", count, logging.Err(err))
+		return false
+	}
+
+	log.Info("DLQ replayed", "topic", *topic, "replayed", count)
+	return true
+}
@@ -3,34 +3,155 @@ package app
 import (
 	"context"
 	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/delivery"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/digest"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/interceptors"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/kafka"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/notifier"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/service"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/unsubscribe"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	notificationsPb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+	authPb "github.com/Novip1906/tasks-grpc/notifications/internal/auth_gen"
+)
+
+var (
+	authTimeout           = 3 * time.Second
+	httpReadHeaderTimeout = 5 * time.Second
+	httpShutdownTimeout   = 5 * time.Second
 )
 
 type Server struct {
-	cfg      *config.Config
-	log      *slog.Logger
-	consumer *kafka.Consumer
+	cfg *config.Config
+	log *slog.Logger
+	gs  *grpc.Server
+	// serves the unsubscribe links of the emails
+	hs         *http.Server
+	consumer   *kafka.Consumer
+	dispatcher *delivery.Dispatcher
+	digests    *digest.Scheduler
+	email      *email.EmailSenderService
 }
 
 func NewServer(cfg *config.Config, log *slog.Logger) (*Server, error) {
-	emailService, err := email.NewEmailSender(&cfg.SMTP, log)
+	authConn, err := grpc.NewClient(cfg.AuthAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	authClient := authPb.NewAuthServiceClient(authConn)
+	directory := users.NewDirectory(authClient)
+
+	stores, err := newStores(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	links := unsubscribe.NewLinks(cfg.Unsubscribe.BaseURL, cfg.Unsubscribe.Secret, cfg.Unsubscribe.TokenTTL)
+	emailService, err := email.NewEmailSender(&cfg.SMTP, cfg.DefaultLocale, links, stores.suppressions, log)
 	if err != nil {
 		return nil, err
 	}
 
-	consumer := kafka.NewConsumer(cfg.Kafka, emailService, log)
-	return &Server{cfg: cfg, log: log, consumer: consumer}, err
+	notifiers := newNotifiers(&cfg.Channels, emailService, log)
+	dispatcher := delivery.NewDispatcher(notifiers, directory, stores.channels, stores.preferences,
+		stores.deferred, stores.digests, stores.dedup, log)
+	digests := digest.NewScheduler(stores.digests, stores.preferences, directory, emailService, stores.dedup, log)
+	consumer := kafka.NewConsumer(cfg.Kafka, emailService, dispatcher, stores.dedup, log)
+
+	gs := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.LoggingInterceptor(log),
+		interceptors.AuthUnaryInterceptor(authClient, authTimeout, log),
+	))
+	channelTypes := slices.Sorted(maps.Keys(notifiers))
+	notificationsService := service.NewNotificationsService(log, stores.channels, stores.preferences, channelTypes)
+	notificationsPb.RegisterNotificationsServiceServer(gs, notificationsService)
+
+	mux := http.NewServeMux()
+	mux.Handle(unsubscribe.Path, unsubscribe.NewHandler(links, stores.suppressions, log))
+	hs := &http.Server{Addr: cfg.Address, Handler: mux, ReadHeaderTimeout: httpReadHeaderTimeout}
+
+	return &Server{cfg: cfg, log: log, gs: gs, hs: hs, consumer: consumer, dispatcher: dispatcher,
+		digests: digests, email: emailService}, nil
+}
+
+// newNotifiers returns the notifiers of the enabled channel types.
+func newNotifiers(cfg *config.Channels, emailService *email.EmailSenderService, log *slog.Logger) map[string]notifier.Notifier {
+	httpClient := notifier.NewHTTPClient(cfg.Timeout, cfg.AllowPrivateTargets)
+
+	notifiers := map[string]notifier.Notifier{
+		models.ChannelEmail:   notifier.NewEmailNotifier(emailService),
+		models.ChannelWebhook: notifier.NewWebhookNotifier(httpClient),
+		models.ChannelSlack:   notifier.NewSlackNotifier(httpClient),
+	}
+	if cfg.Telegram.BotToken != "" {
+		notifiers[models.ChannelTelegram] = notifier.NewTelegramNotifier(httpClient, cfg.Telegram.APIURL, cfg.Telegram.BotToken)
+	} else {
+		log.Warn("TELEGRAM_BOT_TOKEN is empty, the telegram channel is disabled")
+	}
+	return notifiers
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.cfg.GrpcAddress)
+	if err != nil {
+		return err
+	}
+	httpLn, err := net.Listen("tcp", s.cfg.Address)
+	if err != nil {
+		ln.Close()
+		return err
+	}
+
 	if err := s.consumer.Start(ctx); err != nil {
 		return err
 	}
+	go s.dispatcher.RunDeferred(ctx, s.cfg.DeferredInterval)
+	go s.digests.Run(ctx, s.cfg.DigestInterval)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.log.Info("starting grpc server", slog.String("address", s.cfg.GrpcAddress))
+		serveErr <- s.gs.Serve(ln)
+	}()
+	httpErr := make(chan error, 1)
+	go func() {
+		s.log.Info("starting http server", slog.String("address", s.cfg.Address))
+		httpErr <- s.hs.Serve(httpLn)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+		s.log.Error("grpc server stopped", logging.Err(err))
+	case err = <-httpErr:
+		s.log.Error("http server stopped", logging.Err(err))
+	}
 
-	<-ctx.Done()
 	s.log.Info("shutting down server, stopping consumer")
-	return s.consumer.Stop()
+	s.gs.GracefulStop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if shutdownErr := s.hs.Shutdown(shutdownCtx); shutdownErr != nil {
+		s.log.Error("error shutting down http server", logging.Err(shutdownErr))
+	}
+	if stopErr := s.consumer.Stop(); stopErr != nil && err == nil {
+		err = stopErr
+	}
+	if closeErr := s.email.Close(); closeErr != nil {
+		s.log.Error("error closing email transport", logging.Err(closeErr))
+	}
+	return err
 }
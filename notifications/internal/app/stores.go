@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/channels"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/deferred"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/digest"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	StoreRedis  = "redis"
+	StoreMemory = "memory"
+)
+
+// stores keep the state of the service.
+type stores struct {
+	dedup        dedup.Store
+	channels     channels.Store
+	preferences  preferences.Store
+	deferred     deferred.Store
+	digests      digest.Store
+	suppressions suppression.Store
+}
+
+// newStores returns the stores chosen by the config, the memory ones lose the state on restart.
+func newStores(cfg *config.Config) (*stores, error) {
+	switch cfg.Store {
+	case StoreMemory:
+		return &stores{
+			dedup:        dedup.NewMemoryStore(cfg.DedupTTL),
+			channels:     channels.NewMemoryStore(),
+			preferences:  preferences.NewMemoryStore(),
+			deferred:     deferred.NewMemoryStore(),
+			digests:      digest.NewMemoryStore(),
+			suppressions: suppression.NewMemoryStore(),
+		}, nil
+	case StoreRedis, "":
+	default:
+		return nil, fmt.Errorf("unknown store %q", cfg.Store)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Address,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &stores{
+		dedup:        dedup.NewRedisStore(rdb, cfg.DedupTTL),
+		channels:     channels.NewRedisStore(rdb),
+		preferences:  preferences.NewRedisStore(rdb),
+		deferred:     deferred.NewRedisStore(rdb),
+		digests:      digest.NewRedisStore(rdb),
+		suppressions: suppression.NewRedisStore(rdb),
+	}, nil
+}
@@ -0,0 +1,17 @@
+// Package channels stores the notification channels the users configured besides their email.
+package channels
+
+import (
+	"context"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// Store keeps at most one channel of each type per user.
+type Store interface {
+	List(ctx context.Context, userId int64) ([]models.Channel, error)
+	// Set adds the channel or replaces the channel of the same type.
+	Set(ctx context.Context, userId int64, channel models.Channel) error
+	// Delete removes the channel of the type and returns false when there was none.
+	Delete(ctx context.Context, userId int64, channelType string) (bool, error)
+}
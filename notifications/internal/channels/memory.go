@@ -0,0 +1,51 @@
+package channels
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// MemoryStore keeps the channels of the users in the process memory.
+type MemoryStore struct {
+	mu       sync.Mutex
+	channels map[int64]map[string]models.Channel
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{channels: make(map[int64]map[string]models.Channel)}
+}
+
+func (m *MemoryStore) List(ctx context.Context, userId int64) ([]models.Channel, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	channels := make([]models.Channel, 0, len(m.channels[userId]))
+	for _, channel := range m.channels[userId] {
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Type < channels[j].Type })
+	return channels, nil
+}
+
+func (m *MemoryStore) Set(ctx context.Context, userId int64, channel models.Channel) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.channels[userId] == nil {
+		m.channels[userId] = make(map[string]models.Channel)
+	}
+	m.channels[userId][channel.Type] = channel
+	return nil
+}
+
+func (m *MemoryStore) Delete(ctx context.Context, userId int64, channelType string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.channels[userId][channelType]
+	delete(m.channels[userId], channelType)
+	return ok, nil
+}
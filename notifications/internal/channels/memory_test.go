@@ -0,0 +1,41 @@
+package channels
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	slack := models.Channel{Type: models.ChannelSlack, Target: "https://hooks.slack.com/services/1"}
+	require.NoError(t, store.Set(ctx, 1, slack))
+	require.NoError(t, store.Set(ctx, 1, models.Channel{Type: models.ChannelWebhook, Target: "https://old.example.com"}))
+	webhook := models.Channel{Type: models.ChannelWebhook, Target: "https://example.com", Secret: "s"}
+	require.NoError(t, store.Set(ctx, 1, webhook))
+
+	channels, err := store.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Channel{slack, webhook}, channels, "a channel of the same type is replaced")
+
+	channels, err = store.List(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+
+	deleted, err := store.Delete(ctx, 1, models.ChannelSlack)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = store.Delete(ctx, 1, models.ChannelSlack)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	channels, err = store.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Channel{webhook}, channels)
+}
@@ -0,0 +1,58 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisPrefix = "channels:"
+
+// RedisStore keeps the channels of a user in a hash with a field per channel type.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) List(ctx context.Context, userId int64) ([]models.Channel, error) {
+	fields, err := r.client.HGetAll(ctx, redisKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]models.Channel, 0, len(fields))
+	for channelType, value := range fields {
+		var channel models.Channel
+		if err := json.Unmarshal([]byte(value), &channel); err != nil {
+			return nil, fmt.Errorf("unmarshal %s channel: %w", channelType, err)
+		}
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Type < channels[j].Type })
+	return channels, nil
+}
+
+func (r *RedisStore) Set(ctx context.Context, userId int64, channel models.Channel) error {
+	value, err := json.Marshal(channel)
+	if err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, redisKey(userId), channel.Type, value).Err()
+}
+
+func (r *RedisStore) Delete(ctx context.Context, userId int64, channelType string) (bool, error) {
+	deleted, err := r.client.HDel(ctx, redisKey(userId), channelType).Result()
+	return deleted > 0, err
+}
+
+func redisKey(userId int64) string {
+	return redisPrefix + strconv.FormatInt(userId, 10)
+}
@@ -2,16 +2,60 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Address string `yaml:"address" env-required:"true"`
-	Env     string `yaml:"env" env-default:"dev"`
-	SMTP    SMTP   `yaml:"smtp" env-required:"true"`
-	Kafka   Kafka  `yaml:"kafka" env-required:"true"`
+	Address     string        `yaml:"address" env-required:"true"`
+	GrpcAddress string        `yaml:"grpc_address" env-required:"true"`
+	Env         string        `yaml:"env" env-default:"dev"`
+	AuthAddress string        `yaml:"auth_address" env-required:"true"`
+	SMTP        SMTP          `yaml:"smtp" env-required:"true"`
+	Kafka       Kafka         `yaml:"kafka" env-required:"true"`
+	Redis       Redis         `yaml:"redis" env-required:"true"`
+	DedupTTL    time.Duration `yaml:"dedup_ttl" env-default:"72h"`
+	Channels    Channels      `yaml:"channels"`
+	// "redis", or "memory" for the local runs without Redis, the memory stores lose the state on restart
+	Store string `yaml:"store" env:"NOTIFICATIONS_STORE" env-default:"redis"`
+	// language of the emails of the users whose locale has no templates
+	DefaultLocale string `yaml:"default_locale" env-default:"ru"`
+	// how often the notifications deferred until the end of the quiet hours are checked
+	DeferredInterval time.Duration `yaml:"deferred_interval" env-default:"1m"`
+	// how often the digests are checked for being due
+	DigestInterval time.Duration `yaml:"digest_interval" env-default:"1m"`
+	Unsubscribe    Unsubscribe   `yaml:"unsubscribe"`
+}
+
+// Unsubscribe links of the emails, they are served by the HTTP server on Address
+type Unsubscribe struct {
+	// public URL of the HTTP server the links point to, UNSUBSCRIBE_BASE_URL in env overrides it
+	BaseURL string `yaml:"base_url"`
+	// how long the link of an email stays valid
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"2160h"`
+	// key the tokens of the links are signed with
+	Secret string
+}
+
+type Channels struct {
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	// lets the webhooks target the private network, it's only meant for local runs
+	AllowPrivateTargets bool     `yaml:"allow_private_targets" env-default:"false"`
+	Telegram            Telegram `yaml:"telegram"`
+}
+
+// Telegram channel is disabled when the bot token is empty
+type Telegram struct {
+	BotToken string
+	APIURL   string `yaml:"api_url" env-default:"https://api.telegram.org"`
+}
+
+type Redis struct {
+	Address  string `yaml:"address" env-default:":6379"`
+	Password string `yaml:"password" env-default:""`
+	DB       int    `yaml:"db" env-default:"0"`
 }
 
 type SMTP struct {
@@ -19,13 +63,40 @@ type SMTP struct {
 	Password string
 	Host     string `yaml:"host" env-required:"true"`
 	Port     int    `yaml:"port" env-required:"true"`
+	// display name of the From header
+	FromName string `yaml:"from_name" env-default:"Tasks"`
+	// URI of the List-Unsubscribe header like "mailto:unsubscribe@example.com", the header is omitted when empty
+	ListUnsubscribe string `yaml:"list_unsubscribe"`
+	DKIM            DKIM   `yaml:"dkim"`
+	// "smtp" sends the emails, "file" writes them as .eml files to FileDir and "memory" only keeps them,
+	// the last two are meant for local runs and tests
+	Transport string `yaml:"transport" env:"SMTP_TRANSPORT" env-default:"smtp"`
+	FileDir   string `yaml:"file_dir" env-default:"mail"`
+	// "starttls", "tls" for the implicit TLS of port 465, or "none" for a local server without TLS
+	Security    string        `yaml:"security" env-default:"starttls"`
+	PoolSize    int           `yaml:"pool_size" env-default:"2"`
+	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"1m"`
+	Timeout     time.Duration `yaml:"timeout" env-default:"30s"`
+	// at most RecipientLimit emails are sent to a recipient per RecipientInterval, 0 disables the limit
+	RecipientLimit    int           `yaml:"recipient_limit" env-default:"30"`
+	RecipientInterval time.Duration `yaml:"recipient_interval" env-default:"1h"`
+}
+
+// DKIM signing is disabled when the key file is empty
+type DKIM struct {
+	Domain   string `yaml:"domain"`
+	Selector string `yaml:"selector"`
+	// PEM encoded RSA private key
+	KeyFile string `yaml:"key_file" env:"DKIM_KEY_FILE"`
 }
 
 type Kafka struct {
 	Brokers                []string `yaml:"brokers" env-required:"true"`
 	GroupId                string   `yaml:"group_id" env-required:"true"`
 	EmailVerificationTopic string   `yaml:"email_verification_topic" env-default:"email-verification"`
+	TaskEventsTopic        string   `yaml:"task_events_topic" env-default:"task-events"`
 	EventsTopic            string   `yaml:"events_topic" env-default:"events"`
+	DLQSuffix              string   `yaml:"dlq_suffix" env-default:".dlq"`
 }
 
 func MustLoadConfig() *Config {
@@ -42,8 +113,21 @@ func MustLoadConfig() *Config {
 	}
 
 	cfg.SMTP.Password = os.Getenv("SMTP_PASSWORD")
-	if cfg.SMTP.Password == "" {
+	if cfg.SMTP.Password == "" && cfg.SMTP.Transport == "smtp" && cfg.SMTP.Security != "none" {
 		panic("SMTP_PASSWORD in env is empty")
 	}
+
+	cfg.Unsubscribe.Secret = os.Getenv("UNSUBSCRIBE_SECRET")
+	if cfg.Unsubscribe.Secret == "" {
+		panic("UNSUBSCRIBE_SECRET in env is empty")
+	}
+	if baseURL := os.Getenv("UNSUBSCRIBE_BASE_URL"); baseURL != "" {
+		cfg.Unsubscribe.BaseURL = baseURL
+	}
+	if cfg.Unsubscribe.BaseURL == "" {
+		panic("UNSUBSCRIBE_BASE_URL in env is empty")
+	}
+
+	cfg.Channels.Telegram.BotToken = os.Getenv("TELEGRAM_BOT_TOKEN")
 	return &cfg
 }
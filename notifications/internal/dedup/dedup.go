@@ -0,0 +1,39 @@
+// Package dedup keeps the notifications from being delivered twice when Kafka redelivers a message.
+package dedup
+
+import (
+	"context"
+	"strings"
+)
+
+// Store remembers the delivered notifications for a limited time.
+type Store interface {
+	// Claim marks the key as delivered and returns false when it's already marked.
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release removes the mark, so a notification which failed to be sent can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// Key identifies the delivery of the event to the recipient through the channel.
+func Key(eventId, channel, recipient string) string {
+	return strings.Join([]string{eventId, channel, recipient}, ":")
+}
+
+// Once calls send unless the key is already claimed, the claim is released when send fails.
+// It returns false when the notification was delivered before.
+func Once(ctx context.Context, store Store, key string, send func() error) (bool, error) {
+	claimed, err := store.Claim(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	if err := send(); err != nil {
+		// the send error matters more than the release one, the claim expires anyway
+		_ = store.Release(ctx, key)
+		return false, err
+	}
+	return true, nil
+}
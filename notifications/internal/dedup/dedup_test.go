@@ -0,0 +1,69 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnce(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(time.Hour)
+	sends := 0
+	send := func() error {
+		sends++
+		return nil
+	}
+
+	sent, err := Once(ctx, store, "event-1:email:1", send)
+	assert.NoError(t, err)
+	assert.True(t, sent)
+
+	sent, err = Once(ctx, store, "event-1:email:1", send)
+	assert.NoError(t, err)
+	assert.False(t, sent, "the redelivered event is skipped")
+
+	sent, err = Once(ctx, store, "event-1:email:2", send)
+	assert.NoError(t, err)
+	assert.True(t, sent, "another recipient of the event gets it")
+
+	assert.Equal(t, 2, sends)
+}
+
+func TestOnce_FailedSendIsRetried(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(time.Hour)
+
+	sent, err := Once(ctx, store, "event-1:email:1", func() error { return errors.New("smtp is down") })
+	assert.Error(t, err)
+	assert.False(t, sent)
+
+	sent, err = Once(ctx, store, "event-1:email:1", func() error { return nil })
+	assert.NoError(t, err)
+	assert.True(t, sent)
+}
+
+func TestMemoryStore_ClaimExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	claimed, _ := store.Claim(ctx, "key")
+	assert.True(t, claimed)
+
+	now = now.Add(59 * time.Minute)
+	claimed, _ = store.Claim(ctx, "key")
+	assert.False(t, claimed)
+
+	now = now.Add(time.Minute)
+	claimed, _ = store.Claim(ctx, "key")
+	assert.True(t, claimed)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "event-1:email:alice@example.com", Key("event-1", "email", "alice@example.com"))
+}
@@ -0,0 +1,39 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the claims in memory, it's used by the "memory" store of the config.
+type MemoryStore struct {
+	mu     sync.Mutex
+	claims map[string]time.Time
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{claims: make(map[string]time.Time), ttl: ttl, now: time.Now}
+}
+
+func (m *MemoryStore) Claim(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if expiresAt, ok := m.claims[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	m.claims[key] = now.Add(m.ttl)
+	return true, nil
+}
+
+func (m *MemoryStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.claims, key)
+	return nil
+}
@@ -0,0 +1,27 @@
+package dedup
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisPrefix = "dedup:"
+
+type RedisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisStore(client *redis.Client, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, ttl: ttl}
+}
+
+func (r *RedisStore) Claim(ctx context.Context, key string) (bool, error) {
+	return r.client.SetNX(ctx, redisPrefix+key, 1, r.ttl).Result()
+}
+
+func (r *RedisStore) Release(ctx context.Context, key string) error {
+	return r.client.Del(ctx, redisPrefix+key).Err()
+}
@@ -0,0 +1,30 @@
+// Package deferred holds the notifications back until a given time, like the end of the quiet hours of the recipient.
+package deferred
+
+import (
+	"context"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// Notification is a notification to be delivered through the channel later.
+type Notification struct {
+	RecipientId int64               `json:"recipient_id"`
+	Channel     models.Channel      `json:"channel"`
+	Msg         models.EventMessage `json:"msg"`
+	// failed delivery attempts
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// Store keeps the deferred notifications durable. Adding the same notification twice keeps one copy,
+// so a redelivered event doesn't defer it again.
+type Store interface {
+	// Add defers the notification until the time, or moves it there when it's already deferred.
+	Add(ctx context.Context, n Notification, at time.Time) error
+	// Lease returns up to limit notifications due at the time and moves them to now+lease, so no other caller
+	// gets them meanwhile. A notification the caller doesn't remove, as it crashed, is due again once the lease ends.
+	Lease(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Notification, error)
+	// Remove deletes the notification once it's delivered or given up on.
+	Remove(ctx context.Context, n Notification) error
+}
@@ -0,0 +1,82 @@
+package deferred
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the deferred notifications in memory, they are lost on restart.
+type MemoryStore struct {
+	mu sync.Mutex
+	// due times by the JSON of the notifications, like the members of the redis sorted set
+	due map[string]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{due: make(map[string]time.Time)}
+}
+
+func (m *MemoryStore) Add(ctx context.Context, n Notification, at time.Time) error {
+	key, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.due[string(key)] = at
+	return nil
+}
+
+func (m *MemoryStore) Lease(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var keys []string
+	for key, at := range m.due {
+		if !at.After(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return m.due[keys[i]].Before(m.due[keys[j]]) })
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	due := make([]Notification, 0, len(keys))
+	for _, key := range keys {
+		var n Notification
+		if err := json.Unmarshal([]byte(key), &n); err != nil {
+			return due, err
+		}
+		m.due[key] = now.Add(lease)
+		due = append(due, n)
+	}
+	return due, nil
+}
+
+func (m *MemoryStore) Remove(ctx context.Context, n Notification) error {
+	key, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.due, string(key))
+	return nil
+}
+
+// Len returns the number of the deferred notifications.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.due)
+}
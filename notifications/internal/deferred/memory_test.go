@@ -0,0 +1,50 @@
+package deferred
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	first := Notification{RecipientId: 1, Channel: models.Channel{Type: models.ChannelEmail}, Msg: models.EventMessage{EventId: "1"}}
+	second := Notification{RecipientId: 1, Channel: models.Channel{Type: models.ChannelEmail}, Msg: models.EventMessage{EventId: "2"}}
+	later := Notification{RecipientId: 2, Channel: models.Channel{Type: models.ChannelSlack}, Msg: models.EventMessage{EventId: "3"}}
+
+	require.NoError(t, store.Add(ctx, second, now.Add(-time.Minute)))
+	require.NoError(t, store.Add(ctx, first, now.Add(-time.Hour)))
+	require.NoError(t, store.Add(ctx, first, now.Add(-time.Hour)))
+	require.NoError(t, store.Add(ctx, later, now.Add(time.Hour)))
+	assert.Equal(t, 3, store.Len(), "the same notification is kept once")
+
+	due, err := store.Lease(ctx, now, time.Minute, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Notification{first}, due)
+
+	due, err = store.Lease(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Notification{second}, due)
+
+	due, err = store.Lease(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due, "the leased notifications aren't due")
+
+	require.NoError(t, store.Remove(ctx, second))
+	due, err = store.Lease(ctx, now.Add(time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Notification{first}, due, "the notification which wasn't removed is due after the lease")
+
+	require.NoError(t, store.Remove(ctx, first))
+	due, err = store.Lease(ctx, now.Add(time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Notification{later}, due)
+	assert.Equal(t, 1, store.Len())
+}
@@ -0,0 +1,65 @@
+package deferred
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKey = "deferred"
+
+// leaseScript moves the due members to the end of the lease and returns them, atomically,
+// so another instance doesn't lease the same members.
+var leaseScript = redis.NewScript(`
+local members = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(members) do
+	redis.call('ZADD', KEYS[1], ARGV[3], member)
+end
+return members
+`)
+
+// RedisStore keeps the notifications in a sorted set scored by the time they are due at.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Add(ctx context.Context, n Notification, at time.Time) error {
+	member, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return r.client.ZAdd(ctx, redisKey, redis.Z{Score: float64(at.Unix()), Member: member}).Err()
+}
+
+func (r *RedisStore) Lease(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]Notification, error) {
+	members, err := leaseScript.Run(ctx, r.client, []string{redisKey},
+		now.Unix(), limit, now.Add(lease).Unix()).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	due := make([]Notification, 0, len(members))
+	for _, member := range members {
+		var n Notification
+		if err := json.Unmarshal([]byte(member), &n); err != nil {
+			return due, err
+		}
+		due = append(due, n)
+	}
+	return due, nil
+}
+
+func (r *RedisStore) Remove(ctx context.Context, n Notification) error {
+	member, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return r.client.ZRem(ctx, redisKey, member).Err()
+}
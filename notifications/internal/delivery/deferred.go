@@ -0,0 +1,154 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/deferred"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/notifier"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+)
+
+const (
+	deferredBatch       = 100
+	maxDeferredAttempts = 5
+	deferredRetryDelay  = time.Minute
+	// how long the leased notifications are held back from the other instances, long enough to deliver a batch
+	deferredLease = 15 * time.Minute
+)
+
+// RunDeferred delivers the deferred notifications when they are due, checking them every interval
+// until the context is done.
+func (d *Dispatcher) RunDeferred(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.releaseDeferred(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// releaseDeferred delivers the due notifications. A failed notification is deferred again with a growing delay
+// and dropped after maxDeferredAttempts, as there's no Kafka message left to be moved to the DLQ.
+// A notification stays in the store until it's delivered or dropped, so it isn't lost when the service stops midway.
+func (d *Dispatcher) releaseDeferred(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := d.now()
+		due, leaseErr := d.deferred.Lease(ctx, now, deferredLease, deferredBatch)
+		if leaseErr != nil {
+			d.log.Error("Error leasing deferred notifications", logging.Err(leaseErr))
+		}
+
+		for _, n := range due {
+			d.releaseNotification(ctx, n, now)
+		}
+
+		if leaseErr != nil || len(due) < deferredBatch {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) releaseNotification(ctx context.Context, n deferred.Notification, now time.Time) {
+	log := d.log.With("event_id", n.Msg.EventId, "type", n.Msg.Type, "user_id", n.RecipientId, "channel", n.Channel.Type)
+
+	channel, msg, prefs, err := d.resolveDeferred(ctx, n)
+	if err == nil && channel == nil {
+		log.Info("Deferred notification no longer wanted, dropped")
+		d.removeDeferred(ctx, log, n)
+		return
+	}
+	if err == nil {
+		if until, quiet := prefs.QuietUntil(now); quiet {
+			log.Info("Notification deferred until the end of quiet hours", "until", until)
+			if err := d.deferred.Add(ctx, n, until); err != nil {
+				log.Error("Error deferring notification", logging.Err(err))
+			}
+			return
+		}
+		err = d.notify(ctx, log, n.RecipientId, *channel, msg)
+	}
+	if err == nil {
+		d.removeDeferred(ctx, log, n)
+		return
+	}
+	if after, ok := notifier.RetryAfter(err); ok {
+		// the rate limit isn't a failure of the notification, it waits for the limit without spending an attempt
+		log.Info("Notification over the rate limit deferred", "until", now.Add(after))
+		if err := d.deferred.Add(ctx, n, now.Add(after)); err != nil {
+			log.Error("Error deferring notification", logging.Err(err))
+		}
+		return
+	}
+
+	retry := n
+	retry.Attempts++
+	if retry.Attempts >= maxDeferredAttempts {
+		log.Error("Deferred notification dropped", "attempts", retry.Attempts, logging.Err(err))
+		d.removeDeferred(ctx, log, n)
+		return
+	}
+
+	log.Warn("Failed to deliver deferred notification, retrying later", "attempts", retry.Attempts, logging.Err(err))
+	// the retry is added before the attempt is removed, a crash in between delivers it twice at worst
+	if err := d.deferred.Add(ctx, retry, now.Add(time.Duration(retry.Attempts)*deferredRetryDelay)); err != nil {
+		log.Error("Error deferring notification", logging.Err(err))
+		return
+	}
+	d.removeDeferred(ctx, log, n)
+}
+
+// resolveDeferred re-reads the recipient, their preferences and channels, as they may have changed since
+// the notification was deferred. It returns a nil channel when the notification isn't to be delivered through it anymore.
+func (d *Dispatcher) resolveDeferred(ctx context.Context, n deferred.Notification) (*models.Channel,
+	models.EventMessage, *preferences.Preferences, error) {
+	msg := n.Msg
+
+	recipient, err := d.users.GetUser(ctx, n.RecipientId)
+	if errors.Is(err, users.ErrUserNotFound) {
+		return nil, msg, nil, nil
+	}
+	if err != nil {
+		return nil, msg, nil, fmt.Errorf("get recipient: %w", err)
+	}
+
+	prefs, err := d.preferences.Get(ctx, recipient.Id)
+	if err != nil {
+		return nil, msg, nil, fmt.Errorf("get preferences: %w", err)
+	}
+	if !prefs.EventEnabled(msg.Type) {
+		return nil, msg, prefs, nil
+	}
+
+	channels, err := d.recipientChannels(ctx, recipient, prefs)
+	if err != nil {
+		return nil, msg, prefs, err
+	}
+	i := slices.IndexFunc(channels, func(c models.Channel) bool { return c.Type == n.Channel.Type })
+	if i < 0 {
+		return nil, msg, prefs, nil
+	}
+
+	msg.Username = recipient.Username
+	msg.Locale = recipient.Locale
+	return &channels[i], msg, prefs, nil
+}
+
+func (d *Dispatcher) removeDeferred(ctx context.Context, log *slog.Logger, n deferred.Notification) {
+	if err := d.deferred.Remove(ctx, n); err != nil {
+		log.Error("Error removing deferred notification", logging.Err(err))
+	}
+}
@@ -0,0 +1,213 @@
+// Package delivery delivers the task notifications to the users through their channels,
+// following their preferences.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/deferred"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/digest"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/notifier"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+)
+
+type UserDirectory interface {
+	GetUser(ctx context.Context, userId int64) (*models.User, error)
+}
+
+type ChannelStore interface {
+	List(ctx context.Context, userId int64) ([]models.Channel, error)
+}
+
+// Notification is a notification about a task event for the recipient, the names are filled in before it's sent.
+type Notification struct {
+	RecipientId int64
+	// a non-zero ActorId puts the name of the user who made the change into the notification
+	ActorId int64
+	Msg     models.EventMessage
+}
+
+type Dispatcher struct {
+	notifiers   map[string]notifier.Notifier
+	users       UserDirectory
+	channels    ChannelStore
+	preferences preferences.Store
+	deferred    deferred.Store
+	digests     digest.Store
+	dedup       dedup.Store
+	log         *slog.Logger
+	now         func() time.Time
+}
+
+func NewDispatcher(notifiers map[string]notifier.Notifier, users UserDirectory, channels ChannelStore,
+	preferences preferences.Store, deferred deferred.Store, digests digest.Store, dedup dedup.Store,
+	log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		notifiers:   notifiers,
+		users:       users,
+		channels:    channels,
+		preferences: preferences,
+		deferred:    deferred,
+		digests:     digests,
+		dedup:       dedup,
+		log:         log,
+		now:         time.Now,
+	}
+}
+
+// Dispatch delivers the notification through the email and the channels of the recipient they didn't disable,
+// once per channel. The email of a recipient who chose a digest is buffered for it, the reminders aside.
+// During the quiet hours of the recipient the notification is deferred until they end, and a channel
+// over its rate limit defers it until the limit refills.
+func (d *Dispatcher) Dispatch(ctx context.Context, n Notification) error {
+	log := d.log.With("event_id", n.Msg.EventId, "type", n.Msg.Type, "user_id", n.RecipientId)
+
+	recipient, err := d.users.GetUser(ctx, n.RecipientId)
+	if errors.Is(err, users.ErrUserNotFound) {
+		log.Warn("Recipient not found")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get recipient: %w", err)
+	}
+
+	prefs, err := d.preferences.Get(ctx, recipient.Id)
+	if err != nil {
+		return fmt.Errorf("get preferences: %w", err)
+	}
+	if !prefs.EventEnabled(n.Msg.Type) {
+		log.Debug("Notification disabled by recipient")
+		return nil
+	}
+
+	msg := n.Msg
+	msg.Username = recipient.Username
+	msg.Locale = recipient.Locale
+
+	if n.ActorId != 0 {
+		actor, err := d.users.GetUser(ctx, n.ActorId)
+		if err != nil && !errors.Is(err, users.ErrUserNotFound) {
+			return fmt.Errorf("get actor: %w", err)
+		}
+		if actor != nil {
+			msg.Actor = actor.Username
+		}
+	}
+
+	channels, err := d.recipientChannels(ctx, recipient, prefs)
+	if err != nil {
+		return err
+	}
+	if prefs.Digest != nil && msg.Type != models.EventTypeReminder {
+		channels, err = d.bufferDigest(ctx, recipient.Id, channels, msg)
+		if err != nil {
+			return err
+		}
+	}
+	if len(channels) == 0 {
+		log.Debug("Recipient without channels skipped")
+		return nil
+	}
+
+	if until, quiet := prefs.QuietUntil(d.now()); quiet {
+		for _, channel := range channels {
+			dn := deferred.Notification{RecipientId: recipient.Id, Channel: channel, Msg: msg}
+			if err := d.deferred.Add(ctx, dn, until); err != nil {
+				return fmt.Errorf("defer notification: %w", err)
+			}
+		}
+		log.Info("Notification deferred until the end of quiet hours", "until", until)
+		return nil
+	}
+
+	for _, channel := range channels {
+		log := log.With("channel", channel.Type)
+		err := d.notify(ctx, log, recipient.Id, channel, msg)
+		if after, ok := notifier.RetryAfter(err); ok {
+			until := d.now().Add(after)
+			dn := deferred.Notification{RecipientId: recipient.Id, Channel: channel, Msg: msg}
+			if err := d.deferred.Add(ctx, dn, until); err != nil {
+				return fmt.Errorf("defer notification: %w", err)
+			}
+			log.Info("Notification over the rate limit deferred", "until", until)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recipientChannels returns the email of the recipient and their channels, except for the disabled ones.
+func (d *Dispatcher) recipientChannels(ctx context.Context, recipient *models.User,
+	prefs *preferences.Preferences) ([]models.Channel, error) {
+	configured, err := d.channels.List(ctx, recipient.Id)
+	if err != nil {
+		return nil, fmt.Errorf("list channels: %w", err)
+	}
+
+	var channels []models.Channel
+	if recipient.Email != "" {
+		configured = append([]models.Channel{{Type: models.ChannelEmail, Target: recipient.Email}}, configured...)
+	}
+	for _, channel := range configured {
+		if prefs.ChannelEnabled(channel.Type) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels, nil
+}
+
+// bufferDigest buffers the notification for the digest when the recipient gets it by email,
+// and returns the channels it's still delivered through right away.
+func (d *Dispatcher) bufferDigest(ctx context.Context, recipientId int64, channels []models.Channel,
+	msg models.EventMessage) ([]models.Channel, error) {
+	i := slices.IndexFunc(channels, func(c models.Channel) bool { return c.Type == models.ChannelEmail })
+	if i < 0 {
+		return channels, nil
+	}
+
+	if err := d.digests.Add(ctx, recipientId, digest.NewEntry(msg, d.now())); err != nil {
+		return nil, fmt.Errorf("buffer digest entry: %w", err)
+	}
+	return slices.Delete(slices.Clone(channels), i, i+1), nil
+}
+
+// notify delivers the notification through the channel once. A channel which rejects it for good,
+// like a removed webhook, is skipped, so it doesn't hold back the other channels and recipients.
+func (d *Dispatcher) notify(ctx context.Context, log *slog.Logger, recipientId int64, channel models.Channel,
+	msg models.EventMessage) error {
+	n, ok := d.notifiers[channel.Type]
+	if !ok {
+		log.Warn("Channel without notifier skipped")
+		return nil
+	}
+
+	key := dedup.Key(msg.EventId, channel.Type, strconv.FormatInt(recipientId, 10))
+	sent, err := dedup.Once(ctx, d.dedup, key, func() error {
+		log.Info("Sending event notification")
+		return n.Notify(ctx, channel, msg)
+	})
+	if notifier.IsPermanent(err) {
+		log.Warn("Channel rejected event notification", logging.Err(err))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("notify through %s: %w", channel.Type, err)
+	}
+	if !sent {
+		log.Info("Duplicate event notification skipped")
+	}
+	return nil
+}
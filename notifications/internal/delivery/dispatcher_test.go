@@ -0,0 +1,384 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/channels"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/deferred"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/digest"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/notifier"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender struct {
+	sent []models.EventMessage
+}
+
+func (f *fakeSender) SendEventEmail(msg models.EventMessage) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// fakeNotifier records the notifications of a chat channel, failing with err when it's set.
+type fakeNotifier struct {
+	sent []models.Channel
+	err  error
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, channel)
+	return nil
+}
+
+type fakeDirectory struct {
+	users map[int64]*models.User
+	err   error
+}
+
+func (f *fakeDirectory) GetUser(ctx context.Context, userId int64) (*models.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	user, ok := f.users[userId]
+	if !ok {
+		return nil, users.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type testDispatcher struct {
+	*Dispatcher
+	sender      *fakeSender
+	channels    *channels.MemoryStore
+	preferences *preferences.MemoryStore
+	deferred    *deferred.MemoryStore
+	digests     *digest.MemoryStore
+}
+
+func newTestDispatcher() *testDispatcher {
+	sender := &fakeSender{}
+	directory := &fakeDirectory{users: map[int64]*models.User{
+		1: {Id: 1, Username: "alice", Email: "alice@example.com"},
+		2: {Id: 2, Username: "bob", Email: "bob@example.com", Locale: "en"},
+		3: {Id: 3, Username: "carol"},
+	}}
+	d := &testDispatcher{
+		sender:      sender,
+		channels:    channels.NewMemoryStore(),
+		preferences: preferences.NewMemoryStore(),
+		deferred:    deferred.NewMemoryStore(),
+		digests:     digest.NewMemoryStore(),
+	}
+	notifiers := map[string]notifier.Notifier{models.ChannelEmail: notifier.NewEmailNotifier(sender)}
+	d.Dispatcher = NewDispatcher(notifiers, directory, d.channels, d.preferences, d.deferred, d.digests,
+		dedup.NewMemoryStore(time.Hour), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return d
+}
+
+func notification(recipientId int64, eventType string) Notification {
+	return Notification{
+		RecipientId: recipientId,
+		Msg:         models.EventMessage{EventId: "event-1", TaskId: 10, Type: eventType, TaskText: "pay the bills"},
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	assign := notification(2, models.EventTypeAssign)
+	assign.ActorId = 1
+
+	tests := []struct {
+		name         string
+		notification Notification
+		expected     []models.EventMessage
+	}{
+		{
+			name:         "user with email",
+			notification: notification(1, models.EventTypeCreate),
+			expected: []models.EventMessage{
+				{EventId: "event-1", TaskId: 10, Email: "alice@example.com", Username: "alice", Type: models.EventTypeCreate, TaskText: "pay the bills"},
+			},
+		},
+		{
+			name:         "actor name",
+			notification: assign,
+			expected: []models.EventMessage{
+				{EventId: "event-1", TaskId: 10, Email: "bob@example.com", Username: "bob", Type: models.EventTypeAssign, TaskText: "pay the bills", Actor: "alice", Locale: "en"},
+			},
+		},
+		{
+			name:         "user without email",
+			notification: notification(3, models.EventTypeDelete),
+			expected:     nil,
+		},
+		{
+			name:         "unknown user",
+			notification: notification(404, models.EventTypePurge),
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDispatcher()
+			err := d.Dispatch(context.Background(), tt.notification)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d.sender.sent)
+		})
+	}
+}
+
+func TestDispatch_SkipsRedeliveredNotification(t *testing.T) {
+	d := newTestDispatcher()
+
+	require.NoError(t, d.Dispatch(context.Background(), notification(1, models.EventTypeCreate)))
+	require.NoError(t, d.Dispatch(context.Background(), notification(1, models.EventTypeCreate)))
+	require.NoError(t, d.Dispatch(context.Background(), notification(2, models.EventTypeCreate)))
+
+	if assert.Len(t, d.sender.sent, 2, "each recipient gets the event once") {
+		assert.Equal(t, "alice@example.com", d.sender.sent[0].Email)
+		assert.Equal(t, "bob@example.com", d.sender.sent[1].Email)
+	}
+}
+
+func TestDispatch_DirectoryError(t *testing.T) {
+	d := newTestDispatcher()
+	d.users = &fakeDirectory{err: errors.New("auth is down")}
+
+	err := d.Dispatch(context.Background(), notification(1, models.EventTypeCreate))
+
+	assert.Error(t, err, "the message is retried")
+	assert.Empty(t, d.sender.sent)
+}
+
+func TestDispatch_ConfiguredChannels(t *testing.T) {
+	d := newTestDispatcher()
+	slack := &fakeNotifier{}
+	d.notifiers[models.ChannelSlack] = slack
+
+	slackChannel := models.Channel{Type: models.ChannelSlack, Target: "https://hooks.slack.com/services/1"}
+	require.NoError(t, d.channels.Set(context.Background(), 3, slackChannel))
+	require.NoError(t, d.channels.Set(context.Background(), 3, models.Channel{Type: models.ChannelTelegram, Target: "42"}))
+
+	err := d.Dispatch(context.Background(), notification(3, models.EventTypeCreate))
+
+	require.NoError(t, err)
+	assert.Empty(t, d.sender.sent, "the user has no email")
+	assert.Equal(t, []models.Channel{slackChannel}, slack.sent, "the channel without notifier is skipped")
+}
+
+func TestDispatch_ChannelErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		retried bool
+	}{
+		{name: "rejected for good", err: &notifier.StatusError{Code: 404}, retried: false},
+		{name: "temporary failure", err: &notifier.StatusError{Code: 503}, retried: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDispatcher()
+			d.notifiers[models.ChannelWebhook] = &fakeNotifier{err: tt.err}
+			webhook := models.Channel{Type: models.ChannelWebhook, Target: "https://example.com/hook"}
+			require.NoError(t, d.channels.Set(context.Background(), 1, webhook))
+
+			err := d.Dispatch(context.Background(), notification(1, models.EventTypeCreate))
+
+			assert.Equal(t, tt.retried, err != nil)
+			assert.Len(t, d.sender.sent, 1, "the email is sent anyway")
+
+			// a retry doesn't send the delivered email again
+			_ = d.Dispatch(context.Background(), notification(1, models.EventTypeCreate))
+			assert.Len(t, d.sender.sent, 1)
+		})
+	}
+}
+
+func TestDispatch_RateLimitedChannel(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	webhook := &fakeNotifier{err: &email.RateLimitError{Wait: 10 * time.Minute}}
+	d.notifiers[models.ChannelWebhook] = webhook
+	require.NoError(t, d.channels.Set(ctx, 1, models.Channel{Type: models.ChannelWebhook, Target: "https://example.com/hook"}))
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)), "the rate limit isn't retried by Kafka")
+	assert.Len(t, d.sender.sent, 1, "the email is sent anyway")
+	assert.Equal(t, 1, d.deferred.Len(), "the notification is deferred until the limit refills")
+
+	for range maxDeferredAttempts {
+		now = now.Add(10 * time.Minute)
+		d.releaseDeferred(ctx)
+		assert.Equal(t, 1, d.deferred.Len(), "the rate limit doesn't spend the attempts")
+	}
+
+	webhook.err = nil
+	now = now.Add(10 * time.Minute)
+	d.releaseDeferred(ctx)
+	assert.Len(t, webhook.sent, 1)
+	assert.Equal(t, 0, d.deferred.Len())
+}
+
+func TestDispatch_Preferences(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	slack := &fakeNotifier{}
+	d.notifiers[models.ChannelSlack] = slack
+	require.NoError(t, d.channels.Set(ctx, 1, models.Channel{Type: models.ChannelSlack, Target: "https://hooks.slack.com/services/1"}))
+
+	require.NoError(t, d.preferences.Set(ctx, 1, &preferences.Preferences{
+		DisabledEvents:   []string{models.EventTypeUpdate},
+		DisabledChannels: []string{models.ChannelEmail},
+	}))
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeUpdate)))
+	assert.Empty(t, d.sender.sent)
+	assert.Empty(t, slack.sent, "the disabled event isn't delivered")
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)))
+	assert.Empty(t, d.sender.sent, "the disabled channel isn't used")
+	assert.Len(t, slack.sent, 1)
+}
+
+func TestDispatch_QuietHours(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	require.NoError(t, d.preferences.Set(ctx, 1, &preferences.Preferences{
+		QuietHours: &preferences.QuietHours{Start: "22:00", End: "08:00", Timezone: "UTC"},
+	}))
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)))
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)))
+	assert.Empty(t, d.sender.sent)
+	assert.Equal(t, 1, d.deferred.Len(), "the redelivered notification is deferred once")
+
+	now = time.Date(2026, 3, 2, 7, 59, 0, 0, time.UTC)
+	d.releaseDeferred(ctx)
+	assert.Empty(t, d.sender.sent, "the quiet hours aren't over")
+
+	now = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	d.releaseDeferred(ctx)
+	if assert.Len(t, d.sender.sent, 1) {
+		assert.Equal(t, "alice@example.com", d.sender.sent[0].Email)
+		assert.Equal(t, "alice", d.sender.sent[0].Username)
+	}
+	assert.Equal(t, 0, d.deferred.Len())
+
+	// the event is redelivered after the quiet hours
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)))
+	assert.Len(t, d.sender.sent, 1)
+}
+
+func TestDispatch_Digest(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	slack := &fakeNotifier{}
+	d.notifiers[models.ChannelSlack] = slack
+	require.NoError(t, d.channels.Set(ctx, 1, models.Channel{Type: models.ChannelSlack, Target: "https://hooks.slack.com/services/1"}))
+
+	require.NoError(t, d.preferences.Set(ctx, 1, &preferences.Preferences{
+		Digest: &preferences.Digest{Frequency: preferences.DigestDaily, Time: "09:00", Timezone: "UTC"},
+	}))
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeCreate)))
+	assert.Empty(t, d.sender.sent, "the email is buffered for the digest")
+	assert.Len(t, slack.sent, 1, "the chat channels get it right away")
+
+	entries, err := d.digests.Entries(ctx, 1)
+	require.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "event-1", entries[0].EventId)
+		assert.Equal(t, "pay the bills", entries[0].TaskText)
+	}
+
+	require.NoError(t, d.Dispatch(ctx, notification(1, models.EventTypeReminder)))
+	assert.Len(t, d.sender.sent, 1, "the reminders aren't buffered")
+}
+
+func TestReleaseDeferred_Retries(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	webhook := &fakeNotifier{err: &notifier.StatusError{Code: 503}}
+	d.notifiers[models.ChannelWebhook] = webhook
+	n := deferred.Notification{
+		RecipientId: 1,
+		Channel:     models.Channel{Type: models.ChannelWebhook, Target: "https://example.com/hook"},
+		Msg:         models.EventMessage{EventId: "event-1", Type: models.EventTypeCreate},
+	}
+	require.NoError(t, d.channels.Set(ctx, 1, n.Channel))
+	require.NoError(t, d.deferred.Add(ctx, n, now))
+
+	for attempt := 1; attempt < maxDeferredAttempts; attempt++ {
+		d.releaseDeferred(ctx)
+		assert.Equal(t, 1, d.deferred.Len(), "the failed notification is deferred again")
+		now = now.Add(time.Hour)
+	}
+
+	d.releaseDeferred(ctx)
+	assert.Equal(t, 0, d.deferred.Len(), "the notification is dropped after the last attempt")
+}
+
+func TestReleaseDeferred_RereadsRecipient(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDispatcher()
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	slack := &fakeNotifier{}
+	d.notifiers[models.ChannelSlack] = slack
+	email := deferred.Notification{
+		RecipientId: 1,
+		Channel:     models.Channel{Type: models.ChannelEmail, Target: "alice@example.com"},
+		Msg:         models.EventMessage{EventId: "event-1", Type: models.EventTypeCreate},
+	}
+	chat := deferred.Notification{
+		RecipientId: 1,
+		Channel:     models.Channel{Type: models.ChannelSlack, Target: "https://hooks.slack.com/services/1"},
+		Msg:         models.EventMessage{EventId: "event-2", Type: models.EventTypeCreate},
+	}
+	require.NoError(t, d.deferred.Add(ctx, email, now))
+	require.NoError(t, d.deferred.Add(ctx, chat, now))
+
+	// the events were turned off and the slack channel was removed meanwhile
+	require.NoError(t, d.preferences.Set(ctx, 1, &preferences.Preferences{
+		DisabledEvents: []string{models.EventTypeCreate},
+	}))
+	d.releaseDeferred(ctx)
+	assert.Empty(t, d.sender.sent)
+	assert.Empty(t, slack.sent)
+	assert.Equal(t, 0, d.deferred.Len(), "the unwanted notifications are dropped")
+
+	require.NoError(t, d.preferences.Set(ctx, 1, &preferences.Preferences{}))
+	require.NoError(t, d.deferred.Add(ctx, email, now))
+	require.NoError(t, d.deferred.Add(ctx, chat, now))
+	d.releaseDeferred(ctx)
+	if assert.Len(t, d.sender.sent, 1) {
+		assert.Equal(t, "alice", d.sender.sent[0].Username)
+	}
+	assert.Empty(t, slack.sent, "the removed channel is skipped")
+	assert.Equal(t, 0, d.deferred.Len())
+}
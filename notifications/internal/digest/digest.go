@@ -0,0 +1,108 @@
+// Package digest buffers the email notifications of the users who chose a digest and sends them
+// as a daily or weekly summary grouped by day.
+package digest
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// Entry is a buffered notification about a task event.
+type Entry struct {
+	EventId    string    `json:"event_id"`
+	TaskId     int64     `json:"task_id"`
+	Type       string    `json:"type"`
+	TaskText   string    `json:"task_text"`
+	TaskStatus string    `json:"task_status"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// when the entry was buffered, the digest is due at the first digest time after the oldest entry
+	BufferedAt time.Time `json:"buffered_at"`
+}
+
+// Store buffers the entries per recipient durably until their digest is sent.
+type Store interface {
+	// Add buffers the entry unless the entry of the same event and type is already buffered for the user.
+	Add(ctx context.Context, userId int64, entry Entry) error
+	// Users returns the users with buffered entries.
+	Users(ctx context.Context) ([]int64, error)
+	Entries(ctx context.Context, userId int64) ([]Entry, error)
+	// Remove removes the sent entries, the entries buffered meanwhile are kept for the next digest.
+	Remove(ctx context.Context, userId int64, entries []Entry) error
+}
+
+// NewEntry returns the entry of the notification buffered at the time.
+func NewEntry(msg models.EventMessage, now time.Time) Entry {
+	return Entry{
+		EventId:    msg.EventId,
+		TaskId:     msg.TaskId,
+		Type:       msg.Type,
+		TaskText:   msg.TaskText,
+		TaskStatus: msg.TaskStatus,
+		OccurredAt: msg.OccurredAt,
+		BufferedAt: now,
+	}
+}
+
+func (e Entry) key() string {
+	return e.EventId + ":" + e.Type
+}
+
+func (e Entry) time() time.Time {
+	if e.OccurredAt.IsZero() {
+		return e.BufferedAt
+	}
+	return e.OccurredAt
+}
+
+// groupByDay sorts the entries into the days of the location they happened on, the older days first.
+// A task is listed once per section of a day with its latest text.
+func groupByDay(entries []Entry, loc *time.Location) []models.DigestDay {
+	entries = slices.Clone(entries)
+	slices.SortStableFunc(entries, func(a, b Entry) int { return a.time().Compare(b.time()) })
+
+	var days []models.DigestDay
+	for _, e := range entries {
+		local := e.time().In(loc)
+		date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, models.DigestDay{Date: date})
+		}
+
+		day := &days[len(days)-1]
+		section := sectionOf(day, e)
+		if section == nil {
+			continue
+		}
+
+		task := models.DigestTask{TaskId: e.TaskId, Text: e.TaskText, Status: e.TaskStatus}
+		if i := slices.IndexFunc(*section, func(t models.DigestTask) bool { return t.TaskId == e.TaskId }); i >= 0 {
+			(*section)[i] = task
+		} else {
+			*section = append(*section, task)
+		}
+	}
+	return slices.DeleteFunc(days, func(d models.DigestDay) bool {
+		return len(d.Created)+len(d.Updated)+len(d.Completed)+len(d.Deleted) == 0
+	})
+}
+
+// sectionOf returns the section of the day the entry is listed in, nil for the entries left out of digests.
+func sectionOf(day *models.DigestDay, e Entry) *[]models.DigestTask {
+	switch e.Type {
+	case models.EventTypeCreate:
+		return &day.Created
+	case models.EventTypeUpdate, models.EventTypeRestore, models.EventTypeAssign:
+		return &day.Updated
+	case models.EventTypeStatus:
+		if e.TaskStatus == "done" {
+			return &day.Completed
+		}
+		return &day.Updated
+	case models.EventTypeDelete, models.EventTypePurge:
+		return &day.Deleted
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package digest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender struct {
+	sent []models.DigestMessage
+	err  error
+}
+
+func (f *fakeSender) SendDigestEmail(msg models.DigestMessage) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+type fakeDirectory struct {
+	users map[int64]*models.User
+}
+
+func (f *fakeDirectory) GetUser(ctx context.Context, userId int64) (*models.User, error) {
+	user, ok := f.users[userId]
+	if !ok {
+		return nil, users.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// testScheduler runs the scheduler on a clock set by the test.
+type testScheduler struct {
+	*Scheduler
+	store       *MemoryStore
+	preferences *preferences.MemoryStore
+	sender      *fakeSender
+	clock       time.Time
+}
+
+func newTestScheduler(t *testing.T) *testScheduler {
+	s := &testScheduler{
+		store:       NewMemoryStore(),
+		preferences: preferences.NewMemoryStore(),
+		sender:      &fakeSender{},
+		clock:       time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+	directory := &fakeDirectory{users: map[int64]*models.User{
+		1: {Id: 1, Username: "alice", Email: "alice@example.com"},
+		2: {Id: 2, Username: "bob"},
+	}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s.Scheduler = NewScheduler(s.store, s.preferences, directory, s.sender, dedup.NewMemoryStore(time.Hour), log)
+	s.Scheduler.now = func() time.Time { return s.clock }
+
+	daily := &preferences.Digest{Frequency: preferences.DigestDaily, Time: "09:00", Timezone: "Europe/Berlin"}
+	require.NoError(t, s.preferences.Set(context.Background(), 1, &preferences.Preferences{Digest: daily}))
+	return s
+}
+
+func (s *testScheduler) add(t *testing.T, userId int64, eventId, eventType string, taskId int64, status string, occurredAt time.Time) {
+	entry := Entry{EventId: eventId, TaskId: taskId, Type: eventType, TaskText: "task", TaskStatus: status,
+		OccurredAt: occurredAt, BufferedAt: s.clock}
+	require.NoError(t, s.store.Add(context.Background(), userId, entry))
+}
+
+func TestGroupByDay(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	day1 := time.Date(2026, 3, 3, 12, 0, 0, 0, berlin)
+	day2 := time.Date(2026, 3, 4, 0, 30, 0, 0, berlin)
+	entries := []Entry{
+		{EventId: "4", TaskId: 2, Type: models.EventTypeStatus, TaskText: "two", TaskStatus: "done", OccurredAt: day2},
+		{EventId: "1", TaskId: 1, Type: models.EventTypeCreate, TaskText: "one", TaskStatus: "todo", OccurredAt: day1},
+		{EventId: "2", TaskId: 1, Type: models.EventTypeUpdate, TaskText: "one v2", OccurredAt: day1.Add(time.Hour)},
+		{EventId: "3", TaskId: 1, Type: models.EventTypeUpdate, TaskText: "one v3", OccurredAt: day1.Add(2 * time.Hour)},
+		{EventId: "5", TaskId: 3, Type: models.EventTypePurge, TaskText: "three", OccurredAt: day2},
+		{EventId: "6", TaskId: 4, Type: models.EventTypeStatus, TaskText: "four", TaskStatus: "in_progress", OccurredAt: day2},
+		{EventId: "7", TaskId: 5, Type: models.EventTypeReminder, TaskText: "five", OccurredAt: day2},
+	}
+
+	days := groupByDay(entries, berlin)
+
+	require.Len(t, days, 2)
+	assert.True(t, days[0].Date.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, berlin)))
+	assert.Equal(t, []models.DigestTask{{TaskId: 1, Text: "one", Status: "todo"}}, days[0].Created)
+	assert.Equal(t, []models.DigestTask{{TaskId: 1, Text: "one v3"}}, days[0].Updated, "the task is listed once with its latest text")
+
+	assert.True(t, days[1].Date.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, berlin)), "the day is taken in the digest timezone")
+	assert.Equal(t, []models.DigestTask{{TaskId: 2, Text: "two", Status: "done"}}, days[1].Completed)
+	assert.Equal(t, []models.DigestTask{{TaskId: 4, Text: "four", Status: "in_progress"}}, days[1].Updated)
+	assert.Equal(t, []models.DigestTask{{TaskId: 3, Text: "three"}}, days[1].Deleted)
+	assert.Empty(t, days[1].Created)
+}
+
+func TestSchedulerSendsDailyDigest(t *testing.T) {
+	ctx := context.Background()
+	s := newTestScheduler(t)
+
+	// 11:00 in Berlin, the next digest is sent at 09:00 tomorrow
+	s.add(t, 1, "1", models.EventTypeCreate, 1, "todo", s.clock)
+	s.add(t, 1, "1", models.EventTypeCreate, 1, "todo", s.clock)
+	s.add(t, 1, "2", models.EventTypeStatus, 1, "done", s.clock.Add(time.Minute))
+
+	s.sendDue(ctx)
+	assert.Empty(t, s.sender.sent, "the digest isn't due yet")
+
+	s.clock = time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)
+	s.sendDue(ctx)
+
+	require.Len(t, s.sender.sent, 1)
+	msg := s.sender.sent[0]
+	assert.Equal(t, "alice@example.com", msg.Email)
+	assert.Equal(t, "alice", msg.Username)
+	assert.Equal(t, preferences.DigestDaily, msg.Frequency)
+	require.Len(t, msg.Days, 1)
+	assert.Len(t, msg.Days[0].Created, 1)
+	assert.Len(t, msg.Days[0].Completed, 1)
+
+	userIds, err := s.store.Users(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, userIds, "the sent entries are removed")
+
+	s.sendDue(ctx)
+	assert.Len(t, s.sender.sent, 1)
+}
+
+func TestSchedulerRetriesFailedDigest(t *testing.T) {
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	s.add(t, 1, "1", models.EventTypeCreate, 1, "todo", s.clock)
+
+	s.clock = s.clock.Add(24 * time.Hour)
+	s.sender.err = assert.AnError
+	s.sendDue(ctx)
+	assert.Empty(t, s.sender.sent)
+
+	s.sender.err = nil
+	s.sendDue(ctx)
+	assert.Len(t, s.sender.sent, 1, "the failed digest is sent on the next run")
+}
+
+func TestSchedulerSendsRightAwayWhenDigestTurnedOff(t *testing.T) {
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	s.add(t, 1, "1", models.EventTypeCreate, 1, "todo", s.clock)
+	require.NoError(t, s.preferences.Set(ctx, 1, &preferences.Preferences{}))
+
+	s.sendDue(ctx)
+
+	require.Len(t, s.sender.sent, 1)
+	assert.Empty(t, s.sender.sent[0].Frequency)
+}
+
+func TestSchedulerDropsEntriesOfUserWithoutEmail(t *testing.T) {
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	s.add(t, 2, "1", models.EventTypeCreate, 1, "todo", s.clock)
+	s.add(t, 3, "2", models.EventTypeCreate, 2, "todo", s.clock)
+
+	s.sendDue(ctx)
+
+	assert.Empty(t, s.sender.sent)
+	userIds, err := s.store.Users(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, userIds)
+}
@@ -0,0 +1,66 @@
+package digest
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore keeps the buffered entries in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[int64]map[string]Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[int64]map[string]Entry)}
+}
+
+func (m *MemoryStore) Add(ctx context.Context, userId int64, entry Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.entries[userId] == nil {
+		m.entries[userId] = make(map[string]Entry)
+	}
+	if _, ok := m.entries[userId][entry.key()]; !ok {
+		m.entries[userId][entry.key()] = entry
+	}
+	return nil
+}
+
+func (m *MemoryStore) Users(ctx context.Context) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userIds := make([]int64, 0, len(m.entries))
+	for userId := range m.entries {
+		userIds = append(userIds, userId)
+	}
+	sort.Slice(userIds, func(i, j int) bool { return userIds[i] < userIds[j] })
+	return userIds, nil
+}
+
+func (m *MemoryStore) Entries(ctx context.Context, userId int64) ([]Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]Entry, 0, len(m.entries[userId]))
+	for _, entry := range m.entries[userId] {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (m *MemoryStore) Remove(ctx context.Context, userId int64, entries []Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range entries {
+		delete(m.entries[userId], entry.key())
+	}
+	if len(m.entries[userId]) == 0 {
+		delete(m.entries, userId)
+	}
+	return nil
+}
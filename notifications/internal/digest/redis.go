@@ -0,0 +1,99 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisPrefix   = "digest:"
+	redisUsersKey = "digest-users"
+)
+
+// removeScript removes the entries and drops the user from the pending users when none is left,
+// atomically, so an entry buffered meanwhile doesn't lose its user.
+var removeScript = redis.NewScript(`
+redis.call('HDEL', KEYS[1], unpack(ARGV, 2))
+if redis.call('HLEN', KEYS[1]) == 0 then
+	redis.call('SREM', KEYS[2], ARGV[1])
+end
+return 0
+`)
+
+// RedisStore keeps the entries of a user in a hash keyed by the event, and the users with entries in a set.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Add(ctx context.Context, userId int64, entry Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSetNX(ctx, redisKey(userId), entry.key(), value)
+		pipe.SAdd(ctx, redisUsersKey, userId)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisStore) Users(ctx context.Context) ([]int64, error) {
+	members, err := r.client.SMembers(ctx, redisUsersKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]int64, 0, len(members))
+	for _, m := range members {
+		userId, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed digest user %q: %w", m, err)
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, nil
+}
+
+func (r *RedisStore) Entries(ctx context.Context, userId int64) ([]Entry, error) {
+	fields, err := r.client.HGetAll(ctx, redisKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(fields))
+	for key, value := range fields {
+		var entry Entry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			return nil, fmt.Errorf("unmarshal digest entry %s: %w", key, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *RedisStore) Remove(ctx context.Context, userId int64, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(entries)+1)
+	args = append(args, userId)
+	for _, e := range entries {
+		args = append(args, e.key())
+	}
+	return removeScript.Run(ctx, r.client, []string{redisKey(userId), redisUsersKey}, args...).Err()
+}
+
+func redisKey(userId int64) string {
+	return redisPrefix + strconv.FormatInt(userId, 10)
+}
@@ -0,0 +1,157 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/users"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+)
+
+type DigestSender interface {
+	SendDigestEmail(msg models.DigestMessage) error
+}
+
+type UserDirectory interface {
+	GetUser(ctx context.Context, userId int64) (*models.User, error)
+}
+
+// Scheduler sends the buffered entries of the users as digests when their digest time comes.
+type Scheduler struct {
+	store       Store
+	preferences preferences.Store
+	users       UserDirectory
+	sender      DigestSender
+	dedup       dedup.Store
+	log         *slog.Logger
+	now         func() time.Time
+}
+
+func NewScheduler(store Store, preferences preferences.Store, users UserDirectory, sender DigestSender,
+	dedup dedup.Store, log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		store:       store,
+		preferences: preferences,
+		users:       users,
+		sender:      sender,
+		dedup:       dedup,
+		log:         log,
+		now:         time.Now,
+	}
+}
+
+// Run sends the due digests, checking them every interval until the context is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.sendDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue sends the digests of the users whose digest time passed since their oldest entry.
+// A failed digest is kept and retried on the next run.
+func (s *Scheduler) sendDue(ctx context.Context) {
+	userIds, err := s.store.Users(ctx)
+	if err != nil {
+		s.log.Error("Error listing digest users", logging.Err(err))
+		return
+	}
+
+	for _, userId := range userIds {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log := s.log.With("user_id", userId)
+		if err := s.sendUserDigest(ctx, log, userId); err != nil {
+			log.Error("Error sending digest", logging.Err(err))
+		}
+	}
+}
+
+func (s *Scheduler) sendUserDigest(ctx context.Context, log *slog.Logger, userId int64) error {
+	entries, err := s.store.Entries(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("get entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	prefs, err := s.preferences.Get(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("get preferences: %w", err)
+	}
+
+	// a user who turned the digest off gets the collected entries right away
+	now := s.now()
+	slot := now
+	loc := time.UTC
+	if d := prefs.Digest; d != nil {
+		oldest := entries[0].BufferedAt
+		for _, e := range entries[1:] {
+			if e.BufferedAt.Before(oldest) {
+				oldest = e.BufferedAt
+			}
+		}
+
+		next, ok := d.NextAfter(oldest)
+		if ok && next.After(now) {
+			return nil
+		}
+		if ok {
+			slot = next
+			loc, _ = time.LoadLocation(d.Timezone)
+		}
+	}
+
+	recipient, err := s.users.GetUser(ctx, userId)
+	if errors.Is(err, users.ErrUserNotFound) || (err == nil && recipient.Email == "") {
+		log.Warn("Digest recipient without email, entries dropped", "entries", len(entries))
+		return s.store.Remove(ctx, userId, entries)
+	}
+	if err != nil {
+		return fmt.Errorf("get recipient: %w", err)
+	}
+
+	msg := models.DigestMessage{
+		Email:    recipient.Email,
+		Username: recipient.Username,
+		Locale:   recipient.Locale,
+		Days:     groupByDay(entries, loc),
+	}
+	if prefs.Digest != nil {
+		msg.Frequency = prefs.Digest.Frequency
+	}
+
+	if len(msg.Days) > 0 {
+		key := dedup.Key("digest-"+slot.UTC().Format(time.RFC3339), models.ChannelEmail, strconv.FormatInt(userId, 10))
+		sent, err := dedup.Once(ctx, s.dedup, key, func() error {
+			log.Info("Sending digest", "entries", len(entries))
+			return s.sender.SendDigestEmail(msg)
+		})
+		if err != nil {
+			return err
+		}
+		if !sent {
+			log.Info("Duplicate digest skipped")
+		}
+	}
+
+	return s.store.Remove(ctx, userId, entries)
+}
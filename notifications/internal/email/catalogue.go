@@ -0,0 +1,135 @@
+package email
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Names of the templates, each is the "<name>.html" file of a locale directory.
+const (
+	templateVerification = "email_verification"
+	templateEvent        = "event"
+	templateAssignment   = "assignment"
+	templateReminder     = "reminder"
+	templateDigest       = "digest"
+)
+
+// Keys of the messages.json file of a locale directory.
+const (
+	subjectVerification = "verification_subject"
+	subjectEvent        = "event_subject"
+	subjectAssignment   = "assignment_subject"
+	subjectReminder     = "reminder_subject"
+	subjectDigest       = "digest_subject"
+)
+
+var (
+	templateNames = []string{templateVerification, templateEvent, templateAssignment, templateReminder, templateDigest}
+	messageKeys   = []string{subjectVerification, subjectEvent, subjectAssignment, subjectReminder, subjectDigest}
+)
+
+// catalogue holds the templates and the messages of the locales found in the directories of templates/,
+// like templates/en/event.html and templates/en/messages.json. Whatever a locale lacks is taken
+// from the fallback locale, which must have everything.
+type catalogue struct {
+	locales  map[string]*locale
+	fallback string
+}
+
+type locale struct {
+	templates map[string]*template.Template
+	messages  map[string]string
+}
+
+func loadCatalogue(fsys fs.FS, fallback string) (*catalogue, error) {
+	dirs, err := fs.ReadDir(fsys, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &catalogue{locales: make(map[string]*locale), fallback: normalizeLocale(fallback)}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		l, err := loadLocale(fsys, path.Join("templates", dir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("locale %s: %w", dir.Name(), err)
+		}
+		c.locales[normalizeLocale(dir.Name())] = l
+	}
+
+	l, ok := c.locales[c.fallback]
+	if !ok {
+		return nil, fmt.Errorf("fallback locale %q not found", fallback)
+	}
+	for _, name := range templateNames {
+		if l.templates[name] == nil {
+			return nil, fmt.Errorf("fallback locale %q lacks the %s template", fallback, name)
+		}
+	}
+	for _, key := range messageKeys {
+		if l.messages[key] == "" {
+			return nil, fmt.Errorf("fallback locale %q lacks the %s message", fallback, key)
+		}
+	}
+	return c, nil
+}
+
+func loadLocale(fsys fs.FS, dir string) (*locale, error) {
+	l := &locale{templates: make(map[string]*template.Template), messages: make(map[string]string)}
+
+	files, err := fs.Glob(fsys, path.Join(dir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		tmpl, err := template.ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", path.Base(file), err)
+		}
+		l.templates[strings.TrimSuffix(path.Base(file), ".html")] = tmpl
+	}
+
+	data, err := fs.ReadFile(fsys, path.Join(dir, "messages.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.messages); err != nil {
+		return nil, fmt.Errorf("failed to parse messages: %w", err)
+	}
+	return l, nil
+}
+
+// template returns the template of the locale, or of the fallback locale when the locale lacks it.
+func (c *catalogue) template(localeName, name string) *template.Template {
+	if l, ok := c.locales[normalizeLocale(localeName)]; ok && l.templates[name] != nil {
+		return l.templates[name]
+	}
+	return c.locales[c.fallback].templates[name]
+}
+
+// message returns the message of the locale, or of the fallback locale when the locale lacks it.
+func (c *catalogue) message(localeName, key string) string {
+	if l, ok := c.locales[normalizeLocale(localeName)]; ok && l.messages[key] != "" {
+		return l.messages[key]
+	}
+	return c.locales[c.fallback].messages[key]
+}
+
+// normalizeLocale reduces a locale like "en-US" or "en_GB" to its language.
+func normalizeLocale(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "-_"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogue_EmbeddedLocales(t *testing.T) {
+	c, err := loadCatalogue(templateFS, "ru")
+	require.NoError(t, err)
+
+	dueAt := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	data := map[string]any{
+		templateVerification: models.EmailVerificationMessage{Username: "alice", Code: "1234"},
+		templateEvent:        models.EventMessage{Username: "alice", Type: models.EventTypeStatus, TaskStatus: "done", TaskText: "pay"},
+		templateAssignment:   models.EventMessage{Username: "alice", Actor: "bob", TaskText: "pay", TaskDueAt: &dueAt},
+		templateReminder:     models.EventMessage{Username: "alice", TaskText: "pay", TaskDueAt: &dueAt},
+		templateDigest: models.DigestMessage{Username: "alice", Frequency: "daily", Days: []models.DigestDay{
+			{Date: dueAt, Created: []models.DigestTask{{TaskId: 1, Text: "pay"}}},
+		}},
+	}
+
+	for _, locale := range []string{"ru", "en"} {
+		require.Contains(t, c.locales, locale)
+		for _, name := range templateNames {
+			require.NotNil(t, c.locales[locale].templates[name], "%s lacks %s", locale, name)
+
+			var buf bytes.Buffer
+			require.NoError(t, c.template(locale, name).Execute(&buf, data[name]), "%s/%s", locale, name)
+			assert.Contains(t, buf.String(), "alice")
+		}
+		for _, key := range messageKeys {
+			assert.NotEmpty(t, c.locales[locale].messages[key], "%s lacks %s", locale, key)
+		}
+	}
+
+	assert.Equal(t, "Task reminder", c.message("en-US", subjectReminder))
+	assert.Equal(t, "Напоминание о задаче", c.message("de", subjectReminder), "unknown locales fall back")
+	assert.Equal(t, "Напоминание о задаче", c.message("", subjectReminder))
+}
+
+func TestCatalogue_Fallback(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/ru/event.html":      {Data: []byte("Событие")},
+		"templates/ru/messages.json":   {Data: []byte(`{"event_subject": "Уведомление", "reminder_subject": "Напоминание"}`)},
+		"templates/en/event.html":      {Data: []byte("Event")},
+		"templates/en/messages.json":   {Data: []byte(`{"event_subject": "Notification"}`)},
+		"templates/ru/reminder.html":   {Data: []byte("Напоминание")},
+		"templates/ru/assignment.html": {Data: []byte("Назначение")},
+	}
+	c, err := loadCatalogue(fsys, "ru")
+	require.Error(t, err, "the fallback locale must have every template and message")
+	assert.Nil(t, c)
+
+	for _, name := range templateNames {
+		fsys["templates/ru/"+name+".html"] = &fstest.MapFile{Data: []byte(name)}
+	}
+	fsys["templates/ru/messages.json"] = &fstest.MapFile{Data: []byte(`{
+		"verification_subject": "Подтверждение", "event_subject": "Уведомление", "assignment_subject": "Назначение",
+		"reminder_subject": "Напоминание", "digest_subject": "Сводка"}`)}
+	c, err = loadCatalogue(fsys, "ru")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.template("en", templateEvent).Execute(&buf, nil))
+	assert.Equal(t, "Event", buf.String())
+	assert.Equal(t, "Notification", c.message("en", subjectEvent))
+
+	buf.Reset()
+	require.NoError(t, c.template("en", templateReminder).Execute(&buf, nil))
+	assert.Equal(t, templateReminder, buf.String(), "the missing template is taken from the fallback locale")
+	assert.Equal(t, "Напоминание", c.message("en", subjectReminder), "the missing message is taken from the fallback locale")
+
+	_, err = loadCatalogue(fsys, "de")
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dkimSigner signs the messages with rsa-sha256 and the relaxed/relaxed canonicalization of RFC 6376.
+type dkimSigner struct {
+	domain   string
+	selector string
+	key      *rsa.PrivateKey
+	now      func() time.Time
+}
+
+// newDKIMSigner reads the PEM encoded RSA key, in the PKCS #1 or PKCS #8 form, from the file.
+func newDKIMSigner(domain, selector, keyFile string) (*dkimSigner, error) {
+	if domain == "" || selector == "" {
+		return nil, errors.New("dkim domain and selector are required")
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parseRSAKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dkimSigner{domain: domain, selector: selector, key: key, now: time.Now}, nil
+}
+
+func parseRSAKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block in dkim key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse dkim key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("dkim key isn't an RSA key")
+	}
+	return key, nil
+}
+
+// sign returns the DKIM-Signature header of the message covering all of its headers.
+func (s *dkimSigner) sign(headers []header, body []byte) (header, error) {
+	bodyHash := sha256.Sum256(relaxedBody(body))
+
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		names = append(names, strings.ToLower(h.name))
+	}
+
+	tags := []string{
+		"v=1",
+		"a=rsa-sha256",
+		"c=relaxed/relaxed",
+		"d=" + s.domain,
+		"s=" + s.selector,
+		"t=" + strconv.FormatInt(s.now().Unix(), 10),
+		"h=" + strings.Join(names, ":"),
+		"bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]),
+		"b=",
+	}
+	signature := header{name: "DKIM-Signature", value: strings.Join(tags, "; ")}
+
+	// the signature header is hashed last, with an empty b= and without the trailing CRLF
+	hash := sha256.New()
+	for _, h := range headers {
+		hash.Write([]byte(relaxedHeader(h.name, h.value)))
+	}
+	hash.Write([]byte(strings.TrimSuffix(relaxedHeader(signature.name, signature.value), "\r\n")))
+
+	b, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hash.Sum(nil))
+	if err != nil {
+		return header{}, err
+	}
+	signature.value += base64.StdEncoding.EncodeToString(b)
+	return signature, nil
+}
+
+// relaxedHeader canonicalizes the header field with the relaxed algorithm: the name is lowercased,
+// the value is unfolded and the runs of whitespace are reduced to a single space.
+func relaxedHeader(name, value string) string {
+	value = strings.NewReplacer("\r\n", "", "\n", "").Replace(value)
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.Join(strings.Fields(value), " ") + "\r\n"
+}
+
+// relaxedBody canonicalizes the body with the relaxed algorithm: the runs of whitespace in the lines
+// are reduced to a single space, the trailing whitespace and the trailing empty lines are removed.
+func relaxedBody(body []byte) []byte {
+	lines := bytes.Split(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n")), []byte("\n"))
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		line = bytes.TrimRight(line, " \t")
+		fields := bytes.FieldsFunc(line, func(r rune) bool { return r == ' ' || r == '\t' })
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			buf.WriteByte(' ')
+		}
+		buf.Write(bytes.Join(fields, []byte(" ")))
+		buf.WriteString("\r\n")
+	}
+
+	canonical := bytes.TrimRight(buf.Bytes(), "\r\n")
+	if len(canonical) == 0 {
+		return nil
+	}
+	return append(canonical, '\r', '\n')
+}
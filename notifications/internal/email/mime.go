@@ -0,0 +1,156 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// maxLineLength is the length the header lines are folded at, RFC 5322 recommends 78 characters.
+const maxLineLength = 78
+
+// message is an email with an HTML body and its plain text version, written as multipart/alternative.
+type message struct {
+	From      mail.Address
+	To        string
+	Subject   string
+	Date      time.Time
+	MessageID string
+	// URIs of the List-Unsubscribe header, the header is omitted when empty. An HTTPS URI also
+	// adds the List-Unsubscribe-Post header of the RFC 8058 one-click unsubscription.
+	ListUnsubscribe []string
+	Text            string
+	HTML            string
+}
+
+// header is a header field of the message, the value is encoded and ready to be written.
+type header struct {
+	name  string
+	value string
+}
+
+// headers returns the header fields of the message in the order they are written.
+func (m *message) headers() []header {
+	headers := []header{
+		{"From", m.From.String()},
+		{"To", (&mail.Address{Address: m.To}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", m.Date.Format(time.RFC1123Z)},
+		{"Message-ID", m.MessageID},
+	}
+	if len(m.ListUnsubscribe) > 0 {
+		uris := make([]string, len(m.ListUnsubscribe))
+		oneClick := false
+		for i, uri := range m.ListUnsubscribe {
+			uris[i] = "<" + uri + ">"
+			oneClick = oneClick || strings.HasPrefix(uri, "https://")
+		}
+		headers = append(headers, header{"List-Unsubscribe", strings.Join(uris, ", ")})
+		if oneClick {
+			headers = append(headers, header{"List-Unsubscribe-Post", "List-Unsubscribe=One-Click"})
+		}
+	}
+	return append(headers, header{"MIME-Version", "1.0"})
+}
+
+// body writes the multipart/alternative body, the plain text part first as the least preferred one.
+// It returns the Content-Type of the body with its boundary.
+func (m *message) body(w io.Writer) (string, error) {
+	mw := multipart.NewWriter(w)
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := io.WriteString(qw, part.content); err != nil {
+			return "", err
+		}
+		if err := qw.Close(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": mw.Boundary()}), nil
+}
+
+// write returns the message with CRLF line endings. The signer, when it's set, adds a DKIM-Signature header.
+func (m *message) write(signer *dkimSigner) ([]byte, error) {
+	var body bytes.Buffer
+	contentType, err := m.body(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := append(m.headers(), header{"Content-Type", contentType})
+	if signer != nil {
+		signature, err := signer.sign(headers, body.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("dkim sign: %w", err)
+		}
+		headers = append([]header{signature}, headers...)
+	}
+
+	var buf bytes.Buffer
+	for _, h := range headers {
+		buf.WriteString(foldHeader(h.name, h.value))
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// foldHeader returns the header line ending with CRLF, folded at the spaces to keep the lines short.
+// A word longer than a line is kept whole.
+func foldHeader(name, value string) string {
+	var b strings.Builder
+	b.WriteString(name + ":")
+	lineLength := len(name) + 1
+
+	for i, word := range strings.Split(value, " ") {
+		if i > 0 && lineLength+1+len(word) > maxLineLength {
+			b.WriteString("\r\n")
+			lineLength = 0
+		}
+		b.WriteString(" " + word)
+		lineLength += 1 + len(word)
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// newMessageID returns a random Message-ID in the domain.
+func newMessageID(domain string) string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return "<" + hex.EncodeToString(b[:]) + "@" + domain + ">"
+}
+
+// addressDomain returns the domain of the email address.
+func addressDomain(address string) string {
+	_, domain, _ := strings.Cut(address, "@")
+	if domain == "" {
+		return "localhost"
+	}
+	return domain
+}
@@ -0,0 +1,186 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() *message {
+	return &message{
+		From:            mail.Address{Name: "Задачи", Address: "tasks@example.com"},
+		To:              "alice@example.com",
+		Subject:         "Напоминание о задаче «оплатить счета» до конца недели, пожалуйста",
+		Date:            time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC),
+		MessageID:       "<1@example.com>",
+		ListUnsubscribe: []string{"https://example.com/unsubscribe?token=abc", "mailto:unsubscribe@example.com"},
+		Text:            "Здравствуйте, alice!\n",
+		HTML:            "<p>Здравствуйте, <strong>alice</strong>!</p>",
+	}
+}
+
+func TestMessageWrite(t *testing.T) {
+	raw, err := testMessage().write(nil)
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		assert.LessOrEqual(t, len(line), 998)
+		assert.NotContains(t, line, "\n", "the lines end with CRLF")
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, testMessage().Subject, subject)
+	assert.NotContains(t, parsed.Header.Get("Subject"), "Напоминание", "the header is encoded")
+
+	from, err := parsed.Header.AddressList("From")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Задачи", Address: "tasks@example.com"}}, from)
+	assert.Equal(t, "<1@example.com>", parsed.Header.Get("Message-ID"))
+	assert.Equal(t, "<https://example.com/unsubscribe?token=abc>, <mailto:unsubscribe@example.com>",
+		parsed.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", parsed.Header.Get("List-Unsubscribe-Post"))
+	date, err := parsed.Header.Date()
+	require.NoError(t, err)
+	assert.True(t, date.Equal(testMessage().Date))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	var contentTypes []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, string(content))
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, contentTypes)
+	assert.Equal(t, []string{"Здравствуйте, alice!\r\n", testMessage().HTML}, parts)
+}
+
+func TestMessageWrite_WithoutListUnsubscribe(t *testing.T) {
+	msg := testMessage()
+	msg.ListUnsubscribe = nil
+
+	raw, err := msg.write(nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "List-Unsubscribe")
+}
+
+func TestMessageWrite_MailtoListUnsubscribe(t *testing.T) {
+	msg := testMessage()
+	msg.ListUnsubscribe = []string{"mailto:unsubscribe@example.com"}
+
+	raw, err := msg.write(nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "List-Unsubscribe: <mailto:unsubscribe@example.com>\r\n")
+	assert.NotContains(t, string(raw), "List-Unsubscribe-Post", "one-click needs an HTTPS URI")
+}
+
+func TestFoldHeader(t *testing.T) {
+	folded := foldHeader("Subject", strings.Repeat("word ", 30)+strings.Repeat("x", 100))
+
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines[1:] {
+		assert.True(t, strings.HasPrefix(line, " "), "the continuation lines start with a space")
+	}
+	for _, line := range lines[:len(lines)-1] {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+	}
+	assert.Equal(t, "Subject: "+strings.Repeat("word ", 30)+strings.Repeat("x", 100), strings.ReplaceAll(folded[:len(folded)-2], "\r\n", ""))
+}
+
+func TestRelaxedCanonicalization(t *testing.T) {
+	// the example of RFC 6376, section 3.4.5
+	assert.Equal(t, "a:X\r\n", relaxedHeader("A", " X\r\n"))
+	assert.Equal(t, "b:Y Z\r\n", relaxedHeader("B ", " Y\t\r\n\tZ  "))
+	assert.Equal(t, " C\r\nD E\r\n", string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+	assert.Empty(t, relaxedBody([]byte("\r\n\r\n")))
+}
+
+func TestMessageWrite_DKIM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "dkim.pem")
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(keyFile, keyPem, 0o600))
+
+	signer, err := newDKIMSigner("example.com", "mail", keyFile)
+	require.NoError(t, err)
+
+	raw, err := testMessage().write(signer)
+	require.NoError(t, err)
+
+	// verify the signature as a receiver would, from the written message
+	head, body, ok := strings.Cut(string(raw), "\r\n\r\n")
+	require.True(t, ok)
+	fields := splitHeaderFields(head)
+	require.Equal(t, "DKIM-Signature", fields[0][0])
+
+	tags := map[string]string{}
+	for _, tag := range strings.Split(fields[0][1], ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[name] = strings.Join(strings.Fields(value), "")
+	}
+	assert.Equal(t, "example.com", tags["d"])
+	assert.Equal(t, "mail", tags["s"])
+	assert.Equal(t, "from:to:subject:date:message-id:list-unsubscribe:list-unsubscribe-post:mime-version:content-type", tags["h"])
+
+	bodyHash := sha256.Sum256(relaxedBody([]byte(body)))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(bodyHash[:]), tags["bh"])
+
+	hash := sha256.New()
+	for _, field := range fields[1:] {
+		hash.Write([]byte(relaxedHeader(field[0], field[1])))
+	}
+	unsigned := fields[0][1][:strings.LastIndex(fields[0][1], "b=")+2]
+	hash.Write([]byte(strings.TrimSuffix(relaxedHeader(fields[0][0], unsigned), "\r\n")))
+
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	require.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash.Sum(nil), signature))
+}
+
+// splitHeaderFields returns the name and the raw value of the header fields, the folded lines included.
+func splitHeaderFields(head string) [][2]string {
+	var fields [][2]string
+	for _, line := range strings.Split(head, "\r\n") {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			fields[len(fields)-1][1] += "\r\n" + line
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields = append(fields, [2]string{name, value})
+	}
+	return fields
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrRecipientRateLimited = errors.New("recipient rate limit exceeded")
+
+// RateLimitError is returned for an email over the limit of the recipient, it can be sent once the limit refills.
+type RateLimitError struct {
+	Wait time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrRecipientRateLimited, e.Wait)
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRecipientRateLimited }
+
+// RetryAfter returns the time until the recipient gets a token back.
+func (e *RateLimitError) RetryAfter() time.Duration { return e.Wait }
+
+// maxTrackedRecipients bounds the recipients the limiter keeps, the ones with a full bucket are forgotten first.
+const maxTrackedRecipients = 10000
+
+// recipientLimiter is a token bucket per recipient allowing limit emails per interval,
+// a recipient who got no email for a while may get limit emails in a row.
+type recipientLimiter struct {
+	mu       sync.Mutex
+	limit    float64
+	interval time.Duration
+	buckets  map[string]*bucket
+	now      func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newRecipientLimiter returns nil, which allows every email, when the limit is disabled.
+func newRecipientLimiter(limit int, interval time.Duration) *recipientLimiter {
+	if limit <= 0 || interval <= 0 {
+		return nil
+	}
+	return &recipientLimiter{
+		limit:    float64(limit),
+		interval: interval,
+		buckets:  make(map[string]*bucket),
+		now:      time.Now,
+	}
+}
+
+// allow takes a token of the recipient. When they have none left it returns false
+// with the time until they get one back.
+func (l *recipientLimiter) allow(recipient string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	recipient = strings.ToLower(recipient)
+	b, ok := l.buckets[recipient]
+	if !ok {
+		if len(l.buckets) >= maxTrackedRecipients {
+			l.forgetFull(now)
+		}
+		b = &bucket{tokens: l.limit, updated: now}
+		l.buckets[recipient] = b
+	}
+
+	b.tokens = min(l.limit, b.tokens+l.refill(now.Sub(b.updated)))
+	b.updated = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) * float64(l.interval) / l.limit)
+	}
+	b.tokens--
+	return true, 0
+}
+
+// giveBack returns the token taken for an email which wasn't sent, so only the sent emails count.
+func (l *recipientLimiter) giveBack(recipient string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[strings.ToLower(recipient)]; ok {
+		b.tokens = min(l.limit, b.tokens+1)
+	}
+}
+
+func (l *recipientLimiter) refill(elapsed time.Duration) float64 {
+	return l.limit * float64(elapsed) / float64(l.interval)
+}
+
+// forgetFull removes the buckets refilled by now, they are the same as the new ones.
+func (l *recipientLimiter) forgetFull(now time.Time) {
+	for recipient, b := range l.buckets {
+		if b.tokens+l.refill(now.Sub(b.updated)) >= l.limit {
+			delete(l.buckets, recipient)
+		}
+	}
+}
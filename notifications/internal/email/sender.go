@@ -1,77 +1,197 @@
 package email
 
 import (
+	"context"
 	"embed"
+	"errors"
 	"fmt"
-	"html/template"
 	"log/slog"
-	"net/smtp"
+	"net/mail"
+	"time"
 
 	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/unsubscribe"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
 )
 
 //go:embed templates
 var templateFS embed.FS
 
+// suppressionTimeout bounds the lookups and updates of the suppression list.
+var suppressionTimeout = 3 * time.Second
+
 type EmailSenderService struct {
-	smtpConfig       *config.SMTP
-	log              *slog.Logger
-	verificationTmpl *template.Template
-	eventTmpl        *template.Template
+	smtpConfig *config.SMTP
+	log        *slog.Logger
+	catalogue  *catalogue
+	from       mail.Address
+	// nil when DKIM signing is disabled
+	dkim         *dkimSigner
+	transport    Transport
+	links        *unsubscribe.Links
+	suppressions suppression.Store
+	now          func() time.Time
+}
+
+// recipient is the addressee of an email with the link it unsubscribes with.
+type recipient struct {
+	email  string
+	locale string
+	// empty for the transactional emails
+	unsubscribeURL string
+	// the transactional emails, like the verification codes, still reach the addresses which
+	// unsubscribed, only the bounced ones are skipped
+	transactional bool
 }
 
-func NewEmailSender(smtpCfg *config.SMTP, log *slog.Logger) (*EmailSenderService, error) {
-	verificationTmpl, err := template.ParseFS(templateFS, "templates/email_verification.html")
+// NewEmailSender loads the templates of every locale, the emails in a locale without
+// its own template or subject are sent in the default locale.
+// The event and digest emails link to their unsubscription, and no email is sent to the suppressed addresses.
+func NewEmailSender(smtpCfg *config.SMTP, defaultLocale string, links *unsubscribe.Links,
+	suppressions suppression.Store, log *slog.Logger) (*EmailSenderService, error) {
+	catalogue, err := loadCatalogue(templateFS, defaultLocale)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse verification template: %w", err)
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	var signer *dkimSigner
+	if smtpCfg.DKIM.KeyFile != "" {
+		signer, err = newDKIMSigner(smtpCfg.DKIM.Domain, smtpCfg.DKIM.Selector, smtpCfg.DKIM.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dkim key: %w", err)
+		}
 	}
 
-	eventTmpl, err := template.ParseFS(templateFS, "templates/event.html")
+	transport, err := NewTransport(smtpCfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse event template: %w", err)
+		return nil, err
 	}
+
 	return &EmailSenderService{
-		smtpConfig:       smtpCfg,
-		log:              log,
-		verificationTmpl: verificationTmpl,
-		eventTmpl:        eventTmpl,
+		smtpConfig:   smtpCfg,
+		log:          log,
+		catalogue:    catalogue,
+		from:         mail.Address{Name: smtpCfg.FromName, Address: smtpCfg.Email},
+		dkim:         signer,
+		transport:    transport,
+		links:        links,
+		suppressions: suppressions,
+		now:          time.Now,
 	}, nil
 }
 
 func (s *EmailSenderService) SendVerificationEmail(msg models.EmailVerificationMessage) error {
-	subject := "Подтверждение эл. почты"
+	to := recipient{email: msg.Email, locale: msg.Locale, transactional: true}
+	return s.sendLocalized(to, templateVerification, subjectVerification, msg)
+}
+
+func (s *EmailSenderService) SendEventEmail(msg models.EventMessage) error {
+	to := s.recipient(msg.Email, msg.Locale)
+	msg.UnsubscribeURL = to.unsubscribeURL
+
+	switch msg.Type {
+	case models.EventTypeAssign:
+		return s.sendLocalized(to, templateAssignment, subjectAssignment, msg)
+	case models.EventTypeReminder:
+		return s.sendLocalized(to, templateReminder, subjectReminder, msg)
+	}
+
+	return s.sendLocalized(to, templateEvent, subjectEvent, msg)
+}
+
+func (s *EmailSenderService) SendDigestEmail(msg models.DigestMessage) error {
+	to := s.recipient(msg.Email, msg.Locale)
+	msg.UnsubscribeURL = to.unsubscribeURL
+	return s.sendLocalized(to, templateDigest, subjectDigest, msg)
+}
+
+func (s *EmailSenderService) recipient(email, locale string) recipient {
+	return recipient{email: email, locale: locale, unsubscribeURL: s.links.URL(email)}
+}
 
-	body, err := s.renderVerificationTemplate(msg)
+// sendLocalized renders the template with the subject of the locale and sends it,
+// unless the address is suppressed.
+func (s *EmailSenderService) sendLocalized(to recipient, templateName, subjectKey string, data any) error {
+	suppressed, err := s.suppressed(to)
+	if err != nil {
+		return fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	if suppressed {
+		s.log.Info("Email to suppressed address skipped", "email", to.email, "template", templateName)
+		return nil
+	}
+
+	body, err := s.render(to.locale, templateName, data)
 	if err != nil {
 		return err
 	}
 
-	return s.sendEmail(msg.Email, subject, body)
+	return s.sendEmail(to, s.catalogue.message(to.locale, subjectKey), body)
 }
 
-func (s *EmailSenderService) SendEventEmail(msg models.EventMessage) error {
-	subject := "Уведомление"
+func (s *EmailSenderService) suppressed(to recipient) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), suppressionTimeout)
+	defer cancel()
+
+	entry, err := s.suppressions.Get(ctx, to.email)
+	if err != nil || entry == nil {
+		return false, err
+	}
+	return !to.transactional || entry.Reason == suppression.ReasonBounced, nil
+}
 
-	body, err := s.renderEventTemplate(msg)
+// sendEmail sends the email, a recipient address rejected permanently is added to the suppression list
+// and the email isn't retried.
+func (s *EmailSenderService) sendEmail(to recipient, subject, body string) error {
+	msg, err := s.buildMessage(to, subject, body)
 	if err != nil {
 		return err
 	}
 
-	return s.sendEmail(msg.Email, subject, body)
+	err = s.transport.Send(s.from.Address, to.email, msg)
+	if !errors.Is(err, ErrHardBounce) {
+		return err
+	}
+
+	s.log.Warn("Email address bounced, suppressing it", "email", to.email, logging.Err(err))
+	ctx, cancel := context.WithTimeout(context.Background(), suppressionTimeout)
+	defer cancel()
+
+	entry := suppression.Entry{Reason: suppression.ReasonBounced, SuppressedAt: s.now()}
+	if err := s.suppressions.Add(ctx, to.email, entry); err != nil {
+		return fmt.Errorf("failed to suppress bounced address: %w", err)
+	}
+	return nil
 }
 
-func (s *EmailSenderService) sendEmail(to, subject, body string) error {
-	auth := smtp.PlainAuth("", s.smtpConfig.Email, s.smtpConfig.Password, s.smtpConfig.Host)
+// Close closes the connections of the transport.
+func (s *EmailSenderService) Close() error {
+	return s.transport.Close()
+}
 
-	msg := []byte(
-		"To: " + to + "\r\n" +
-			"Subject: " + subject + "\r\n" +
-			"MIME-version: 1.0;\r\n" +
-			"Content-Type: text/html; charset=\"UTF-8\";\r\n" +
-			"\r\n" + body,
-	)
+// buildMessage returns the MIME message of the rendered HTML body with its plain text version.
+// The List-Unsubscribe header of the non-transactional emails offers the link of the recipient
+// before the configured URI.
+func (s *EmailSenderService) buildMessage(to recipient, subject, body string) ([]byte, error) {
+	var listUnsubscribe []string
+	if !to.transactional {
+		listUnsubscribe = []string{to.unsubscribeURL}
+		if s.smtpConfig.ListUnsubscribe != "" {
+			listUnsubscribe = append(listUnsubscribe, s.smtpConfig.ListUnsubscribe)
+		}
+	}
 
-	addr := fmt.Sprintf("%s:%d", s.smtpConfig.Host, s.smtpConfig.Port)
-	return smtp.SendMail(addr, auth, s.smtpConfig.Email, []string{to}, msg)
+	msg := &message{
+		From:            s.from,
+		To:              to.email,
+		Subject:         subject,
+		Date:            s.now(),
+		MessageID:       newMessageID(addressDomain(s.from.Address)),
+		ListUnsubscribe: listUnsubscribe,
+		Text:            htmlToText(body),
+		HTML:            body,
+	}
+	return msg.write(s.dkim)
 }
@@ -0,0 +1,104 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/unsubscribe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSender(t *testing.T, suppressions suppression.Store) *EmailSenderService {
+	links := unsubscribe.NewLinks("https://tasks.example.com", "secret", time.Hour)
+	s, err := NewEmailSender(&config.SMTP{Email: "tasks@example.com", FromName: "Tasks", Transport: TransportMemory},
+		"ru", links, suppressions, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return s
+}
+
+func sentMessages(s *EmailSenderService) []SentMessage {
+	return s.transport.(*MemoryTransport).Messages()
+}
+
+func TestEmailSender_UnsubscribeLink(t *testing.T) {
+	s := newTestSender(t, suppression.NewMemoryStore())
+
+	for _, locale := range []string{"ru", "en"} {
+		require.NoError(t, s.SendEventEmail(models.EventMessage{
+			Email: "alice@example.com", Username: "alice", Type: models.EventTypeCreate, TaskText: "pay", Locale: locale,
+		}))
+	}
+	require.NoError(t, s.SendVerificationEmail(models.EmailVerificationMessage{Email: "alice@example.com", Code: "123456"}))
+
+	messages := sentMessages(s)
+	require.Len(t, messages, 3)
+	for _, msg := range messages[:2] {
+		data := string(msg.Data)
+		assert.Contains(t, data, "List-Unsubscribe: <https://tasks.example.com/unsubscribe?token=")
+		assert.Contains(t, data, "List-Unsubscribe-Post: List-Unsubscribe=One-Click")
+		// the quoted-printable body escapes the "=" of the query as "=3D"
+		assert.Contains(t, data, "https://tasks.example.com/unsubscribe?token=3D", "the templates embed the link")
+	}
+
+	verification := string(messages[2].Data)
+	assert.NotContains(t, verification, "List-Unsubscribe", "the verification email can't be unsubscribed from")
+	assert.NotContains(t, verification, "/unsubscribe")
+}
+
+func TestEmailSender_SkipsSuppressedAddresses(t *testing.T) {
+	ctx := context.Background()
+	store := suppression.NewMemoryStore()
+	require.NoError(t, store.Add(ctx, "Unsubscribed@example.com", suppression.Entry{Reason: suppression.ReasonUnsubscribed}))
+	require.NoError(t, store.Add(ctx, "bounced@example.com", suppression.Entry{Reason: suppression.ReasonBounced}))
+	s := newTestSender(t, store)
+
+	require.NoError(t, s.SendEventEmail(models.EventMessage{Email: "unsubscribed@example.com", Type: models.EventTypeCreate}))
+	require.NoError(t, s.SendDigestEmail(models.DigestMessage{Email: "bounced@example.com"}))
+	require.NoError(t, s.SendVerificationEmail(models.EmailVerificationMessage{Email: "bounced@example.com", Code: "1"}))
+	assert.Empty(t, sentMessages(s))
+
+	require.NoError(t, s.SendVerificationEmail(models.EmailVerificationMessage{Email: "unsubscribed@example.com", Code: "1"}))
+	messages := sentMessages(s)
+	require.Len(t, messages, 1, "the verification codes still reach the addresses which unsubscribed")
+	assert.Equal(t, "unsubscribed@example.com", messages[0].To)
+}
+
+func TestEmailSender_SuppressesHardBounces(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.reject = "ghost@example.com"
+	store := suppression.NewMemoryStore()
+	s := newTestSender(t, store)
+	transport, err := NewSMTPTransport(server.config())
+	require.NoError(t, err)
+	defer transport.Close()
+	s.transport = transport
+
+	msg := models.EventMessage{Email: "ghost@example.com", Type: models.EventTypeCreate, TaskText: "pay"}
+	require.NoError(t, s.SendEventEmail(msg), "a bounced email isn't retried")
+
+	entry, err := store.Get(context.Background(), "ghost@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, suppression.ReasonBounced, entry.Reason)
+
+	require.NoError(t, s.SendEventEmail(msg))
+	connections, _ := server.stats()
+	assert.Equal(t, 1, connections, "the suppressed address isn't sent to again")
+}
+
+func TestIsHardBounce(t *testing.T) {
+	assert.True(t, isHardBounce(fmt.Errorf("smtp rcpt: %w", &textproto.Error{Code: 550, Msg: "5.1.1 no such user"})))
+	assert.True(t, isHardBounce(&textproto.Error{Code: 553, Msg: "mailbox name not allowed"}))
+	assert.False(t, isHardBounce(&textproto.Error{Code: 550, Msg: "5.7.1 message rejected as spam"}))
+	assert.False(t, isHardBounce(&textproto.Error{Code: 452, Msg: "4.2.2 mailbox full"}))
+	assert.False(t, isHardBounce(io.EOF))
+}
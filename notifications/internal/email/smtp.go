@@ -0,0 +1,243 @@
+package email
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+)
+
+const (
+	SecuritySTARTTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// SMTPTransport sends the messages through up to PoolSize persistent connections to the SMTP server,
+// a connection is reused until it fails or stays idle for longer than IdleTimeout.
+type SMTPTransport struct {
+	cfg     *config.SMTP
+	addr    string
+	tls     *tls.Config
+	limiter *recipientLimiter
+	// a token is taken for every open connection, busy or idle
+	slots chan struct{}
+	idle  chan *smtpConn
+	now   func() time.Time
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func NewSMTPTransport(cfg *config.SMTP) (*SMTPTransport, error) {
+	switch cfg.Security {
+	case SecuritySTARTTLS, SecurityTLS, SecurityNone:
+	default:
+		return nil, fmt.Errorf("unknown smtp security %q, expected starttls, tls or none", cfg.Security)
+	}
+
+	poolSize := max(cfg.PoolSize, 1)
+	return &SMTPTransport{
+		cfg:     cfg,
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		tls:     &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12},
+		limiter: newRecipientLimiter(cfg.RecipientLimit, cfg.RecipientInterval),
+		slots:   make(chan struct{}, poolSize),
+		idle:    make(chan *smtpConn, poolSize),
+		now:     time.Now,
+	}, nil
+}
+
+// Send delivers the message through an idle connection, or a new one when there's none.
+// It waits for a connection when all of them are busy. Only the sent messages count towards the recipient limit.
+func (t *SMTPTransport) Send(from, to string, msg []byte) error {
+	if ok, wait := t.limiter.allow(to); !ok {
+		return &RateLimitError{Wait: wait}
+	}
+
+	c, err := t.acquire()
+	if err != nil {
+		t.limiter.giveBack(to)
+		return err
+	}
+
+	if err := t.deliver(c, from, to, msg); err != nil {
+		// the connection state is unknown after a failure, a rejected recipient included
+		t.discard(c)
+		t.limiter.giveBack(to)
+		return err
+	}
+
+	t.release(c)
+	return nil
+}
+
+func (t *SMTPTransport) deliver(c *smtpConn, from, to string, msg []byte) error {
+	if err := c.conn.SetDeadline(t.now().Add(t.timeout())); err != nil {
+		return err
+	}
+
+	if err := c.client.Mail(from); err != nil {
+		return fmt.Errorf("smtp mail: %w", err)
+	}
+	if err := c.client.Rcpt(to); err != nil {
+		if isHardBounce(err) {
+			return fmt.Errorf("smtp rcpt: %w: %w", ErrHardBounce, err)
+		}
+		return fmt.Errorf("smtp rcpt: %w", err)
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return nil
+}
+
+// isHardBounce tells whether the reply rejects the mailbox itself, the other 5xx replies,
+// like the policy rejections, may be about the message and don't suppress the address.
+func isHardBounce(err error) bool {
+	var reply *textproto.Error
+	if !errors.As(err, &reply) {
+		return false
+	}
+	// the enhanced status 5.7.x is a security or policy rejection, even with the 550 code
+	if strings.HasPrefix(reply.Msg, "5.7.") {
+		return false
+	}
+	// 550 mailbox unavailable, 551 user not local, 553 mailbox name not allowed
+	return reply.Code == 550 || reply.Code == 551 || reply.Code == 553
+}
+
+// acquire returns an idle connection which is still alive, or dials a new one when a slot is free.
+func (t *SMTPTransport) acquire() (*smtpConn, error) {
+	for {
+		select {
+		case c := <-t.idle:
+			if t.alive(c) {
+				return c, nil
+			}
+			t.discard(c)
+		case t.slots <- struct{}{}:
+			c, err := t.dial()
+			if err != nil {
+				<-t.slots
+				return nil, err
+			}
+			return c, nil
+		}
+	}
+}
+
+// alive checks the idle connection with RSET, the connections idle for too long are closed
+// as the servers drop them anyway.
+func (t *SMTPTransport) alive(c *smtpConn) bool {
+	if t.cfg.IdleTimeout > 0 && t.now().Sub(c.lastUsed) > t.cfg.IdleTimeout {
+		return false
+	}
+	if err := c.conn.SetDeadline(t.now().Add(t.timeout())); err != nil {
+		return false
+	}
+	return c.client.Reset() == nil
+}
+
+func (t *SMTPTransport) release(c *smtpConn) {
+	c.lastUsed = t.now()
+	t.idle <- c
+}
+
+// discard closes the connection and frees its slot.
+func (t *SMTPTransport) discard(c *smtpConn) {
+	_ = c.client.Close()
+	<-t.slots
+}
+
+func (t *SMTPTransport) dial() (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: t.timeout()}
+
+	var conn net.Conn
+	var err error
+	if t.cfg.Security == SecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", t.addr, t.tls)
+	} else {
+		conn, err = dialer.Dial("tcp", t.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("smtp dial: %w", err)
+	}
+
+	if err := conn.SetDeadline(t.now().Add(t.timeout())); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	client, err := smtp.NewClient(conn, t.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("smtp greeting: %w", err)
+	}
+
+	if err := t.handshake(client); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &smtpConn{conn: conn, client: client, lastUsed: t.now()}, nil
+}
+
+// handshake upgrades the connection with STARTTLS when it's required and authenticates.
+func (t *SMTPTransport) handshake(client *smtp.Client) error {
+	if t.cfg.Security == SecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server doesn't support STARTTLS")
+		}
+		if err := client.StartTLS(t.tls); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if t.cfg.Password == "" {
+		return nil
+	}
+	if ok, _ := client.Extension("AUTH"); !ok {
+		return errors.New("smtp server doesn't support AUTH")
+	}
+	if err := client.Auth(smtp.PlainAuth("", t.cfg.Email, t.cfg.Password, t.cfg.Host)); err != nil {
+		return fmt.Errorf("smtp auth: %w", err)
+	}
+	return nil
+}
+
+func (t *SMTPTransport) timeout() time.Duration {
+	if t.cfg.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return t.cfg.Timeout
+}
+
+// Close quits the idle connections, it's called when nothing is sent anymore.
+func (t *SMTPTransport) Close() error {
+	for {
+		select {
+		case c := <-t.idle:
+			_ = c.conn.SetDeadline(t.now().Add(t.timeout()))
+			_ = c.client.Quit()
+			<-t.slots
+		default:
+			return nil
+		}
+	}
+}
@@ -1,25 +1,11 @@
 package email
 
-import (
-	"bytes"
+import "bytes"
 
-	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
-)
-
-func (s *EmailSenderService) renderVerificationTemplate(msg models.EmailVerificationMessage) (string, error) {
-	var buf bytes.Buffer
-
-	if err := s.verificationTmpl.Execute(&buf, msg); err != nil {
-		return "", err
-	}
-
-	return buf.String(), nil
-}
-
-func (s *EmailSenderService) renderEventTemplate(msg models.EventMessage) (string, error) {
+func (s *EmailSenderService) render(locale, templateName string, data any) (string, error) {
 	var buf bytes.Buffer
 
-	if err := s.eventTmpl.Execute(&buf, msg); err != nil {
+	if err := s.catalogue.template(locale, templateName).Execute(&buf, data); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// Elements starting a new line and a new paragraph of the plain text.
+var (
+	lineElements      = map[string]bool{"br": true, "div": true, "li": true, "tr": true}
+	paragraphElements = map[string]bool{
+		"p": true, "table": true, "ul": true, "ol": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+	// skippedElements aren't part of the plain text
+	skippedElements = map[string]bool{"head": true, "style": true, "script": true, "title": true}
+)
+
+// htmlToText returns the plain text version of the rendered HTML email: the text of the elements
+// with the whitespace collapsed, a line per block element and an empty line between the paragraphs.
+func htmlToText(document string) string {
+	t := &textWriter{}
+	z := html.NewTokenizer(strings.NewReader(document))
+	skipped := 0
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			// io.EOF, or a malformed document rendered as far as it was read
+			return t.String()
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case skippedElements[tag] && tt == html.StartTagToken:
+				skipped++
+			case skippedElements[tag] && tt == html.EndTagToken && skipped > 0:
+				skipped--
+			case paragraphElements[tag]:
+				t.breakLine(2)
+			case lineElements[tag]:
+				t.breakLine(1)
+			}
+			if tag == "li" && tt == html.StartTagToken {
+				t.writeText("- ")
+			}
+		case html.TextToken:
+			if skipped == 0 {
+				t.writeText(string(z.Text()))
+			}
+		}
+	}
+}
+
+type textWriter struct {
+	buf []byte
+}
+
+// writeText appends the text with the runs of whitespace collapsed to a single space.
+func (t *textWriter) writeText(text string) {
+	for _, r := range text {
+		space := unicode.IsSpace(r)
+		if !space {
+			t.buf = append(t.buf, string(r)...)
+			continue
+		}
+		if len(t.buf) > 0 && t.buf[len(t.buf)-1] != ' ' && t.buf[len(t.buf)-1] != '\n' {
+			t.buf = append(t.buf, ' ')
+		}
+	}
+}
+
+// breakLine ends the line, leaving n-1 empty lines after it.
+func (t *textWriter) breakLine(n int) {
+	t.buf = bytes.TrimRight(t.buf, " ")
+	if len(t.buf) == 0 {
+		return
+	}
+
+	trailing := len(t.buf) - len(bytes.TrimRight(t.buf, "\n"))
+	for ; trailing < n; trailing++ {
+		t.buf = append(t.buf, '\n')
+	}
+}
+
+func (t *textWriter) String() string {
+	return strings.TrimSpace(string(t.buf)) + "\n"
+}
@@ -0,0 +1,29 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+	document := `<!DOCTYPE html>
+<html>
+<head><style>.header { color: #2563eb; }</style></head>
+<body>
+    <div class="container">
+        <div class="header"><h2>Напоминание о задаче</h2></div>
+        <p>Здравствуйте, <strong>alice</strong>!</p>
+        <div class="content">
+            <div class="task">pay   the
+                bills</div>
+            <ul><li>one</li><li>two</li></ul>
+            <p>first<br>second &amp; third</p>
+        </div>
+    </div>
+</body>
+</html>`
+
+	expected := "Напоминание о задаче\n\nЗдравствуйте, alice!\n\npay the bills\n\n- one\n- two\n\nfirst\nsecond & third\n"
+	assert.Equal(t, expected, htmlToText(document))
+}
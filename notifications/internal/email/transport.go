@@ -0,0 +1,118 @@
+package email
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+)
+
+const (
+	TransportSMTP   = "smtp"
+	TransportFile   = "file"
+	TransportMemory = "memory"
+)
+
+// ErrHardBounce is wrapped by the errors of the transports when the recipient address is rejected
+// permanently, like an unknown mailbox, so no more emails are sent to it.
+var ErrHardBounce = errors.New("recipient address rejected permanently")
+
+// Transport delivers the written messages to the recipient.
+type Transport interface {
+	Send(from, to string, msg []byte) error
+	Close() error
+}
+
+// NewTransport returns the transport chosen by the config.
+func NewTransport(cfg *config.SMTP) (Transport, error) {
+	switch cfg.Transport {
+	case TransportSMTP, "":
+		return NewSMTPTransport(cfg)
+	case TransportFile:
+		return NewFileTransport(cfg.FileDir)
+	case TransportMemory:
+		return NewMemoryTransport(), nil
+	}
+	return nil, fmt.Errorf("unknown email transport %q", cfg.Transport)
+}
+
+// FileTransport writes the messages as .eml files to a directory instead of sending them,
+// so they can be opened in a mail client.
+type FileTransport struct {
+	dir string
+}
+
+func NewFileTransport(dir string) (*FileTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileTransport{dir: dir}, nil
+}
+
+// Send writes the message to a file named after the time and the recipient, like "20260304-090000.000-alice@example.com-1f2e.eml".
+func (f *FileTransport) Send(from, to string, msg []byte) error {
+	var suffix [2]byte
+	_, _ = rand.Read(suffix[:])
+
+	name := fmt.Sprintf("%s-%s-%s.eml", time.Now().UTC().Format("20060102-150405.000"), safeFileName(to), hex.EncodeToString(suffix[:]))
+	return os.WriteFile(filepath.Join(f.dir, name), msg, 0o644)
+}
+
+func (f *FileTransport) Close() error {
+	return nil
+}
+
+// safeFileName replaces the characters which aren't safe in file names.
+func safeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '@' || r == '.' || r == '-' || r == '_' || r == '+' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// SentMessage is a message kept by the MemoryTransport.
+type SentMessage struct {
+	From string
+	To   string
+	Data []byte
+}
+
+// MemoryTransport keeps the messages instead of sending them, Messages returns them.
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []SentMessage
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (m *MemoryTransport) Send(from, to string, msg []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, SentMessage{From: from, To: to, Data: append([]byte(nil), msg...)})
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (m *MemoryTransport) Messages() []SentMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]SentMessage(nil), m.messages...)
+}
+
+func (m *MemoryTransport) Close() error {
+	return nil
+}
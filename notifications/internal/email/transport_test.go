@@ -0,0 +1,249 @@
+package email
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts the messages of a plain SMTP session, rejecting the recipients in reject.
+type fakeSMTPServer struct {
+	ln     net.Listener
+	reject string
+
+	mu          sync.Mutex
+	connections int
+	messages    []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(command, "RCPT") && s.reject != "" && strings.Contains(command, strings.ToUpper(s.reject)):
+			reply("550 5.1.1 no such user")
+		case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"),
+			strings.HasPrefix(command, "RSET"), strings.HasPrefix(command, "NOOP"):
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 unknown command")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) config() *config.SMTP {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return &config.SMTP{
+		Email:    "tasks@example.com",
+		Host:     host,
+		Port:     p,
+		Security: SecurityNone,
+		PoolSize: 1,
+		Timeout:  5 * time.Second,
+	}
+}
+
+func (s *fakeSMTPServer) stats() (int, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections, append([]string(nil), s.messages...)
+}
+
+func TestSMTPTransport_ReusesConnection(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	transport, err := NewSMTPTransport(server.config())
+	require.NoError(t, err)
+	defer transport.Close()
+
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 1\r\n\r\nfirst\r\n")))
+	require.NoError(t, transport.Send("tasks@example.com", "bob@example.com", []byte("Subject: 2\r\n\r\nsecond\r\n")))
+
+	connections, messages := server.stats()
+	assert.Equal(t, 1, connections, "the connection is reused")
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[1], "second")
+}
+
+func TestSMTPTransport_ReplacesFailedConnection(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.reject = "ghost@example.com"
+	transport, err := NewSMTPTransport(server.config())
+	require.NoError(t, err)
+	defer transport.Close()
+
+	err = transport.Send("tasks@example.com", "ghost@example.com", []byte("Subject: 1\r\n\r\nlost\r\n"))
+	assert.ErrorContains(t, err, "550")
+	assert.ErrorIs(t, err, ErrHardBounce)
+
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 2\r\n\r\nsent\r\n")))
+	connections, messages := server.stats()
+	assert.Equal(t, 2, connections, "the failed connection isn't reused")
+	assert.Len(t, messages, 1)
+}
+
+func TestSMTPTransport_IdleTimeout(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	cfg := server.config()
+	cfg.IdleTimeout = time.Minute
+	transport, err := NewSMTPTransport(cfg)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 1\r\n\r\n1\r\n")))
+
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 2\r\n\r\n2\r\n")))
+
+	connections, _ := server.stats()
+	assert.Equal(t, 2, connections, "the connection idle for too long is replaced")
+}
+
+func TestSMTPTransport_RecipientRateLimit(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	cfg := server.config()
+	cfg.RecipientLimit = 2
+	cfg.RecipientInterval = time.Hour
+	transport, err := NewSMTPTransport(cfg)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	now := time.Now()
+	transport.limiter.now = func() time.Time { return now }
+	msg := []byte("Subject: 1\r\n\r\n1\r\n")
+
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", msg))
+	require.NoError(t, transport.Send("tasks@example.com", "Alice@example.com", msg))
+	var rateLimited *RateLimitError
+	if assert.ErrorAs(t, transport.Send("tasks@example.com", "alice@example.com", msg), &rateLimited) {
+		assert.ErrorIs(t, rateLimited, ErrRecipientRateLimited)
+		assert.Equal(t, 30*time.Minute, rateLimited.RetryAfter(), "a token is back in half the interval")
+	}
+	assert.NoError(t, transport.Send("tasks@example.com", "bob@example.com", msg), "the limit is per recipient")
+
+	now = now.Add(30 * time.Minute)
+	assert.NoError(t, transport.Send("tasks@example.com", "alice@example.com", msg), "the bucket is refilled over time")
+	assert.ErrorIs(t, transport.Send("tasks@example.com", "alice@example.com", msg), ErrRecipientRateLimited)
+}
+
+func TestSMTPTransport_RecipientRateLimitCountsSentMessages(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.reject = "ghost@example.com"
+	cfg := server.config()
+	cfg.RecipientLimit = 1
+	cfg.RecipientInterval = time.Hour
+	transport, err := NewSMTPTransport(cfg)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	msg := []byte("Subject: 1\r\n\r\n1\r\n")
+	assert.ErrorIs(t, transport.Send("tasks@example.com", "ghost@example.com", msg), ErrHardBounce)
+	assert.ErrorIs(t, transport.Send("tasks@example.com", "ghost@example.com", msg), ErrHardBounce,
+		"the failed message doesn't take the token")
+}
+
+func TestNewSMTPTransport_UnknownSecurity(t *testing.T) {
+	_, err := NewSMTPTransport(&config.SMTP{Host: "localhost", Port: 25, Security: "ssl"})
+	assert.Error(t, err)
+}
+
+func TestFileTransport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	transport, err := NewTransport(&config.SMTP{Transport: TransportFile, FileDir: dir})
+	require.NoError(t, err)
+
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 1\r\n\r\nhello\r\n")))
+	require.NoError(t, transport.Send("tasks@example.com", "alice@example.com", []byte("Subject: 2\r\n\r\nagain\r\n")))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*alice@example.com*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Subject: ")
+}
+
+func TestEmailSender_MemoryTransport(t *testing.T) {
+	s := newTestSender(t, suppression.NewMemoryStore())
+
+	require.NoError(t, s.SendDigestEmail(models.DigestMessage{
+		Email:    "alice@example.com",
+		Username: "alice",
+		Locale:   "en",
+		Days:     []models.DigestDay{{Date: time.Now(), Created: []models.DigestTask{{TaskId: 1, Text: "pay"}}}},
+	}))
+
+	messages := s.transport.(*MemoryTransport).Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "tasks@example.com", messages[0].From)
+	assert.Equal(t, "alice@example.com", messages[0].To)
+	assert.Contains(t, string(messages[0].Data), "Subject: Task digest")
+	assert.Contains(t, string(messages[0].Data), "Content-Type: multipart/alternative")
+}
@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	messagesPb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+)
+
+// The producers send the messages from api/proto/messages.proto as protobuf with these headers,
+// the messages without them are JSON sent before the protobuf migration.
+const (
+	headerContentType   = "content-type"
+	headerSchemaVersion = "schema-version"
+	contentTypeProtobuf = "application/x-protobuf"
+	schemaVersion       = "1"
+)
+
+// isProtobuf tells whether the message value is protobuf and checks its schema version is supported.
+func isProtobuf(msg kafka.Message) (bool, error) {
+	contentType, ok := header(msg, headerContentType)
+	if !ok {
+		return false, nil
+	}
+	if contentType != contentTypeProtobuf {
+		return false, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	if version, _ := header(msg, headerSchemaVersion); version != schemaVersion {
+		return false, fmt.Errorf("unsupported schema version %q", version)
+	}
+	return true, nil
+}
+
+func header(msg kafka.Message, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// messageEventId returns the event id of the message, the messages sent before the event ids were added
+// are identified by their position in Kafka, which still catches their redeliveries.
+func messageEventId(msg kafka.Message, eventId string) string {
+	if eventId != "" {
+		return eventId
+	}
+	return fmt.Sprintf("kafka:%s:%d:%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+func decodeVerificationMessage(msg kafka.Message) (models.EmailVerificationMessage, error) {
+	var verificationMsg models.EmailVerificationMessage
+
+	protobuf, err := isProtobuf(msg)
+	if err != nil {
+		return verificationMsg, err
+	}
+	if !protobuf {
+		if err := json.Unmarshal(msg.Value, &verificationMsg); err != nil {
+			return verificationMsg, fmt.Errorf("unmarshal verification message: %w", err)
+		}
+		return verificationMsg, nil
+	}
+
+	var pb messagesPb.EmailVerificationMessage
+	if err := proto.Unmarshal(msg.Value, &pb); err != nil {
+		return verificationMsg, fmt.Errorf("unmarshal verification message: %w", err)
+	}
+	verificationMsg.Email = pb.GetEmail()
+	verificationMsg.Code = pb.GetCode()
+	verificationMsg.Username = pb.GetUsername()
+	verificationMsg.EventId = pb.GetEventId()
+	verificationMsg.Locale = pb.GetLocale()
+	return verificationMsg, nil
+}
+
+func decodeTaskEvent(msg kafka.Message) (*models.TaskEvent, error) {
+	protobuf, err := isProtobuf(msg)
+	if err != nil {
+		return nil, err
+	}
+	if !protobuf {
+		var event models.TaskEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			return nil, fmt.Errorf("unmarshal task event: %w", err)
+		}
+		return &event, nil
+	}
+
+	var pb messagesPb.TaskEvent
+	if err := proto.Unmarshal(msg.Value, &pb); err != nil {
+		return nil, fmt.Errorf("unmarshal task event: %w", err)
+	}
+	return taskEventFromPb(&pb), nil
+}
+
+func taskEventFromPb(pb *messagesPb.TaskEvent) *models.TaskEvent {
+	var changes []models.FieldChange
+	for _, c := range pb.GetChanges() {
+		changes = append(changes, models.FieldChange{Field: c.GetField(), Old: c.GetOld(), New: c.GetNew()})
+	}
+
+	task := pb.GetTask()
+	return &models.TaskEvent{
+		Id:         pb.GetId(),
+		Type:       pb.GetType(),
+		Version:    int(pb.GetVersion()),
+		TaskId:     pb.GetTaskId(),
+		UserId:     pb.GetUserId(),
+		OccurredAt: timeFromPb(pb.GetOccurredAt()),
+		Changes:    changes,
+		Task: models.TaskSnapshot{
+			Text:       task.GetText(),
+			Status:     task.GetStatus(),
+			Priority:   int(task.GetPriority()),
+			DueAt:      optionalTimeFromPb(task.GetDueAt()),
+			AuthorId:   task.GetAuthorId(),
+			AssigneeId: task.GetAssigneeId(),
+			ProjectId:  task.GetProjectId(),
+			CreatedAt:  timeFromPb(task.GetCreatedAt()),
+			UpdatedAt:  timeFromPb(task.GetUpdatedAt()),
+		},
+	}
+}
+
+func timeFromPb(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func optionalTimeFromPb(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	messagesPb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+)
+
+func protobufMessage(t *testing.T, m proto.Message, version string) kafka.Message {
+	value, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return kafka.Message{
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: headerContentType, Value: []byte(contentTypeProtobuf)},
+			{Key: headerSchemaVersion, Value: []byte(version)},
+		},
+	}
+}
+
+func TestDecodeVerificationMessage(t *testing.T) {
+	pb := &messagesPb.EmailVerificationMessage{Email: "alice@example.com", Code: "1234", Username: "alice", Locale: "en"}
+	jsonMsg := kafka.Message{Value: []byte(`{"email":"alice@example.com","code":"1234","username":"alice","locale":"en"}`)}
+
+	for name, msg := range map[string]kafka.Message{
+		"protobuf": protobufMessage(t, pb, schemaVersion),
+		"json":     jsonMsg,
+	} {
+		t.Run(name, func(t *testing.T) {
+			decoded, err := decodeVerificationMessage(msg)
+
+			require.NoError(t, err)
+			assert.Equal(t, "alice@example.com", decoded.Email)
+			assert.Equal(t, "1234", decoded.Code)
+			assert.Equal(t, "alice", decoded.Username)
+			assert.Equal(t, "en", decoded.Locale)
+		})
+	}
+}
+
+func TestDecodeTaskEvent(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	pb := &messagesPb.TaskEvent{
+		Id:         "event-1",
+		Type:       "task.updated",
+		Version:    1,
+		TaskId:     10,
+		UserId:     1,
+		OccurredAt: timestamppb.New(now),
+		Changes:    []*messagesPb.FieldChange{{Field: "text", Old: "pay", New: "pay the bills"}},
+		Task:       &messagesPb.TaskSnapshot{Text: "pay the bills", Status: "todo", DueAt: timestamppb.New(now)},
+	}
+	jsonMsg := kafka.Message{Value: []byte(`{"id":"event-1","type":"task.updated","version":1,"task_id":10,"user_id":1,
+		"occurred_at":"2026-05-04T09:00:00Z","changes":[{"field":"text","old":"pay","new":"pay the bills"}],
+		"task":{"text":"pay the bills","status":"todo","due_at":"2026-05-04T09:00:00Z"}}`)}
+
+	for name, msg := range map[string]kafka.Message{
+		"protobuf": protobufMessage(t, pb, schemaVersion),
+		"json":     jsonMsg,
+	} {
+		t.Run(name, func(t *testing.T) {
+			event, err := decodeTaskEvent(msg)
+
+			require.NoError(t, err)
+			assert.Equal(t, "event-1", event.Id)
+			assert.Equal(t, "task.updated", event.Type)
+			assert.Equal(t, 1, event.Version)
+			assert.Equal(t, int64(10), event.TaskId)
+			assert.True(t, now.Equal(event.OccurredAt))
+			if assert.Len(t, event.Changes, 1) {
+				assert.Equal(t, "pay", event.Changes[0].Old)
+			}
+			assert.Equal(t, "pay the bills", event.Task.Text)
+			if assert.NotNil(t, event.Task.DueAt) {
+				assert.True(t, now.Equal(*event.Task.DueAt))
+			}
+		})
+	}
+}
+
+func TestDecodeTaskEvent_UnsupportedSchemaVersion(t *testing.T) {
+	_, err := decodeTaskEvent(protobufMessage(t, &messagesPb.TaskEvent{Id: "event-1"}, "2"))
+
+	assert.ErrorContains(t, err, "unsupported schema version")
+}
@@ -2,34 +2,57 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
 	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
 	"github.com/segmentio/kafka-go"
 )
 
+const (
+	maxAttempts        = 3
+	maxDeadLetterDelay = 30 * time.Second
+	// the longest wait for the email limit of a recipient to refill before the next attempt
+	maxRateLimitDelay = 5 * time.Minute
+)
+
 type Consumer struct {
 	readers      map[string]*kafka.Reader
+	dlq          messageWriter
 	emailService *email.EmailSenderService
+	dispatcher   Dispatcher
+	dedup        dedup.Store
 	config       config.Kafka
 	wg           sync.WaitGroup
 	log          *slog.Logger
+	retryDelay   time.Duration
+	now          func() time.Time
 }
 
 type MessageHandler interface {
-	HandleMessage(ctx context.Context, message []byte) error
+	HandleMessage(ctx context.Context, msg kafka.Message) error
 }
 
-func NewConsumer(config config.Kafka, emailService *email.EmailSenderService, log *slog.Logger) *Consumer {
+// NewConsumer returns the consumer of the notifications topics. The notifications of the task events
+// are delivered by the dispatcher, the verification emails are always sent by emailService.
+func NewConsumer(config config.Kafka, emailService *email.EmailSenderService, dispatcher Dispatcher, dedup dedup.Store,
+	log *slog.Logger) *Consumer {
 	return &Consumer{
 		readers:      make(map[string]*kafka.Reader),
+		dlq:          newWriter(config.Brokers),
 		emailService: emailService,
+		dispatcher:   dispatcher,
+		dedup:        dedup,
 		config:       config,
 		log:          log,
+		retryDelay:   time.Second,
+		now:          time.Now,
 	}
 }
 
@@ -37,8 +60,9 @@ func (c *Consumer) Start(ctx context.Context) error {
 	c.log.Info("Starting Kafka consumers")
 
 	handlers := map[string]MessageHandler{
-		c.config.EmailVerificationTopic: &emailVerificationHandler{emailService: c.emailService, log: c.log},
-		c.config.EventsTopic:            &eventsHandler{emailService: c.emailService, log: c.log},
+		c.config.EmailVerificationTopic: &emailVerificationHandler{emailService: c.emailService, dedup: c.dedup, log: c.log},
+		c.config.TaskEventsTopic:        &taskEventsHandler{dispatcher: c.dispatcher, log: c.log},
+		c.config.EventsTopic:            &eventsHandler{emailService: c.emailService, dedup: c.dedup, log: c.log},
 	}
 
 	for topic, handler := range handlers {
@@ -70,6 +94,8 @@ func (c *Consumer) createReader(topic string) *kafka.Reader {
 	})
 }
 
+// consumeTopic commits a message only after it's handled or moved to the DLQ,
+// so the messages in progress are consumed again after a crash.
 func (c *Consumer) consumeTopic(ctx context.Context, topic string, reader *kafka.Reader, handler MessageHandler) {
 	defer c.wg.Done()
 
@@ -81,54 +107,128 @@ func (c *Consumer) consumeTopic(ctx context.Context, topic string, reader *kafka
 			return
 		default:
 			c.log.Debug("waiting for msg")
-			msg, err := reader.ReadMessage(ctx)
+			msg, err := reader.FetchMessage(ctx)
 			if err != nil {
 				if ctx.Err() != nil {
 					return
 				}
 
-				c.log.Error("Error reading message from Kafka",
+				c.log.Error("Error fetching message from Kafka",
 					"topic", topic,
 					logging.Err(err))
 				continue
 			}
 
-			c.handleMessageWithRetry(ctx, topic, msg, handler)
-		}
-	}
-}
-
-func (c *Consumer) handleMessageWithRetry(ctx context.Context, topic string, msg kafka.Message, handler MessageHandler) {
-	const maxRetries = 3
-	var lastErr error
+			if !c.processMessage(ctx, msg, handler) {
+				return
+			}
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := handler.HandleMessage(ctx, msg.Value); err != nil {
-			lastErr = err
-			c.log.Warn("Failed to process message, retrying",
-				"topic", topic,
-				"attempt", attempt,
-				"maxRetries", maxRetries,
-				logging.Err(err))
+			if err := reader.CommitMessages(ctx, msg); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
 
-			if attempt < maxRetries {
-				time.Sleep(time.Duration(attempt) * time.Second)
+				c.log.Error("Error committing message",
+					"topic", topic,
+					"partition", msg.Partition,
+					"offset", msg.Offset,
+					logging.Err(err))
 			}
-			continue
 		}
+	}
+}
 
+// processMessage handles the message, moving it to the DLQ of its topic when it fails.
+// It returns false when the context is done before the message is settled, so it's left uncommitted.
+func (c *Consumer) processMessage(ctx context.Context, msg kafka.Message, handler MessageHandler) bool {
+	attempts, err := c.handleMessageWithRetry(ctx, msg, handler)
+	if err == nil {
 		c.log.Info("Successfully processed message",
-			"topic", topic,
+			"topic", msg.Topic,
 			"partition", msg.Partition,
 			"offset", msg.Offset)
-		return
+		return true
+	}
+	if ctx.Err() != nil {
+		return false
 	}
 
-	c.log.Error("Failed to process message after all retries",
-		"topic", topic,
+	c.log.Error("Failed to process message, moving it to the DLQ",
+		"topic", msg.Topic,
 		"partition", msg.Partition,
 		"offset", msg.Offset,
-		logging.Err(lastErr))
+		"attempts", attempts,
+		logging.Err(err))
+	return c.deadLetter(ctx, msg, err, attempts)
+}
+
+// handleMessageWithRetry retries the failed message up to maxAttempts times, the permanent errors aren't retried.
+// An email over the limit of the recipient is retried once the limit refills.
+func (c *Consumer) handleMessageWithRetry(ctx context.Context, msg kafka.Message, handler MessageHandler) (int, error) {
+	var lastErr error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		lastErr = handler.HandleMessage(ctx, msg)
+		if lastErr == nil || isPermanent(lastErr) {
+			return attempt, lastErr
+		}
+
+		c.log.Warn("Failed to process message, retrying",
+			"topic", msg.Topic,
+			"attempt", attempt,
+			"maxAttempts", maxAttempts,
+			logging.Err(lastErr))
+
+		if attempt < maxAttempts && !sleep(ctx, c.attemptDelay(attempt, lastErr)) {
+			return attempt, ctx.Err()
+		}
+	}
+
+	return maxAttempts, lastErr
+}
+
+// attemptDelay returns the wait before the next attempt, growing with the attempts.
+func (c *Consumer) attemptDelay(attempt int, err error) time.Duration {
+	delay := time.Duration(attempt) * c.retryDelay
+	var rateLimited *email.RateLimitError
+	if errors.As(err, &rateLimited) {
+		delay = max(delay, min(rateLimited.RetryAfter(), maxRateLimitDelay))
+	}
+	return delay
+}
+
+// deadLetter writes the message to the DLQ, retrying until it succeeds or the context is done,
+// as the message can't be committed before it's saved somewhere.
+func (c *Consumer) deadLetter(ctx context.Context, msg kafka.Message, handleErr error, attempts int) bool {
+	dlqMsg := deadLetterMessage(msg, msg.Topic+c.config.DLQSuffix, handleErr, attempts, c.now())
+
+	delay := c.retryDelay
+	for {
+		err := c.dlq.WriteMessages(ctx, dlqMsg)
+		if err == nil {
+			c.log.Info("Message moved to the DLQ", "topic", msg.Topic, "dlq", dlqMsg.Topic, "offset", msg.Offset)
+			return true
+		}
+
+		c.log.Error("Error writing message to the DLQ", "dlq", dlqMsg.Topic, logging.Err(err))
+		if !sleep(ctx, delay) {
+			return false
+		}
+		delay = min(2*delay, maxDeadLetterDelay)
+	}
+}
+
+// sleep waits for the duration and returns false when the context is done before that.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
 
 func (c *Consumer) Stop() error {
@@ -146,6 +246,13 @@ func (c *Consumer) Stop() error {
 
 	c.wg.Wait()
 
+	if closer, ok := c.dlq.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			c.log.Error("Error closing DLQ writer", logging.Err(err))
+			lastErr = err
+		}
+	}
+
 	c.log.Info("Kafka consumers stopped")
 	return lastErr
 }
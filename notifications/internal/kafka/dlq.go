@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers added to the messages moved to a DLQ, the original headers are kept.
+const (
+	headerDLQPrefix    = "dlq-"
+	headerDLQError     = "dlq-error"
+	headerDLQTopic     = "dlq-original-topic"
+	headerDLQPartition = "dlq-original-partition"
+	headerDLQOffset    = "dlq-original-offset"
+	headerDLQAttempts  = "dlq-attempts"
+	headerDLQFailedAt  = "dlq-failed-at"
+)
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// permanentError marks the errors retrying can't fix, like a malformed message,
+// such messages are moved to the DLQ without retries.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+func newWriter(brokers []string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		BatchSize:    1,
+		BatchTimeout: 10 * time.Millisecond,
+		RequiredAcks: kafka.RequireOne,
+		Async:        false,
+	}
+}
+
+// deadLetterMessage returns the failed message addressed to the DLQ with the error metadata in its headers.
+func deadLetterMessage(msg kafka.Message, dlqTopic string, handleErr error, attempts int, failedAt time.Time) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+6)
+	for _, h := range msg.Headers {
+		if !strings.HasPrefix(h.Key, headerDLQPrefix) {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: headerDLQError, Value: []byte(handleErr.Error())},
+		kafka.Header{Key: headerDLQTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: headerDLQPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: headerDLQOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: headerDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: headerDLQFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339))},
+	)
+
+	return kafka.Message{
+		Topic:   dlqTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// replayMessage returns the DLQ message addressed back to its original topic without the DLQ headers.
+// The messages without the original topic header go to the given topic.
+func replayMessage(msg kafka.Message, topic string) kafka.Message {
+	if original, ok := header(msg, headerDLQTopic); ok && original != "" {
+		topic = original
+	}
+
+	var headers []kafka.Header
+	for _, h := range msg.Headers {
+		if !strings.HasPrefix(h.Key, headerDLQPrefix) {
+			headers = append(headers, h)
+		}
+	}
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
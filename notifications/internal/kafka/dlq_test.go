@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHandler struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeHandler) HandleMessage(ctx context.Context, msg kafka.Message) error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+type fakeWriter struct {
+	messages []kafka.Message
+	errs     []error
+}
+
+func (f *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return err
+	}
+	f.messages = append(f.messages, msgs...)
+	return nil
+}
+
+type fakeFetcher struct {
+	pending   []kafka.Message
+	committed []int64
+}
+
+func (f *fakeFetcher) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(f.pending) == 0 {
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+	msg := f.pending[0]
+	f.pending = f.pending[1:]
+	return msg, nil
+}
+
+func (f *fakeFetcher) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		f.committed = append(f.committed, msg.Offset)
+	}
+	return nil
+}
+
+var testNow = time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+
+func newTestConsumer(dlq messageWriter) *Consumer {
+	return &Consumer{
+		dlq:    dlq,
+		config: config.Kafka{DLQSuffix: ".dlq"},
+		log:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		now:    func() time.Time { return testNow },
+	}
+}
+
+func testMessage() kafka.Message {
+	return kafka.Message{
+		Topic:     "task-events",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("10"),
+		Value:     []byte("value"),
+		Headers:   []kafka.Header{{Key: headerContentType, Value: []byte(contentTypeProtobuf)}},
+	}
+}
+
+func TestProcessMessage_RetriesTransientErrors(t *testing.T) {
+	handler := &fakeHandler{errs: []error{errors.New("smtp is down")}}
+	dlq := &fakeWriter{}
+
+	ok := newTestConsumer(dlq).processMessage(context.Background(), testMessage(), handler)
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, handler.calls)
+	assert.Empty(t, dlq.messages)
+}
+
+func TestProcessMessage_MovesExhaustedMessageToDLQ(t *testing.T) {
+	handleErr := errors.New("smtp is down")
+	handler := &fakeHandler{errs: []error{handleErr, handleErr, handleErr}}
+	dlq := &fakeWriter{errs: []error{errors.New("kafka is down")}}
+
+	ok := newTestConsumer(dlq).processMessage(context.Background(), testMessage(), handler)
+
+	assert.True(t, ok)
+	assert.Equal(t, maxAttempts, handler.calls)
+	require.Len(t, dlq.messages, 1, "the DLQ write is retried")
+
+	msg := dlq.messages[0]
+	assert.Equal(t, "task-events.dlq", msg.Topic)
+	assert.Equal(t, []byte("10"), msg.Key)
+	assert.Equal(t, []byte("value"), msg.Value)
+	assert.Equal(t, []kafka.Header{
+		{Key: headerContentType, Value: []byte(contentTypeProtobuf)},
+		{Key: headerDLQError, Value: []byte("smtp is down")},
+		{Key: headerDLQTopic, Value: []byte("task-events")},
+		{Key: headerDLQPartition, Value: []byte("2")},
+		{Key: headerDLQOffset, Value: []byte("42")},
+		{Key: headerDLQAttempts, Value: []byte("3")},
+		{Key: headerDLQFailedAt, Value: []byte("2026-05-04T09:00:00Z")},
+	}, msg.Headers)
+}
+
+func TestProcessMessage_PermanentErrorIsNotRetried(t *testing.T) {
+	handler := &fakeHandler{errs: []error{permanent(errors.New("malformed message"))}}
+	dlq := &fakeWriter{}
+
+	ok := newTestConsumer(dlq).processMessage(context.Background(), testMessage(), handler)
+
+	assert.True(t, ok)
+	assert.Equal(t, 1, handler.calls)
+	if assert.Len(t, dlq.messages, 1) {
+		attempts, _ := header(dlq.messages[0], headerDLQAttempts)
+		assert.Equal(t, "1", attempts)
+	}
+}
+
+func TestAttemptDelay(t *testing.T) {
+	c := newTestConsumer(&fakeWriter{})
+	c.retryDelay = time.Second
+
+	assert.Equal(t, 2*time.Second, c.attemptDelay(2, errors.New("smtp is down")))
+	assert.Equal(t, time.Minute, c.attemptDelay(1, &email.RateLimitError{Wait: time.Minute}),
+		"the email over the limit waits for it to refill")
+	assert.Equal(t, maxRateLimitDelay, c.attemptDelay(1, fmt.Errorf("send: %w", &email.RateLimitError{Wait: time.Hour})))
+}
+
+func TestProcessMessage_CancelledLeavesMessageUncommitted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	handler := &fakeHandler{errs: []error{permanent(errors.New("malformed message"))}}
+	dlq := &fakeWriter{errs: []error{context.Canceled}}
+
+	ok := newTestConsumer(dlq).processMessage(ctx, testMessage(), handler)
+
+	assert.False(t, ok)
+	assert.Empty(t, dlq.messages)
+}
+
+func TestReplay(t *testing.T) {
+	failed := deadLetterMessage(testMessage(), "task-events.dlq", errors.New("smtp is down"), 3, testNow)
+	failed.Offset = 7
+	reader := &fakeFetcher{pending: []kafka.Message{failed}}
+	writer := &fakeWriter{}
+
+	count, err := replay(context.Background(), reader, writer, "task-events", 0, 10*time.Millisecond,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []int64{7}, reader.committed)
+	if assert.Len(t, writer.messages, 1) {
+		msg := writer.messages[0]
+		assert.Equal(t, "task-events", msg.Topic)
+		assert.Equal(t, []byte("10"), msg.Key)
+		assert.Equal(t, []kafka.Header{{Key: headerContentType, Value: []byte(contentTypeProtobuf)}}, msg.Headers,
+			"the DLQ headers are removed")
+	}
+}
@@ -6,21 +6,29 @@ import (
 	"fmt"
 	"log/slog"
 
-	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/segmentio/kafka-go"
 )
 
+// eventsHandler sends the JSON email events of the older tasks versions, which are replaced by the task events.
 type eventsHandler struct {
-	emailService *email.EmailSenderService
+	emailService EventEmailSender
+	dedup        dedup.Store
 	log          *slog.Logger
 }
 
-func (h *eventsHandler) HandleMessage(ctx context.Context, message []byte) error {
+func (h *eventsHandler) HandleMessage(ctx context.Context, msg kafka.Message) error {
 	var eventMsg models.EventMessage
-	if err := json.Unmarshal(message, &eventMsg); err != nil {
-		return fmt.Errorf("unmarshal event message: %w", err)
+	if err := json.Unmarshal(msg.Value, &eventMsg); err != nil {
+		return permanent(fmt.Errorf("unmarshal event message: %w", err))
 	}
 
 	h.log.Info("Received event request", "email", eventMsg.Email)
-	return h.emailService.SendEventEmail(eventMsg)
+
+	key := dedup.Key(messageEventId(msg, ""), models.ChannelEmail, eventMsg.Email)
+	_, err := dedup.Once(ctx, h.dedup, key, func() error {
+		return h.emailService.SendEventEmail(eventMsg)
+	})
+	return err
 }
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/config"
+	"github.com/segmentio/kafka-go"
+)
+
+// ReplayDLQ moves up to limit messages from the DLQ of the topic back to the topic, a zero limit moves all of them.
+// It stops when no message arrives for the wait duration. The DLQ is read by its own consumer group,
+// so a message is replayed once even when the command is run again.
+func ReplayDLQ(ctx context.Context, cfg config.Kafka, topic string, limit int, wait time.Duration, log *slog.Logger) (int, error) {
+	dlqTopic := topic + cfg.DLQSuffix
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		GroupID: cfg.GroupId + "-dlq-replay",
+		Topic:   dlqTopic,
+		MaxWait: time.Second,
+	})
+	defer reader.Close()
+
+	writer := newWriter(cfg.Brokers)
+	defer writer.Close()
+
+	return replay(ctx, reader, writer, topic, limit, wait, log.With(slog.String("dlq", dlqTopic)))
+}
+
+type messageFetcher interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+func replay(ctx context.Context, reader messageFetcher, writer messageWriter, topic string, limit int, wait time.Duration,
+	log *slog.Logger) (int, error) {
+	replayed := 0
+	for limit == 0 || replayed < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, wait)
+		msg, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			break
+		}
+		if err != nil {
+			return replayed, fmt.Errorf("fetch DLQ message: %w", err)
+		}
+
+		replayMsg := replayMessage(msg, topic)
+		if err := writer.WriteMessages(ctx, replayMsg); err != nil {
+			return replayed, fmt.Errorf("write message to %s: %w", replayMsg.Topic, err)
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return replayed, fmt.Errorf("commit DLQ message: %w", err)
+		}
+
+		errText, _ := header(msg, headerDLQError)
+		log.Info("Message replayed", "topic", replayMsg.Topic, "offset", msg.Offset, "error", errText)
+		replayed++
+	}
+
+	return replayed, nil
+}
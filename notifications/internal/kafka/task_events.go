@@ -0,0 +1,118 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/delivery"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/segmentio/kafka-go"
+)
+
+const taskEventVersion = 1
+
+type EventEmailSender interface {
+	SendEventEmail(msg models.EventMessage) error
+}
+
+type Dispatcher interface {
+	Dispatch(ctx context.Context, n delivery.Notification) error
+}
+
+// taskEventsHandler derives the notifications from the task events and passes them to the dispatcher.
+type taskEventsHandler struct {
+	dispatcher Dispatcher
+	log        *slog.Logger
+}
+
+func (h *taskEventsHandler) HandleMessage(ctx context.Context, msg kafka.Message) error {
+	event, err := decodeTaskEvent(msg)
+	if err != nil {
+		return permanent(err)
+	}
+
+	log := h.log.With("event_id", event.Id, "event_type", event.Type, "task_id", event.TaskId)
+	if event.Version != taskEventVersion {
+		log.Warn("Unsupported task event version skipped", "version", event.Version)
+		return nil
+	}
+
+	log.Info("Received task event")
+	eventId := messageEventId(msg, event.Id)
+	for _, n := range eventNotifications(event) {
+		n.Msg.EventId = eventId
+		if err := h.dispatcher.Dispatch(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventNotifications returns the notifications the event produces: the user is notified about their own change
+// and a new assignee is told about the task unless they assigned it to themselves.
+func eventNotifications(event *models.TaskEvent) []delivery.Notification {
+	task := event.Task
+	notification := func(recipientId int64, eventType string) delivery.Notification {
+		return delivery.Notification{
+			RecipientId: recipientId,
+			Msg: models.EventMessage{
+				TaskId:     event.TaskId,
+				OccurredAt: event.OccurredAt,
+				Type:       eventType,
+				TaskText:   task.Text,
+				TaskStatus: task.Status,
+			},
+		}
+	}
+
+	switch event.Type {
+	case models.TaskEventCreated:
+		return []delivery.Notification{notification(event.UserId, models.EventTypeCreate)}
+	case models.TaskEventDeleted:
+		return []delivery.Notification{notification(event.UserId, models.EventTypeDelete)}
+	case models.TaskEventRestored:
+		return []delivery.Notification{notification(event.UserId, models.EventTypeRestore)}
+	case models.TaskEventPurged:
+		return []delivery.Notification{notification(event.UserId, models.EventTypePurge)}
+	case models.TaskEventReminderDue:
+		reminder := notification(event.UserId, models.EventTypeReminder)
+		reminder.Msg.TaskDueAt = task.DueAt
+		return []delivery.Notification{reminder}
+	case models.TaskEventUpdated:
+	default:
+		return nil
+	}
+
+	var notifications []delivery.Notification
+	if _, ok := event.Change(models.TaskFieldStatus); ok {
+		notifications = append(notifications, notification(event.UserId, models.EventTypeStatus))
+	} else if hasChangesBesides(event, models.TaskFieldAssigneeId) {
+		update := notification(event.UserId, models.EventTypeUpdate)
+		update.Msg.TaskOldText = task.Text
+		if change, ok := event.Change(models.TaskFieldText); ok {
+			update.Msg.TaskOldText = change.Old
+		}
+		notifications = append(notifications, update)
+	}
+
+	if change, ok := event.Change(models.TaskFieldAssigneeId); ok && change.New != "" {
+		assigneeId, err := strconv.ParseInt(change.New, 10, 64)
+		if err == nil && assigneeId != event.UserId {
+			assign := notification(assigneeId, models.EventTypeAssign)
+			assign.ActorId = event.UserId
+			assign.Msg.TaskDueAt = task.DueAt
+			notifications = append(notifications, assign)
+		}
+	}
+	return notifications
+}
+
+func hasChangesBesides(event *models.TaskEvent, field string) bool {
+	for _, c := range event.Changes {
+		if c.Field != field {
+			return true
+		}
+	}
+	return false
+}
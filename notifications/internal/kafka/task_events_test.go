@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/delivery"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDispatcher struct {
+	dispatched []delivery.Notification
+	err        error
+}
+
+func (f *fakeDispatcher) Dispatch(ctx context.Context, n delivery.Notification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.dispatched = append(f.dispatched, n)
+	return nil
+}
+
+func newTestTaskEventsHandler(dispatcher *fakeDispatcher) *taskEventsHandler {
+	return &taskEventsHandler{
+		dispatcher: dispatcher,
+		log:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func taskEvent(t *testing.T, eventType string, userId int64, changes ...models.FieldChange) kafka.Message {
+	event := models.TaskEvent{
+		Id:      "event-1",
+		Type:    eventType,
+		Version: 1,
+		TaskId:  10,
+		UserId:  userId,
+		Changes: changes,
+		Task:    models.TaskSnapshot{Text: "pay the bills", Status: "todo"},
+	}
+	value, err := json.Marshal(&event)
+	require.NoError(t, err)
+	return kafka.Message{Value: value}
+}
+
+func TestTaskEventsHandler(t *testing.T) {
+	msg := func(eventType string) models.EventMessage {
+		return models.EventMessage{EventId: "event-1", TaskId: 10, Type: eventType, TaskText: "pay the bills", TaskStatus: "todo"}
+	}
+	update := msg(models.EventTypeUpdate)
+	update.TaskOldText = "pay"
+
+	tests := []struct {
+		name     string
+		message  kafka.Message
+		expected []delivery.Notification
+	}{
+		{
+			name:     "created task",
+			message:  taskEvent(t, models.TaskEventCreated, 1),
+			expected: []delivery.Notification{{RecipientId: 1, Msg: msg(models.EventTypeCreate)}},
+		},
+		{
+			name:     "changed text",
+			message:  taskEvent(t, models.TaskEventUpdated, 1, models.FieldChange{Field: "text", Old: "pay", New: "pay the bills"}),
+			expected: []delivery.Notification{{RecipientId: 1, Msg: update}},
+		},
+		{
+			name:     "changed status",
+			message:  taskEvent(t, models.TaskEventUpdated, 1, models.FieldChange{Field: "status", Old: "in_progress", New: "todo"}),
+			expected: []delivery.Notification{{RecipientId: 1, Msg: msg(models.EventTypeStatus)}},
+		},
+		{
+			name:     "assigned to another user",
+			message:  taskEvent(t, models.TaskEventUpdated, 1, models.FieldChange{Field: "assignee_id", New: "2"}),
+			expected: []delivery.Notification{{RecipientId: 2, ActorId: 1, Msg: msg(models.EventTypeAssign)}},
+		},
+		{
+			name: "changed text and assigned",
+			message: taskEvent(t, models.TaskEventUpdated, 1,
+				models.FieldChange{Field: "text", Old: "pay", New: "pay the bills"},
+				models.FieldChange{Field: "assignee_id", New: "2"}),
+			expected: []delivery.Notification{
+				{RecipientId: 1, Msg: update},
+				{RecipientId: 2, ActorId: 1, Msg: msg(models.EventTypeAssign)},
+			},
+		},
+		{
+			name:     "assigned to themselves",
+			message:  taskEvent(t, models.TaskEventUpdated, 1, models.FieldChange{Field: "assignee_id", New: "1"}),
+			expected: nil,
+		},
+		{
+			name:     "unknown event type",
+			message:  taskEvent(t, "task.archived", 1),
+			expected: nil,
+		},
+		{
+			name:     "unsupported version",
+			message:  kafka.Message{Value: []byte(`{"id":"event-1","type":"task.created","version":2,"task_id":10,"user_id":1}`)},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := &fakeDispatcher{}
+			err := newTestTaskEventsHandler(dispatcher).HandleMessage(context.Background(), tt.message)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, dispatcher.dispatched)
+		})
+	}
+}
+
+func TestTaskEventsHandler_DispatchError(t *testing.T) {
+	dispatcher := &fakeDispatcher{err: errors.New("auth is down")}
+
+	err := newTestTaskEventsHandler(dispatcher).HandleMessage(context.Background(), taskEvent(t, models.TaskEventCreated, 1))
+
+	assert.Error(t, err, "the message is retried")
+	assert.False(t, isPermanent(err))
+}
+
+func TestTaskEventsHandler_MalformedMessage(t *testing.T) {
+	err := newTestTaskEventsHandler(&fakeDispatcher{}).HandleMessage(context.Background(), kafka.Message{Value: []byte("{")})
+
+	assert.Error(t, err)
+	assert.True(t, isPermanent(err))
+}
@@ -2,25 +2,34 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log/slog"
 
+	"github.com/Novip1906/tasks-grpc/notifications/internal/dedup"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/email"
 	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/segmentio/kafka-go"
 )
 
 type emailVerificationHandler struct {
 	emailService *email.EmailSenderService
+	dedup        dedup.Store
 	log          *slog.Logger
 }
 
-func (h *emailVerificationHandler) HandleMessage(ctx context.Context, message []byte) error {
-	var verificationMsg models.EmailVerificationMessage
-	if err := json.Unmarshal(message, &verificationMsg); err != nil {
-		return fmt.Errorf("unmarshal verification message: %w", err)
+func (h *emailVerificationHandler) HandleMessage(ctx context.Context, msg kafka.Message) error {
+	verificationMsg, err := decodeVerificationMessage(msg)
+	if err != nil {
+		return permanent(err)
 	}
 
 	h.log.Info("Received verification email request", "email", verificationMsg.Email)
-	return h.emailService.SendVerificationEmail(verificationMsg)
+
+	key := dedup.Key(messageEventId(msg, verificationMsg.EventId), models.ChannelEmail, verificationMsg.Email)
+	sent, err := dedup.Once(ctx, h.dedup, key, func() error {
+		return h.emailService.SendVerificationEmail(verificationMsg)
+	})
+	if err == nil && !sent {
+		h.log.Info("Duplicate verification email skipped", "email", verificationMsg.Email)
+	}
+	return err
 }
@@ -0,0 +1,16 @@
+package models
+
+const (
+	ChannelEmail    = "email"
+	ChannelWebhook  = "webhook"
+	ChannelTelegram = "telegram"
+	ChannelSlack    = "slack"
+)
+
+// Channel delivers the notifications of a user. Target is the email, the webhook URL, the Telegram chat id
+// or the Slack incoming webhook URL, Secret signs the webhook requests.
+type Channel struct {
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// DigestMessage is the email summarising the changes of the tasks of the user, grouped by day.
+type DigestMessage struct {
+	Email     string
+	Username  string
+	Frequency string
+	Locale    string
+	Days      []DigestDay
+	// set by the email sender for the template
+	UnsubscribeURL string
+}
+
+// DigestDay lists the tasks changed during the day, each task is listed once per section.
+type DigestDay struct {
+	Date      time.Time
+	Created   []DigestTask
+	Updated   []DigestTask
+	Completed []DigestTask
+	Deleted   []DigestTask
+}
+
+type DigestTask struct {
+	TaskId int64
+	Text   string
+	Status string
+}
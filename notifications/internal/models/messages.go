@@ -1,15 +1,105 @@
 package models
 
+import "time"
+
 type EmailVerificationMessage struct {
 	Email    string `json:"email"`
 	Code     string `json:"code"`
 	Username string `json:"username"`
+	EventId  string `json:"event_id"`
+	Locale   string `json:"locale,omitempty"`
 }
 
+// EventMessage is the notification about a change of a task, the "assign" and "reminder" emails are rendered
+// with their own templates. The older tasks versions sent it to Kafka, now it's derived from the task events.
 type EventMessage struct {
-	Email       string `json:"email"`
-	Username    string `json:"username"`
-	Type        string `json:"type"`
-	TaskText    string `json:"task_text"`
-	TaskOldText string `json:"task_old_text"`
+	EventId     string     `json:"event_id,omitempty"`
+	TaskId      int64      `json:"task_id,omitempty"`
+	Email       string     `json:"email"`
+	Username    string     `json:"username"`
+	Type        string     `json:"type"`
+	TaskText    string     `json:"task_text"`
+	TaskOldText string     `json:"task_old_text"`
+	TaskStatus  string     `json:"task_status"`
+	TaskDueAt   *time.Time `json:"task_due_at,omitempty"`
+	Actor       string     `json:"actor,omitempty"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+	// language of the email, the default one when empty
+	Locale string `json:"locale,omitempty"`
+	// set by the email sender for the template
+	UnsubscribeURL string `json:"-"`
+}
+
+const (
+	EventTypeCreate   = "create"
+	EventTypeUpdate   = "update"
+	EventTypeDelete   = "delete"
+	EventTypeRestore  = "restore"
+	EventTypePurge    = "purge"
+	EventTypeStatus   = "status"
+	EventTypeAssign   = "assign"
+	EventTypeReminder = "reminder"
+)
+
+const (
+	TaskEventCreated     = "task.created"
+	TaskEventUpdated     = "task.updated"
+	TaskEventDeleted     = "task.deleted"
+	TaskEventRestored    = "task.restored"
+	TaskEventPurged      = "task.purged"
+	TaskEventReminderDue = "task.reminder_due"
+)
+
+const (
+	TaskFieldText       = "text"
+	TaskFieldStatus     = "status"
+	TaskFieldAssigneeId = "assignee_id"
+)
+
+// TaskEvent is a domain event of a task published by the tasks service, UserId is the user who made the change
+// or the recipient of the reminder. Version 1 is the only known version.
+type TaskEvent struct {
+	Id         string        `json:"id"`
+	Type       string        `json:"type"`
+	Version    int           `json:"version"`
+	TaskId     int64         `json:"task_id"`
+	UserId     int64         `json:"user_id"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+	Task       TaskSnapshot  `json:"task"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type TaskSnapshot struct {
+	Text       string     `json:"text"`
+	Status     string     `json:"status"`
+	Priority   int        `json:"priority"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	AuthorId   int64      `json:"author_id"`
+	AssigneeId int64      `json:"assignee_id,omitempty"`
+	ProjectId  int64      `json:"project_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Change returns the change of the field, or false when the field isn't changed.
+func (e *TaskEvent) Change(field string) (FieldChange, bool) {
+	for _, c := range e.Changes {
+		if c.Field == field {
+			return c, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+type User struct {
+	Id       int64
+	Username string
+	Email    string
+	Locale   string
 }
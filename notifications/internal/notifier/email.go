@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+type EmailSender interface {
+	SendEventEmail(msg models.EventMessage) error
+}
+
+// EmailNotifier sends the notifications with the email templates through SMTP.
+type EmailNotifier struct {
+	sender EmailSender
+}
+
+func NewEmailNotifier(sender EmailSender) *EmailNotifier {
+	return &EmailNotifier{sender: sender}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error {
+	msg.Email = channel.Target
+	return n.sender.SendEventEmail(msg)
+}
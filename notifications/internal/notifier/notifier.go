@@ -0,0 +1,125 @@
+// Package notifier delivers the task notifications through the channels configured by the users.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// maxErrorBody limits the part of the response body kept in StatusError
+const maxErrorBody = 512
+
+var (
+	ErrInvalidTarget    = errors.New("invalid channel target")
+	ErrForbiddenAddress = errors.New("forbidden channel address")
+)
+
+// Notifier sends the notification to the target of the channel.
+type Notifier interface {
+	Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error
+}
+
+// StatusError is returned when the channel responds with a non-2xx status.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.Code, e.Body)
+}
+
+// IsPermanent tells whether the channel rejected the notification for a reason retries won't fix,
+// like a removed webhook or a Telegram chat the bot isn't a member of.
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrInvalidTarget) || errors.Is(err, ErrForbiddenAddress) {
+		return true
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		code := statusErr.Code
+		return code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+	}
+	return false
+}
+
+// RetryAfter returns the delay the channel asked to wait for before the notification is sent again,
+// like the email limit of the recipient, ok is false when it didn't ask for one.
+func RetryAfter(err error) (delay time.Duration, ok bool) {
+	var retry interface{ RetryAfter() time.Duration }
+	if errors.As(err, &retry) {
+		return retry.RetryAfter(), true
+	}
+	return 0, false
+}
+
+// NewHTTPClient returns the client for the HTTP channels. The users choose the URLs the notifications are posted to,
+// so unless allowPrivate is set the client refuses to connect to the loopback, private and link-local addresses.
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be the only address checked
+	transport.Proxy = nil
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// postJSON posts the body to the URL and checks the response status. The URL isn't put into the errors,
+// as the Slack webhook URLs and the Telegram API URLs hold secrets.
+func postJSON(ctx context.Context, client *http.Client, target string, body []byte, header http.Header) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidTarget
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTarget, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{Code: resp.StatusCode, Body: string(respBody)}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// SlackNotifier posts the notifications to the Slack incoming webhook of the user.
+type SlackNotifier struct {
+	client *http.Client
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func NewSlackNotifier(client *http.Client) *SlackNotifier {
+	return &SlackNotifier{client: client}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error {
+	body, err := json.Marshal(slackMessage{Text: messageText(msg)})
+	if err != nil {
+		return err
+	}
+
+	return postJSON(ctx, n.client, channel.Target, body, nil)
+}
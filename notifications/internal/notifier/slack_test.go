@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotifier(t *testing.T) {
+	var message slackMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	dueAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	msg := models.EventMessage{Type: models.EventTypeReminder, TaskText: "pay the bills", TaskDueAt: &dueAt}
+	channel := models.Channel{Type: models.ChannelSlack, Target: srv.URL + "/services/T000/B000/XXX"}
+	require.NoError(t, NewSlackNotifier(srv.Client()).Notify(context.Background(), channel, msg))
+
+	assert.Equal(t, "Напоминание о задаче: pay the bills\nСрок: 01.03.2026 09:30 UTC", message.Text)
+}
+
+func TestSlackNotifier_ConnectionErrorHidesURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	target := srv.URL + "/services/T000/B000/XXX"
+	srv.Close()
+
+	err := NewSlackNotifier(http.DefaultClient).Notify(context.Background(),
+		models.Channel{Type: models.ChannelSlack, Target: target}, models.EventMessage{})
+
+	require.Error(t, err)
+	assert.False(t, IsPermanent(err), "connection errors are retried")
+	assert.NotContains(t, err.Error(), "XXX")
+}
+
+func TestMessageText(t *testing.T) {
+	tests := []struct {
+		msg      models.EventMessage
+		expected string
+	}{
+		{models.EventMessage{Type: models.EventTypeCreate, TaskText: "a"}, "Создана задача: a"},
+		{models.EventMessage{Type: models.EventTypeUpdate, TaskOldText: "a", TaskText: "b"}, "Задача изменена: «a» → «b»"},
+		{models.EventMessage{Type: models.EventTypeStatus, TaskStatus: "done", TaskText: "a"}, "Задача выполнена: a"},
+		{models.EventMessage{Type: models.EventTypeStatus, TaskStatus: "in_progress", TaskText: "a"}, "Статус задачи изменён на «В работе»: a"},
+		{models.EventMessage{Type: models.EventTypeAssign, TaskText: "a"}, "Вам назначена задача: a"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, messageText(tt.msg), tt.msg.Type)
+	}
+}
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+// TelegramNotifier sends the notifications to the chat of the user through the Telegram bot API,
+// the user has to start the bot first for it to be allowed to write to the chat.
+type TelegramNotifier struct {
+	client *http.Client
+	apiURL string
+	token  string
+}
+
+type telegramMessage struct {
+	ChatId                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+func NewTelegramNotifier(client *http.Client, apiURL, token string) *TelegramNotifier {
+	return &TelegramNotifier{client: client, apiURL: strings.TrimSuffix(apiURL, "/"), token: token}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error {
+	body, err := json.Marshal(telegramMessage{
+		ChatId:                channel.Target,
+		Text:                  messageText(msg),
+		DisableWebPagePreview: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return postJSON(ctx, n.client, n.apiURL+"/bot"+n.token+"/sendMessage", body, nil)
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramNotifier(t *testing.T) {
+	var path string
+	var message telegramMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	n := NewTelegramNotifier(srv.Client(), srv.URL+"/", "123:abc")
+	channel := models.Channel{Type: models.ChannelTelegram, Target: "42"}
+	msg := models.EventMessage{Type: models.EventTypeAssign, TaskText: "pay the bills", Actor: "alice"}
+	require.NoError(t, n.Notify(context.Background(), channel, msg))
+
+	assert.Equal(t, "/bot123:abc/sendMessage", path)
+	assert.Equal(t, "42", message.ChatId)
+	assert.Equal(t, "alice назначил(а) вам задачу: pay the bills", message.Text)
+}
+
+func TestTelegramNotifier_ChatNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+	}))
+	defer srv.Close()
+
+	err := NewTelegramNotifier(srv.Client(), srv.URL, "123:abc").Notify(context.Background(),
+		models.Channel{Type: models.ChannelTelegram, Target: "42"}, models.EventMessage{Type: models.EventTypeCreate})
+
+	require.Error(t, err)
+	assert.True(t, IsPermanent(err))
+	assert.Contains(t, err.Error(), "chat not found")
+	assert.NotContains(t, err.Error(), "123:abc", "the bot token isn't leaked to the logs")
+}
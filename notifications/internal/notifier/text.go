@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+var statusNames = map[string]string{
+	"todo":        "К выполнению",
+	"in_progress": "В работе",
+	"done":        "Выполнена",
+	"cancelled":   "Отменена",
+}
+
+// messageText renders the notification as plain text for the chat channels, it follows the email templates.
+func messageText(msg models.EventMessage) string {
+	switch msg.Type {
+	case models.EventTypeCreate:
+		return "Создана задача: " + msg.TaskText
+	case models.EventTypeUpdate:
+		return fmt.Sprintf("Задача изменена: «%s» → «%s»", msg.TaskOldText, msg.TaskText)
+	case models.EventTypeDelete:
+		return "Задача перемещена в корзину: " + msg.TaskText
+	case models.EventTypeRestore:
+		return "Задача восстановлена из корзины: " + msg.TaskText
+	case models.EventTypePurge:
+		return "Задача удалена навсегда: " + msg.TaskText
+	case models.EventTypeStatus:
+		if msg.TaskStatus == "done" {
+			return "Задача выполнена: " + msg.TaskText
+		}
+		return fmt.Sprintf("Статус задачи изменён на «%s»: %s", statusName(msg.TaskStatus), msg.TaskText)
+	case models.EventTypeAssign:
+		text := "Вам назначена задача: " + msg.TaskText
+		if msg.Actor != "" {
+			text = fmt.Sprintf("%s назначил(а) вам задачу: %s", msg.Actor, msg.TaskText)
+		}
+		return text + dueText(msg)
+	case models.EventTypeReminder:
+		return "Напоминание о задаче: " + msg.TaskText + dueText(msg)
+	}
+	return "Уведомление о задаче: " + msg.TaskText
+}
+
+func statusName(status string) string {
+	if name, ok := statusNames[status]; ok {
+		return name
+	}
+	return status
+}
+
+func dueText(msg models.EventMessage) string {
+	if msg.TaskDueAt == nil {
+		return ""
+	}
+	return "\nСрок: " + msg.TaskDueAt.UTC().Format("02.01.2006 15:04") + " UTC"
+}
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+)
+
+const (
+	SignatureHeader = "X-Tasks-Signature"
+	TimestampHeader = "X-Tasks-Timestamp"
+)
+
+// WebhookNotifier posts the notifications as JSON to the URL of the user, signed with the secret of the channel.
+type WebhookNotifier struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+type webhookPayload struct {
+	EventId  string      `json:"event_id"`
+	Type     string      `json:"type"`
+	TaskId   int64       `json:"task_id"`
+	Username string      `json:"username"`
+	Actor    string      `json:"actor,omitempty"`
+	Task     webhookTask `json:"task"`
+}
+
+type webhookTask struct {
+	Text    string     `json:"text"`
+	OldText string     `json:"old_text,omitempty"`
+	Status  string     `json:"status"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
+}
+
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	return &WebhookNotifier{client: client, now: time.Now}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, channel models.Channel, msg models.EventMessage) error {
+	body, err := json.Marshal(webhookPayload{
+		EventId:  msg.EventId,
+		Type:     msg.Type,
+		TaskId:   msg.TaskId,
+		Username: msg.Username,
+		Actor:    msg.Actor,
+		Task: webhookTask{
+			Text:    msg.TaskText,
+			OldText: msg.TaskOldText,
+			Status:  msg.TaskStatus,
+			DueAt:   msg.TaskDueAt,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := n.now().Unix()
+	header := http.Header{}
+	header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	header.Set(SignatureHeader, Sign(channel.Secret, timestamp, body))
+
+	return postJSON(ctx, n.client, channel.Target, body, header)
+}
+
+// Sign returns the signature of a webhook request: "sha256=" and the hex HMAC-SHA256 of the timestamp,
+// a dot and the body keyed with the channel secret. The receivers should compare it in constant time
+// and reject the old timestamps, so a captured request can't be replayed.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_SignsPayload(t *testing.T) {
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.Client())
+	n.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	channel := models.Channel{Type: models.ChannelWebhook, Target: srv.URL + "/hook", Secret: "s3cret"}
+	msg := models.EventMessage{EventId: "event-1", TaskId: 10, Username: "alice", Type: models.EventTypeCreate,
+		TaskText: "pay the bills", TaskStatus: "todo", Email: "alice@example.com"}
+	require.NoError(t, n.Notify(context.Background(), channel, msg))
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "1700000000", header.Get(TimestampHeader))
+	assert.Equal(t, Sign("s3cret", 1700000000, body), header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("other", 1700000000, body), header.Get(SignatureHeader))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "event-1", payload["event_id"])
+	assert.Equal(t, "create", payload["type"])
+	assert.Equal(t, float64(10), payload["task_id"])
+	assert.Equal(t, map[string]any{"text": "pay the bills", "status": "todo"}, payload["task"])
+	assert.NotContains(t, string(body), "alice@example.com", "the email isn't shared with the webhook")
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "gone", status: http.StatusGone, permanent: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, permanent: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, permanent: false},
+		{name: "server error", status: http.StatusBadGateway, permanent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nope", tt.status)
+			}))
+			defer srv.Close()
+
+			err := NewWebhookNotifier(srv.Client()).Notify(context.Background(),
+				models.Channel{Type: models.ChannelWebhook, Target: srv.URL}, models.EventMessage{})
+
+			var statusErr *StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.status, statusErr.Code)
+			assert.Equal(t, tt.permanent, IsPermanent(err))
+		})
+	}
+}
+
+func TestWebhookNotifier_InvalidTarget(t *testing.T) {
+	for _, target := range []string{"", "ftp://example.com/hook", "example.com/hook", "http://"} {
+		err := NewWebhookNotifier(http.DefaultClient).Notify(context.Background(),
+			models.Channel{Type: models.ChannelWebhook, Target: target}, models.EventMessage{})
+
+		assert.ErrorIs(t, err, ErrInvalidTarget, target)
+		assert.True(t, IsPermanent(err), target)
+	}
+}
+
+func TestNewHTTPClient_RefusesPrivateAddresses(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	channel := models.Channel{Type: models.ChannelWebhook, Target: srv.URL}
+
+	err := NewWebhookNotifier(NewHTTPClient(time.Second, false)).Notify(context.Background(), channel, models.EventMessage{})
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.True(t, IsPermanent(err))
+	assert.False(t, called)
+
+	err = NewWebhookNotifier(NewHTTPClient(time.Second, true)).Notify(context.Background(), channel, models.EventMessage{})
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,32 @@
+package preferences
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps the preferences in memory.
+type MemoryStore struct {
+	mu    sync.Mutex
+	prefs map[int64]Preferences
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{prefs: make(map[int64]Preferences)}
+}
+
+func (m *MemoryStore) Get(ctx context.Context, userId int64) (*Preferences, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefs := m.prefs[userId]
+	return &prefs, nil
+}
+
+func (m *MemoryStore) Set(ctx context.Context, userId int64, prefs *Preferences) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prefs[userId] = *prefs
+	return nil
+}
@@ -0,0 +1,124 @@
+// Package preferences stores which notifications the users want to get and when.
+package preferences
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Preferences of a user, the zero value enables every notification at any time.
+type Preferences struct {
+	// event types (models.EventType*) the user isn't notified about
+	DisabledEvents []string `json:"disabled_events,omitempty"`
+	// channel types (models.Channel*) the notifications aren't delivered through, the email included
+	DisabledChannels []string    `json:"disabled_channels,omitempty"`
+	QuietHours       *QuietHours `json:"quiet_hours,omitempty"`
+	// nil sends every email right away
+	Digest *Digest `json:"digest,omitempty"`
+}
+
+// QuietHours is the daily period the notifications are held back in, Start and End are "HH:MM"
+// in the timezone. The period crosses midnight when End is before Start, like 22:00-08:00.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Digest collects the emails into a daily or a weekly summary sent at Time ("HH:MM") in the timezone,
+// the weekly one on Mondays. The reminders are still sent right away.
+type Digest struct {
+	Frequency string `json:"frequency"`
+	Time      string `json:"time"`
+	Timezone  string `json:"timezone"`
+}
+
+type Store interface {
+	// Get returns the preferences of the user, the defaults when they never changed them.
+	Get(ctx context.Context, userId int64) (*Preferences, error)
+	Set(ctx context.Context, userId int64, prefs *Preferences) error
+}
+
+func (p *Preferences) EventEnabled(eventType string) bool {
+	return !slices.Contains(p.DisabledEvents, eventType)
+}
+
+func (p *Preferences) ChannelEnabled(channelType string) bool {
+	return !slices.Contains(p.DisabledChannels, channelType)
+}
+
+// QuietUntil returns the end of the quiet hours when the time falls into them.
+func (p *Preferences) QuietUntil(now time.Time) (time.Time, bool) {
+	if p.QuietHours == nil {
+		return time.Time{}, false
+	}
+	return p.QuietHours.Until(now)
+}
+
+// Until returns the end of the quiet period the time falls into. Malformed quiet hours are never quiet.
+func (q *QuietHours) Until(now time.Time) (time.Time, bool) {
+	start, okStart := ParseClock(q.Start)
+	end, okEnd := ParseClock(q.End)
+	loc, err := time.LoadLocation(q.Timezone)
+	if !okStart || !okEnd || err != nil || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	quiet := minute >= start && minute < end
+	if end < start {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	year, month, day := local.Date()
+	if minute >= end {
+		day++
+	}
+	return time.Date(year, month, day, end/60, end%60, 0, 0, loc), true
+}
+
+// ParseClock returns the minute of the day of a "HH:MM" time.
+func ParseClock(s string) (int, bool) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, false
+	}
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// NextAfter returns the first digest time strictly after the given time, or false when the digest is malformed.
+// The digest keeps its wall clock across DST changes.
+func (d *Digest) NextAfter(t time.Time) (time.Time, bool) {
+	minute, ok := ParseClock(d.Time)
+	loc, err := time.LoadLocation(d.Timezone)
+	if !ok || err != nil || (d.Frequency != DigestDaily && d.Frequency != DigestWeekly) {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	year, month, day := local.Date()
+	next := time.Date(year, month, day, minute/60, minute%60, 0, 0, loc)
+	for !next.After(t) || (d.Frequency == DigestWeekly && next.Weekday() != time.Monday) {
+		day++
+		next = time.Date(year, month, day, minute/60, minute%60, 0, 0, loc)
+	}
+	return next, true
+}
@@ -0,0 +1,117 @@
+package preferences
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursUntil(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	night := &QuietHours{Start: "22:00", End: "08:00", Timezone: "Europe/Moscow"}
+	lunch := &QuietHours{Start: "13:00", End: "14:30", Timezone: "Europe/Moscow"}
+
+	tests := []struct {
+		name      string
+		hours     *QuietHours
+		now       time.Time
+		quiet     bool
+		quietTill time.Time
+	}{
+		{"before night", night, time.Date(2026, 3, 1, 21, 59, 0, 0, moscow), false, time.Time{}},
+		{"night start", night, time.Date(2026, 3, 1, 22, 0, 0, 0, moscow), true, time.Date(2026, 3, 2, 8, 0, 0, 0, moscow)},
+		{"after midnight", night, time.Date(2026, 3, 2, 3, 0, 0, 0, moscow), true, time.Date(2026, 3, 2, 8, 0, 0, 0, moscow)},
+		{"night end", night, time.Date(2026, 3, 2, 8, 0, 0, 0, moscow), false, time.Time{}},
+		{"UTC time in Moscow night", night, time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC), true, time.Date(2026, 3, 2, 8, 0, 0, 0, moscow)},
+		{"lunch", lunch, time.Date(2026, 3, 1, 13, 10, 0, 0, moscow), true, time.Date(2026, 3, 1, 14, 30, 0, 0, moscow)},
+		{"after lunch", lunch, time.Date(2026, 3, 1, 14, 30, 0, 0, moscow), false, time.Time{}},
+		{"month end", night, time.Date(2026, 3, 31, 23, 0, 0, 0, moscow), true, time.Date(2026, 4, 1, 8, 0, 0, 0, moscow)},
+		{
+			"DST change keeps the wall clock",
+			&QuietHours{Start: "22:00", End: "08:00", Timezone: "Europe/Berlin"},
+			time.Date(2026, 3, 28, 23, 0, 0, 0, berlin),
+			true,
+			time.Date(2026, 3, 29, 8, 0, 0, 0, berlin),
+		},
+		{"empty period", &QuietHours{Start: "10:00", End: "10:00", Timezone: "UTC"}, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"unknown timezone", &QuietHours{Start: "00:00", End: "23:59", Timezone: "Mars/Olympus"}, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.hours.Until(tt.now)
+
+			assert.Equal(t, tt.quiet, quiet)
+			assert.True(t, tt.quietTill.Equal(until), "until %s", until)
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	for s, minute := range map[string]int{"00:00": 0, "08:30": 510, "23:59": 1439} {
+		parsed, ok := ParseClock(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, minute, parsed, s)
+	}
+
+	for _, s := range []string{"", "8:30", "24:00", "12:60", "12-30", "ab:cd", "12:300"} {
+		_, ok := ParseClock(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestPreferences(t *testing.T) {
+	var prefs Preferences
+	assert.True(t, prefs.EventEnabled("create"))
+	assert.True(t, prefs.ChannelEnabled("email"))
+	_, quiet := prefs.QuietUntil(time.Now())
+	assert.False(t, quiet)
+
+	prefs = Preferences{DisabledEvents: []string{"update"}, DisabledChannels: []string{"email"}}
+	assert.False(t, prefs.EventEnabled("update"))
+	assert.True(t, prefs.EventEnabled("create"))
+	assert.False(t, prefs.ChannelEnabled("email"))
+	assert.True(t, prefs.ChannelEnabled("slack"))
+}
+
+func TestDigestNextAfter(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	daily := &Digest{Frequency: DigestDaily, Time: "09:00", Timezone: "Europe/Berlin"}
+	weekly := &Digest{Frequency: DigestWeekly, Time: "09:00", Timezone: "Europe/Berlin"}
+
+	tests := []struct {
+		name     string
+		digest   *Digest
+		after    time.Time
+		expected time.Time
+	}{
+		{"daily before the time", daily, time.Date(2026, 3, 4, 8, 0, 0, 0, berlin), time.Date(2026, 3, 4, 9, 0, 0, 0, berlin)},
+		{"daily at the time", daily, time.Date(2026, 3, 4, 9, 0, 0, 0, berlin), time.Date(2026, 3, 5, 9, 0, 0, 0, berlin)},
+		{"daily after the time", daily, time.Date(2026, 3, 4, 10, 0, 0, 0, berlin), time.Date(2026, 3, 5, 9, 0, 0, 0, berlin)},
+		{"daily across DST", daily, time.Date(2026, 3, 28, 10, 0, 0, 0, berlin), time.Date(2026, 3, 29, 9, 0, 0, 0, berlin)},
+		{"weekly on Wednesday", weekly, time.Date(2026, 3, 4, 10, 0, 0, 0, berlin), time.Date(2026, 3, 9, 9, 0, 0, 0, berlin)},
+		{"weekly on Monday morning", weekly, time.Date(2026, 3, 9, 8, 0, 0, 0, berlin), time.Date(2026, 3, 9, 9, 0, 0, 0, berlin)},
+		{"weekly at the time", weekly, time.Date(2026, 3, 9, 9, 0, 0, 0, berlin), time.Date(2026, 3, 16, 9, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := tt.digest.NextAfter(tt.after)
+
+			assert.True(t, ok)
+			assert.True(t, tt.expected.Equal(next), "next %s", next)
+		})
+	}
+
+	_, ok := (&Digest{Frequency: "monthly", Time: "09:00", Timezone: "UTC"}).NextAfter(time.Now())
+	assert.False(t, ok)
+}
@@ -0,0 +1,48 @@
+package preferences
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisPrefix = "preferences:"
+
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Get(ctx context.Context, userId int64) (*Preferences, error) {
+	value, err := r.client.Get(ctx, redisKey(userId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return &Preferences{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var prefs Preferences
+	if err := json.Unmarshal(value, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+func (r *RedisStore) Set(ctx context.Context, userId int64, prefs *Preferences) error {
+	value, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, redisKey(userId), value, 0).Err()
+}
+
+func redisKey(userId int64) string {
+	return redisPrefix + strconv.FormatInt(userId, 10)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	pb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxTargetLen = 2048
+
+// a numeric chat id or a public channel username
+var telegramChatRegex = regexp.MustCompile(`^(-?\d{1,20}|@[A-Za-z][A-Za-z0-9_]{4,31})$`)
+
+type ChannelsStorage interface {
+	List(ctx context.Context, userId int64) ([]models.Channel, error)
+	Set(ctx context.Context, userId int64, channel models.Channel) error
+	Delete(ctx context.Context, userId int64, channelType string) (bool, error)
+}
+
+type NotificationsService struct {
+	pb.UnimplementedNotificationsServiceServer
+	log         *slog.Logger
+	channels    ChannelsStorage
+	preferences PreferencesStorage
+	// channel types the service has notifiers for, the email included
+	channelTypes []string
+}
+
+func NewNotificationsService(log *slog.Logger, channels ChannelsStorage, preferences PreferencesStorage,
+	channelTypes []string) *NotificationsService {
+	return &NotificationsService{log: log, channels: channels, preferences: preferences, channelTypes: channelTypes}
+}
+
+// ListChannels returns the email of the user followed by the channels they configured.
+func (s *NotificationsService) ListChannels(ctx context.Context, req *pb.ListChannelsRequest) (*pb.ListChannelsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	channels, err := s.channels.List(ctx, tokenClaims.UserId)
+	if err != nil {
+		log.Error("channels store error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	resp := &pb.ListChannelsResponse{Channels: make([]*pb.Channel, 0, len(channels)+1)}
+	if tokenClaims.Email != "" {
+		resp.Channels = append(resp.Channels, &pb.Channel{Type: models.ChannelEmail, Target: tokenClaims.Email})
+	}
+	for _, channel := range channels {
+		resp.Channels = append(resp.Channels, channelToPb(channel))
+	}
+	return resp, nil
+}
+
+// SetChannel adds the channel or replaces the channel of the same type. A new secret is generated
+// every time the webhook channel is set, it's returned only once.
+func (s *NotificationsService) SetChannel(ctx context.Context, req *pb.SetChannelRequest) (*pb.SetChannelResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx).With(slog.String("channel", req.GetType()))
+
+	log.Debug("attempt")
+
+	if err := s.checkChannelType(req.GetType()); err != nil {
+		log.Error("channel type invalid")
+		return nil, err
+	}
+
+	target, errMessage := validateTarget(req.GetType(), req.GetTarget())
+	if errMessage != "" {
+		log.Error("channel target invalid")
+		return nil, status.Error(codes.InvalidArgument, errMessage)
+	}
+
+	channel := models.Channel{Type: req.GetType(), Target: target}
+	if channel.Type == models.ChannelWebhook {
+		channel.Secret = generateSecret()
+	}
+
+	if err := s.channels.Set(ctx, tokenClaims.UserId, channel); err != nil {
+		log.Error("channels store error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("channel set")
+
+	return &pb.SetChannelResponse{Channel: channelToPb(channel), Secret: channel.Secret}, nil
+}
+
+func (s *NotificationsService) DeleteChannel(ctx context.Context, req *pb.DeleteChannelRequest) (*pb.DeleteChannelResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx).With(slog.String("channel", req.GetType()))
+
+	if req.GetType() == models.ChannelEmail {
+		log.Error("email channel can`t be deleted")
+		return nil, status.Error(codes.InvalidArgument, ErrEmailChannelMessage)
+	}
+
+	deleted, err := s.channels.Delete(ctx, tokenClaims.UserId, req.GetType())
+	if err != nil {
+		log.Error("channels store error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	if !deleted {
+		log.Error("channel not found")
+		return nil, status.Error(codes.NotFound, ErrChannelNotFoundMessage)
+	}
+
+	log.Info("channel deleted")
+
+	return &pb.DeleteChannelResponse{}, nil
+}
+
+func (s *NotificationsService) checkChannelType(channelType string) error {
+	if channelType == models.ChannelEmail {
+		return status.Error(codes.InvalidArgument, ErrEmailChannelMessage)
+	}
+	if !slices.Contains(s.channelTypes, channelType) {
+		return status.Error(codes.InvalidArgument, ErrInvalidChannelTypeMessage)
+	}
+	return nil
+}
+
+// validateTarget checks the target of the channel and returns it normalized,
+// or the message of the InvalidArgument error.
+func validateTarget(channelType, target string) (string, string) {
+	target = strings.TrimSpace(target)
+	switch channelType {
+	case models.ChannelWebhook:
+		if !urlIsValid(target, "http", "https") {
+			return "", ErrInvalidWebhookURLMessage
+		}
+	case models.ChannelSlack:
+		if !urlIsValid(target, "https") {
+			return "", ErrInvalidSlackURLMessage
+		}
+	case models.ChannelTelegram:
+		if !telegramChatRegex.MatchString(target) {
+			return "", ErrInvalidTelegramChatMessage
+		}
+	default:
+		return "", ErrInvalidChannelTypeMessage
+	}
+	return target, ""
+}
+
+func urlIsValid(target string, schemes ...string) bool {
+	if len(target) > maxTargetLen {
+		return false
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+	return slices.Contains(schemes, u.Scheme)
+}
+
+func generateSecret() string {
+	var b [32]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func channelToPb(channel models.Channel) *pb.Channel {
+	return &pb.Channel{Type: channel.Type, Target: channel.Target}
+}
@@ -0,0 +1,16 @@
+package service
+
+var (
+	ErrInvalidChannelTypeMessage     = "Channel type is invalid, expected webhook, telegram or slack"
+	ErrEmailChannelMessage           = "Email channel always uses the account email"
+	ErrInvalidWebhookURLMessage      = "Webhook URL is invalid, expected http or https URL"
+	ErrInvalidSlackURLMessage        = "Slack webhook URL is invalid, expected https URL"
+	ErrInvalidTelegramChatMessage    = "Telegram chat is invalid, expected chat id or @channel"
+	ErrChannelNotFoundMessage        = "Channel not found"
+	ErrInvalidEventTypeMessage       = "Event type is invalid, expected create, update, delete, restore, purge, status, assign or reminder"
+	ErrInvalidDisabledChannelMessage = "Disabled channel is invalid, expected email, webhook, telegram or slack"
+	ErrInvalidQuietHoursMessage      = "Quiet hours are invalid, expected different start and end like 22:00 and 08:00"
+	ErrInvalidDigestMessage          = "Digest is invalid, expected daily or weekly frequency and time like 09:00"
+	ErrInvalidTimezoneMessage        = "Timezone is invalid, expected IANA name like Europe/Berlin"
+	ErrInternalMessage               = "Server internal error"
+)
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/preferences"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var eventTypes = []string{
+	models.EventTypeCreate,
+	models.EventTypeUpdate,
+	models.EventTypeDelete,
+	models.EventTypeRestore,
+	models.EventTypePurge,
+	models.EventTypeStatus,
+	models.EventTypeAssign,
+	models.EventTypeReminder,
+}
+
+var knownChannelTypes = []string{models.ChannelEmail, models.ChannelWebhook, models.ChannelTelegram, models.ChannelSlack}
+
+type PreferencesStorage interface {
+	Get(ctx context.Context, userId int64) (*preferences.Preferences, error)
+	Set(ctx context.Context, userId int64, prefs *preferences.Preferences) error
+}
+
+func (s *NotificationsService) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.Preferences, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	prefs, err := s.preferences.Get(ctx, tokenClaims.UserId)
+	if err != nil {
+		log.Error("preferences store error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	return preferencesToPb(prefs), nil
+}
+
+// UpdatePreferences replaces the preferences of the user, the unset fields are reset to the defaults.
+func (s *NotificationsService) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.Preferences, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("attempt")
+
+	prefs, errMessage := preferencesFromPb(req.GetPreferences())
+	if errMessage != "" {
+		log.Error("preferences invalid", "error", errMessage)
+		return nil, status.Error(codes.InvalidArgument, errMessage)
+	}
+
+	if err := s.preferences.Set(ctx, tokenClaims.UserId, prefs); err != nil {
+		log.Error("preferences store error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("preferences updated")
+
+	return preferencesToPb(prefs), nil
+}
+
+// preferencesFromPb validates the preferences, it returns the message of the InvalidArgument error when they are invalid.
+func preferencesFromPb(p *pb.Preferences) (*preferences.Preferences, string) {
+	prefs := &preferences.Preferences{
+		DisabledEvents:   uniqueSorted(p.GetDisabledEvents()),
+		DisabledChannels: uniqueSorted(p.GetDisabledChannels()),
+	}
+
+	for _, eventType := range prefs.DisabledEvents {
+		if !slices.Contains(eventTypes, eventType) {
+			return nil, ErrInvalidEventTypeMessage
+		}
+	}
+	for _, channelType := range prefs.DisabledChannels {
+		if !slices.Contains(knownChannelTypes, channelType) {
+			return nil, ErrInvalidDisabledChannelMessage
+		}
+	}
+
+	if q := p.GetQuietHours(); q != nil {
+		start, okStart := preferences.ParseClock(q.GetStart())
+		end, okEnd := preferences.ParseClock(q.GetEnd())
+		if !okStart || !okEnd || start == end {
+			return nil, ErrInvalidQuietHoursMessage
+		}
+		if !timezoneIsValid(q.GetTimezone()) {
+			return nil, ErrInvalidTimezoneMessage
+		}
+		prefs.QuietHours = &preferences.QuietHours{Start: q.GetStart(), End: q.GetEnd(), Timezone: q.GetTimezone()}
+	}
+
+	if d := p.GetDigest(); d != nil {
+		frequency := d.GetFrequency()
+		if _, ok := preferences.ParseClock(d.GetTime()); !ok ||
+			(frequency != preferences.DigestDaily && frequency != preferences.DigestWeekly) {
+			return nil, ErrInvalidDigestMessage
+		}
+		if !timezoneIsValid(d.GetTimezone()) {
+			return nil, ErrInvalidTimezoneMessage
+		}
+		prefs.Digest = &preferences.Digest{Frequency: frequency, Time: d.GetTime(), Timezone: d.GetTimezone()}
+	}
+	return prefs, ""
+}
+
+func timezoneIsValid(name string) bool {
+	// LoadLocation takes "" for UTC and "Local" for the zone of the server
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func uniqueSorted(values []string) []string {
+	var unique []string
+	for _, v := range values {
+		unique = append(unique, strings.TrimSpace(v))
+	}
+	slices.Sort(unique)
+	return slices.Compact(unique)
+}
+
+func preferencesToPb(prefs *preferences.Preferences) *pb.Preferences {
+	p := &pb.Preferences{
+		DisabledEvents:   prefs.DisabledEvents,
+		DisabledChannels: prefs.DisabledChannels,
+	}
+	if q := prefs.QuietHours; q != nil {
+		p.QuietHours = &pb.QuietHours{Start: q.Start, End: q.End, Timezone: q.Timezone}
+	}
+	if d := prefs.Digest; d != nil {
+		p.Digest = &pb.Digest{Frequency: d.Frequency, Time: d.Time, Timezone: d.Timezone}
+	}
+	return p
+}
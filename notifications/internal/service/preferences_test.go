@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	_ "time/tzdata"
+
+	pb "github.com/Novip1906/tasks-grpc/notifications/api/proto/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdatePreferences(t *testing.T) {
+	s := newTestNotificationsService()
+	ctx := userContext()
+
+	prefs, err := s.GetPreferences(ctx, &pb.GetPreferencesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, prefs.GetDisabledEvents())
+	assert.Nil(t, prefs.GetQuietHours())
+
+	updated, err := s.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{Preferences: &pb.Preferences{
+		DisabledEvents:   []string{"update", "create", "update"},
+		DisabledChannels: []string{"email"},
+		QuietHours:       &pb.QuietHours{Start: "22:00", End: "08:00", Timezone: "Europe/Berlin"},
+		Digest:           &pb.Digest{Frequency: "weekly", Time: "09:00", Timezone: "Europe/Berlin"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create", "update"}, updated.GetDisabledEvents())
+
+	prefs, err = s.GetPreferences(ctx, &pb.GetPreferencesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create", "update"}, prefs.GetDisabledEvents())
+	assert.Equal(t, []string{"email"}, prefs.GetDisabledChannels())
+	assert.Equal(t, "Europe/Berlin", prefs.GetQuietHours().GetTimezone())
+	assert.Equal(t, "weekly", prefs.GetDigest().GetFrequency())
+
+	_, err = s.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{})
+	require.NoError(t, err)
+	prefs, err = s.GetPreferences(ctx, &pb.GetPreferencesRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, prefs.GetQuietHours(), "the unset fields are reset")
+	assert.Nil(t, prefs.GetDigest())
+}
+
+func TestUpdatePreferences_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		prefs *pb.Preferences
+	}{
+		{name: "unknown event", prefs: &pb.Preferences{DisabledEvents: []string{"archive"}}},
+		{name: "unknown channel", prefs: &pb.Preferences{DisabledChannels: []string{"sms"}}},
+		{name: "malformed start", prefs: &pb.Preferences{QuietHours: &pb.QuietHours{Start: "22", End: "08:00", Timezone: "UTC"}}},
+		{name: "empty period", prefs: &pb.Preferences{QuietHours: &pb.QuietHours{Start: "08:00", End: "08:00", Timezone: "UTC"}}},
+		{name: "unknown timezone", prefs: &pb.Preferences{QuietHours: &pb.QuietHours{Start: "22:00", End: "08:00", Timezone: "Mars/Olympus"}}},
+		{name: "server timezone", prefs: &pb.Preferences{QuietHours: &pb.QuietHours{Start: "22:00", End: "08:00", Timezone: "Local"}}},
+		{name: "no timezone", prefs: &pb.Preferences{QuietHours: &pb.QuietHours{Start: "22:00", End: "08:00"}}},
+		{name: "unknown digest frequency", prefs: &pb.Preferences{Digest: &pb.Digest{Frequency: "monthly", Time: "09:00", Timezone: "UTC"}}},
+		{name: "malformed digest time", prefs: &pb.Preferences{Digest: &pb.Digest{Frequency: "daily", Time: "9am", Timezone: "UTC"}}},
+		{name: "no digest timezone", prefs: &pb.Preferences{Digest: &pb.Digest{Frequency: "daily", Time: "09:00"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestNotificationsService().UpdatePreferences(userContext(), &pb.UpdatePreferencesRequest{Preferences: tt.prefs})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
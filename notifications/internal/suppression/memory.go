@@ -0,0 +1,43 @@
+package suppression
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps the suppression list in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+func (m *MemoryStore) Add(ctx context.Context, email string, entry Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[normalize(email)] = entry
+	return nil
+}
+
+func (m *MemoryStore) Get(ctx context.Context, email string) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[normalize(email)]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (m *MemoryStore) Remove(ctx context.Context, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, normalize(email))
+	return nil
+}
@@ -0,0 +1,48 @@
+package suppression
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKey is the hash of the entries by the address.
+const redisKey = "suppressions"
+
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Add(ctx context.Context, email string, entry Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, redisKey, normalize(email), value).Err()
+}
+
+func (r *RedisStore) Get(ctx context.Context, email string) (*Entry, error) {
+	value, err := r.client.HGet(ctx, redisKey, normalize(email)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *RedisStore) Remove(ctx context.Context, email string) error {
+	return r.client.HDel(ctx, redisKey, normalize(email)).Err()
+}
@@ -0,0 +1,32 @@
+package suppression
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	// ReasonUnsubscribed is set when the recipient followed the unsubscribe link of an email.
+	ReasonUnsubscribed = "unsubscribed"
+	// ReasonBounced is set when the mail server rejected the address as unknown.
+	ReasonBounced = "bounced"
+)
+
+// Entry is an address no email is sent to.
+type Entry struct {
+	Reason       string    `json:"reason"`
+	SuppressedAt time.Time `json:"suppressed_at"`
+}
+
+// Store is the suppression list consulted before every email, the addresses are case insensitive.
+type Store interface {
+	Add(ctx context.Context, email string, entry Entry) error
+	// Get returns nil when the address isn't suppressed.
+	Get(ctx context.Context, email string) (*Entry, error)
+	Remove(ctx context.Context, email string) error
+}
+
+func normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,176 @@
+package unsubscribe
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/Novip1906/tasks-grpc/notifications/pkg/logging"
+)
+
+var storeTimeout = 3 * time.Second
+
+const (
+	pageConfirm      = "confirm"
+	pageUnsubscribed = "unsubscribed"
+	pageResubscribed = "resubscribed"
+	pageInvalid      = "invalid"
+	pageExpired      = "expired"
+	pageError        = "error"
+)
+
+// actionResubscribe is the value of the "action" form field which lifts the unsubscription.
+const actionResubscribe = "resubscribe"
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Tasks</title>
+    <style>
+        .container { max-width: 600px; margin: 40px auto; padding: 20px; font-family: Arial, sans-serif; }
+        button { padding: 8px 16px; margin-top: 10px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+    {{if eq .Page "confirm"}}
+        <h2>Отписаться от писем? / Unsubscribe?</h2>
+        <p>На адрес <strong>{{.Email}}</strong> больше не будут приходить уведомления.</p>
+        <p>No more notifications will be sent to <strong>{{.Email}}</strong>.</p>
+        <form method="post">
+            <input type="hidden" name="token" value="{{.Token}}">
+            <button type="submit">Отписаться / Unsubscribe</button>
+        </form>
+    {{else if eq .Page "unsubscribed"}}
+        <h2>Вы отписались / You are unsubscribed</h2>
+        <p>На адрес <strong>{{.Email}}</strong> больше не будут приходить уведомления.</p>
+        <p>No more notifications will be sent to <strong>{{.Email}}</strong>.</p>
+        <form method="post">
+            <input type="hidden" name="token" value="{{.Token}}">
+            <input type="hidden" name="action" value="resubscribe">
+            <button type="submit">Подписаться снова / Resubscribe</button>
+        </form>
+    {{else if eq .Page "resubscribed"}}
+        <h2>Подписка возобновлена / You are resubscribed</h2>
+        <p>Уведомления снова будут приходить на адрес <strong>{{.Email}}</strong>.</p>
+        <p>The notifications will be sent to <strong>{{.Email}}</strong> again.</p>
+    {{else if eq .Page "expired"}}
+        <h2>Ссылка устарела / The link has expired</h2>
+        <p>Воспользуйтесь ссылкой из более нового письма.</p>
+        <p>Please use the link of a more recent email.</p>
+    {{else if eq .Page "invalid"}}
+        <h2>Неверная ссылка / Invalid link</h2>
+    {{else}}
+        <h2>Что-то пошло не так / Something went wrong</h2>
+        <p>Попробуйте позже. / Please try again later.</p>
+    {{end}}
+    </div>
+</body>
+</html>`))
+
+type pageData struct {
+	Page  string
+	Email string
+	Token string
+}
+
+// Handler serves the unsubscribe links. GET shows a confirmation page, so the links opened by
+// the mail scanners unsubscribe nobody, and POST adds the address to the suppression list.
+// POST also serves the RFC 8058 one-click unsubscription of the mail clients.
+type Handler struct {
+	links        *Links
+	suppressions suppression.Store
+	log          *slog.Logger
+	now          func() time.Time
+}
+
+func NewHandler(links *Links, suppressions suppression.Store, log *slog.Logger) *Handler {
+	return &Handler{links: links, suppressions: suppressions, log: log, now: time.Now}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.FormValue("token")
+	email, err := h.links.Verify(token)
+	if errors.Is(err, ErrTokenExpired) {
+		h.render(w, http.StatusBadRequest, pageData{Page: pageExpired})
+		return
+	}
+	if err != nil {
+		h.render(w, http.StatusBadRequest, pageData{Page: pageInvalid})
+		return
+	}
+
+	data := pageData{Email: email, Token: token}
+	if r.Method == http.MethodGet {
+		data.Page = pageConfirm
+		h.render(w, http.StatusOK, data)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), storeTimeout)
+	defer cancel()
+
+	if r.PostFormValue("action") == actionResubscribe {
+		data.Page = pageResubscribed
+		err = h.resubscribe(ctx, email)
+	} else {
+		data.Page = pageUnsubscribed
+		err = h.unsubscribe(ctx, email)
+	}
+	if err != nil {
+		h.log.Error("Failed to update suppression list", "email", email, logging.Err(err))
+		h.render(w, http.StatusInternalServerError, pageData{Page: pageError})
+		return
+	}
+	h.render(w, http.StatusOK, data)
+}
+
+// unsubscribe suppresses the address, an address which bounced keeps its reason.
+func (h *Handler) unsubscribe(ctx context.Context, email string) error {
+	entry, err := h.suppressions.Get(ctx, email)
+	if err != nil || entry != nil {
+		return err
+	}
+
+	if err := h.suppressions.Add(ctx, email, suppression.Entry{
+		Reason:       suppression.ReasonUnsubscribed,
+		SuppressedAt: h.now(),
+	}); err != nil {
+		return err
+	}
+	h.log.Info("Email address unsubscribed", "email", email)
+	return nil
+}
+
+// resubscribe lifts the unsubscription, an address which bounced stays suppressed.
+func (h *Handler) resubscribe(ctx context.Context, email string) error {
+	entry, err := h.suppressions.Get(ctx, email)
+	if err != nil || entry == nil || entry.Reason != suppression.ReasonUnsubscribed {
+		return err
+	}
+
+	if err := h.suppressions.Remove(ctx, email); err != nil {
+		return err
+	}
+	h.log.Info("Email address resubscribed", "email", email)
+	return nil
+}
+
+func (h *Handler) render(w http.ResponseWriter, status int, data pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := page.Execute(w, data); err != nil {
+		h.log.Error("Failed to render unsubscribe page", logging.Err(err))
+	}
+}
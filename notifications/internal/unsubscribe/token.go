@@ -0,0 +1,90 @@
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Path is the path of the unsubscribe endpoint, the token is passed in the "token" query parameter.
+const Path = "/unsubscribe"
+
+var (
+	ErrInvalidToken = errors.New("invalid unsubscribe token")
+	ErrTokenExpired = errors.New("unsubscribe token expired")
+)
+
+// Links signs the unsubscribe tokens of the email addresses and builds the links the emails embed.
+// The token is the base64url encoded address with its expiry time, followed by their HMAC-SHA256,
+// so it's verified without being stored.
+type Links struct {
+	baseURL string
+	secret  []byte
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func NewLinks(baseURL, secret string, ttl time.Duration) *Links {
+	return &Links{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// URL returns the unsubscribe link of the address.
+func (l *Links) URL(email string) string {
+	return l.baseURL + Path + "?token=" + url.QueryEscape(l.Token(email))
+}
+
+// Token returns the token of the address which expires after the TTL.
+func (l *Links) Token(email string) string {
+	payload := email + "|" + strconv.FormatInt(l.now().Add(l.ttl).Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(l.sign(payload))
+}
+
+// Verify returns the address of the token, checking its signature and expiry time.
+func (l *Links) Verify(token string) (string, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !hmac.Equal(signature, l.sign(string(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	// the address may contain "|" itself, the expiry time is after the last one
+	sep := strings.LastIndexByte(string(payload), '|')
+	if sep <= 0 {
+		return "", ErrInvalidToken
+	}
+	expiresAt, err := strconv.ParseInt(string(payload[sep+1:]), 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if !l.now().Before(time.Unix(expiresAt, 0)) {
+		return "", ErrTokenExpired
+	}
+	return string(payload[:sep]), nil
+}
+
+func (l *Links) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,140 @@
+package unsubscribe
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/notifications/internal/suppression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks_Token(t *testing.T) {
+	links := NewLinks("https://tasks.example.com/", "secret", time.Hour)
+	now := time.Now()
+	links.now = func() time.Time { return now }
+
+	token := links.Token("a|b@example.com")
+	email, err := links.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "a|b@example.com", email)
+
+	link, err := url.Parse(links.URL("alice@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "tasks.example.com", link.Host)
+	assert.Equal(t, Path, link.Path)
+	email, err = links.Verify(link.Query().Get("token"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", email)
+
+	now = now.Add(time.Hour)
+	_, err = links.Verify(token)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestLinks_VerifyRejectsForgedTokens(t *testing.T) {
+	links := NewLinks("https://tasks.example.com", "secret", time.Hour)
+	token := links.Token("alice@example.com")
+
+	other := NewLinks("https://tasks.example.com", "other", time.Hour)
+	_, err := other.Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "signed with another secret")
+
+	payload, signature, _ := strings.Cut(links.Token("bob@example.com"), ".")
+	_, alicesSignature, _ := strings.Cut(token, ".")
+	_, err = links.Verify(payload + "." + alicesSignature)
+	assert.ErrorIs(t, err, ErrInvalidToken, "the payload of another token")
+	_, err = links.Verify(payload + signature)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = links.Verify("")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func newTestHandler() (*Handler, *Links, *suppression.MemoryStore) {
+	links := NewLinks("https://tasks.example.com", "secret", time.Hour)
+	store := suppression.NewMemoryStore()
+	return NewHandler(links, store, slog.New(slog.NewTextHandler(io.Discard, nil))), links, store
+}
+
+func serve(h http.Handler, method, token string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, Path+"?token="+url.QueryEscape(token), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_GetOnlyConfirms(t *testing.T) {
+	h, links, store := newTestHandler()
+
+	rec := serve(h, http.MethodGet, links.Token("alice@example.com"), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<form method="post">`)
+
+	entry, err := store.Get(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "opening the link unsubscribes nobody")
+}
+
+func TestHandler_OneClickUnsubscribe(t *testing.T) {
+	h, links, store := newTestHandler()
+	token := links.Token("alice@example.com")
+
+	rec := serve(h, http.MethodPost, token, url.Values{"List-Unsubscribe": {"One-Click"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	entry, err := store.Get(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, suppression.ReasonUnsubscribed, entry.Reason)
+
+	rec = serve(h, http.MethodPost, token, url.Values{"action": {actionResubscribe}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	entry, err = store.Get(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestHandler_BouncedAddressStaysSuppressed(t *testing.T) {
+	h, links, store := newTestHandler()
+	ctx := context.Background()
+	require.NoError(t, store.Add(ctx, "alice@example.com", suppression.Entry{Reason: suppression.ReasonBounced}))
+	token := links.Token("alice@example.com")
+
+	serve(h, http.MethodPost, token, nil)
+	serve(h, http.MethodPost, token, url.Values{"action": {actionResubscribe}})
+
+	entry, err := store.Get(ctx, "alice@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, suppression.ReasonBounced, entry.Reason)
+}
+
+func TestHandler_InvalidToken(t *testing.T) {
+	h, links, store := newTestHandler()
+	now := time.Now()
+	links.now = func() time.Time { return now }
+	token := links.Token("alice@example.com")
+	now = now.Add(2 * time.Hour)
+
+	rec := serve(h, http.MethodPost, token, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "expired")
+
+	rec = serve(h, http.MethodPost, "forged", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	entry, err := store.Get(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	rec = serve(h, http.MethodDelete, token, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,45 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	authPb "github.com/Novip1906/tasks-grpc/notifications/internal/auth_gen"
+	"github.com/Novip1906/tasks-grpc/notifications/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const lookupTimeout = 3 * time.Second
+
+var ErrUserNotFound = errors.New("user not found")
+
+// Directory looks the recipients of the notifications up in the auth service.
+type Directory struct {
+	client authPb.AuthServiceClient
+}
+
+func NewDirectory(client authPb.AuthServiceClient) *Directory {
+	return &Directory{client: client}
+}
+
+func (d *Directory) GetUser(ctx context.Context, userId int64) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	resp, err := d.client.GetUserById(ctx, &authPb.GetUserByIdRequest{UserId: userId})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		Id:       resp.GetUserId(),
+		Username: resp.GetUsername(),
+		Email:    resp.GetEmail(),
+		Locale:   resp.GetLocale(),
+	}, nil
+}
@@ -19,12 +19,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TasksService_CreateTask_FullMethodName  = "/tasks.TasksService/CreateTask"
-	TasksService_GetTask_FullMethodName     = "/tasks.TasksService/GetTask"
-	TasksService_SearchTask_FullMethodName  = "/tasks.TasksService/SearchTask"
-	TasksService_GetAllTasks_FullMethodName = "/tasks.TasksService/GetAllTasks"
-	TasksService_UpdateTask_FullMethodName  = "/tasks.TasksService/UpdateTask"
-	TasksService_DeleteTask_FullMethodName  = "/tasks.TasksService/DeleteTask"
+	TasksService_CreateTask_FullMethodName            = "/tasks.TasksService/CreateTask"
+	TasksService_GetTask_FullMethodName               = "/tasks.TasksService/GetTask"
+	TasksService_SearchTask_FullMethodName            = "/tasks.TasksService/SearchTask"
+	TasksService_GetAllTasks_FullMethodName           = "/tasks.TasksService/GetAllTasks"
+	TasksService_ListOverdueTasks_FullMethodName      = "/tasks.TasksService/ListOverdueTasks"
+	TasksService_UpdateTask_FullMethodName            = "/tasks.TasksService/UpdateTask"
+	TasksService_DeleteTask_FullMethodName            = "/tasks.TasksService/DeleteTask"
+	TasksService_SetTaskStatus_FullMethodName         = "/tasks.TasksService/SetTaskStatus"
+	TasksService_ListDeletedTasks_FullMethodName      = "/tasks.TasksService/ListDeletedTasks"
+	TasksService_RestoreTask_FullMethodName           = "/tasks.TasksService/RestoreTask"
+	TasksService_PurgeTask_FullMethodName             = "/tasks.TasksService/PurgeTask"
+	TasksService_ListTaskRevisions_FullMethodName     = "/tasks.TasksService/ListTaskRevisions"
+	TasksService_DiffTaskRevisions_FullMethodName     = "/tasks.TasksService/DiffTaskRevisions"
+	TasksService_RevertTask_FullMethodName            = "/tasks.TasksService/RevertTask"
+	TasksService_AddTags_FullMethodName               = "/tasks.TasksService/AddTags"
+	TasksService_RemoveTags_FullMethodName            = "/tasks.TasksService/RemoveTags"
+	TasksService_ListTags_FullMethodName              = "/tasks.TasksService/ListTags"
+	TasksService_AddChecklistItem_FullMethodName      = "/tasks.TasksService/AddChecklistItem"
+	TasksService_ToggleChecklistItem_FullMethodName   = "/tasks.TasksService/ToggleChecklistItem"
+	TasksService_ReorderChecklistItems_FullMethodName = "/tasks.TasksService/ReorderChecklistItems"
+	TasksService_RemoveChecklistItem_FullMethodName   = "/tasks.TasksService/RemoveChecklistItem"
+	TasksService_ShareTask_FullMethodName             = "/tasks.TasksService/ShareTask"
+	TasksService_UnshareTask_FullMethodName           = "/tasks.TasksService/UnshareTask"
+	TasksService_ListTaskMembers_FullMethodName       = "/tasks.TasksService/ListTaskMembers"
+	TasksService_AssignTask_FullMethodName            = "/tasks.TasksService/AssignTask"
+	TasksService_ListAssignedTasks_FullMethodName     = "/tasks.TasksService/ListAssignedTasks"
+	TasksService_AddReminder_FullMethodName           = "/tasks.TasksService/AddReminder"
+	TasksService_ListReminders_FullMethodName         = "/tasks.TasksService/ListReminders"
+	TasksService_RemoveReminder_FullMethodName        = "/tasks.TasksService/RemoveReminder"
 )
 
 // TasksServiceClient is the client API for TasksService service.
@@ -35,8 +58,31 @@ type TasksServiceClient interface {
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
 	SearchTask(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error)
 	GetAllTasks(ctx context.Context, in *GetAllTasksRequest, opts ...grpc.CallOption) (*GetAllTasksResponse, error)
+	ListOverdueTasks(ctx context.Context, in *ListOverdueTasksRequest, opts ...grpc.CallOption) (*ListOverdueTasksResponse, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error)
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
+	SetTaskStatus(ctx context.Context, in *SetTaskStatusRequest, opts ...grpc.CallOption) (*SetTaskStatusResponse, error)
+	ListDeletedTasks(ctx context.Context, in *ListDeletedTasksRequest, opts ...grpc.CallOption) (*ListDeletedTasksResponse, error)
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	PurgeTask(ctx context.Context, in *PurgeTaskRequest, opts ...grpc.CallOption) (*PurgeTaskResponse, error)
+	ListTaskRevisions(ctx context.Context, in *ListTaskRevisionsRequest, opts ...grpc.CallOption) (*ListTaskRevisionsResponse, error)
+	DiffTaskRevisions(ctx context.Context, in *DiffTaskRevisionsRequest, opts ...grpc.CallOption) (*DiffTaskRevisionsResponse, error)
+	RevertTask(ctx context.Context, in *RevertTaskRequest, opts ...grpc.CallOption) (*RevertTaskResponse, error)
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error)
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*AddChecklistItemResponse, error)
+	ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*ToggleChecklistItemResponse, error)
+	ReorderChecklistItems(ctx context.Context, in *ReorderChecklistItemsRequest, opts ...grpc.CallOption) (*ReorderChecklistItemsResponse, error)
+	RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemRequest, opts ...grpc.CallOption) (*RemoveChecklistItemResponse, error)
+	ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error)
+	UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error)
+	ListTaskMembers(ctx context.Context, in *ListTaskMembersRequest, opts ...grpc.CallOption) (*ListTaskMembersResponse, error)
+	AssignTask(ctx context.Context, in *AssignTaskRequest, opts ...grpc.CallOption) (*AssignTaskResponse, error)
+	ListAssignedTasks(ctx context.Context, in *ListAssignedTasksRequest, opts ...grpc.CallOption) (*ListAssignedTasksResponse, error)
+	AddReminder(ctx context.Context, in *AddReminderRequest, opts ...grpc.CallOption) (*AddReminderResponse, error)
+	ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error)
+	RemoveReminder(ctx context.Context, in *RemoveReminderRequest, opts ...grpc.CallOption) (*RemoveReminderResponse, error)
 }
 
 type tasksServiceClient struct {
@@ -87,6 +133,16 @@ func (c *tasksServiceClient) GetAllTasks(ctx context.Context, in *GetAllTasksReq
 	return out, nil
 }
 
+func (c *tasksServiceClient) ListOverdueTasks(ctx context.Context, in *ListOverdueTasksRequest, opts ...grpc.CallOption) (*ListOverdueTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOverdueTasksResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListOverdueTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tasksServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTaskResponse)
@@ -107,6 +163,226 @@ func (c *tasksServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskReque
 	return out, nil
 }
 
+func (c *tasksServiceClient) SetTaskStatus(ctx context.Context, in *SetTaskStatusRequest, opts ...grpc.CallOption) (*SetTaskStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTaskStatusResponse)
+	err := c.cc.Invoke(ctx, TasksService_SetTaskStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListDeletedTasks(ctx context.Context, in *ListDeletedTasksRequest, opts ...grpc.CallOption) (*ListDeletedTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedTasksResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListDeletedTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_RestoreTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) PurgeTask(ctx context.Context, in *PurgeTaskRequest, opts ...grpc.CallOption) (*PurgeTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_PurgeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListTaskRevisions(ctx context.Context, in *ListTaskRevisionsRequest, opts ...grpc.CallOption) (*ListTaskRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskRevisionsResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListTaskRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) DiffTaskRevisions(ctx context.Context, in *DiffTaskRevisionsRequest, opts ...grpc.CallOption) (*DiffTaskRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffTaskRevisionsResponse)
+	err := c.cc.Invoke(ctx, TasksService_DiffTaskRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) RevertTask(ctx context.Context, in *RevertTaskRequest, opts ...grpc.CallOption) (*RevertTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_RevertTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTagsResponse)
+	err := c.cc.Invoke(ctx, TasksService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTagsResponse)
+	err := c.cc.Invoke(ctx, TasksService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) AddChecklistItem(ctx context.Context, in *AddChecklistItemRequest, opts ...grpc.CallOption) (*AddChecklistItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddChecklistItemResponse)
+	err := c.cc.Invoke(ctx, TasksService_AddChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemRequest, opts ...grpc.CallOption) (*ToggleChecklistItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToggleChecklistItemResponse)
+	err := c.cc.Invoke(ctx, TasksService_ToggleChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ReorderChecklistItems(ctx context.Context, in *ReorderChecklistItemsRequest, opts ...grpc.CallOption) (*ReorderChecklistItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderChecklistItemsResponse)
+	err := c.cc.Invoke(ctx, TasksService_ReorderChecklistItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemRequest, opts ...grpc.CallOption) (*RemoveChecklistItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveChecklistItemResponse)
+	err := c.cc.Invoke(ctx, TasksService_RemoveChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ShareTask(ctx context.Context, in *ShareTaskRequest, opts ...grpc.CallOption) (*ShareTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_ShareTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) UnshareTask(ctx context.Context, in *UnshareTaskRequest, opts ...grpc.CallOption) (*UnshareTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_UnshareTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListTaskMembers(ctx context.Context, in *ListTaskMembersRequest, opts ...grpc.CallOption) (*ListTaskMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskMembersResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListTaskMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) AssignTask(ctx context.Context, in *AssignTaskRequest, opts ...grpc.CallOption) (*AssignTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignTaskResponse)
+	err := c.cc.Invoke(ctx, TasksService_AssignTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListAssignedTasks(ctx context.Context, in *ListAssignedTasksRequest, opts ...grpc.CallOption) (*ListAssignedTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssignedTasksResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListAssignedTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) AddReminder(ctx context.Context, in *AddReminderRequest, opts ...grpc.CallOption) (*AddReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReminderResponse)
+	err := c.cc.Invoke(ctx, TasksService_AddReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRemindersResponse)
+	err := c.cc.Invoke(ctx, TasksService_ListReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tasksServiceClient) RemoveReminder(ctx context.Context, in *RemoveReminderRequest, opts ...grpc.CallOption) (*RemoveReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveReminderResponse)
+	err := c.cc.Invoke(ctx, TasksService_RemoveReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TasksServiceServer is the server API for TasksService service.
 // All implementations must embed UnimplementedTasksServiceServer
 // for forward compatibility.
@@ -115,8 +391,31 @@ type TasksServiceServer interface {
 	GetTask(context.Context, *GetTaskRequest) (*Task, error)
 	SearchTask(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error)
 	GetAllTasks(context.Context, *GetAllTasksRequest) (*GetAllTasksResponse, error)
+	ListOverdueTasks(context.Context, *ListOverdueTasksRequest) (*ListOverdueTasksResponse, error)
 	UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error)
 	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
+	SetTaskStatus(context.Context, *SetTaskStatusRequest) (*SetTaskStatusResponse, error)
+	ListDeletedTasks(context.Context, *ListDeletedTasksRequest) (*ListDeletedTasksResponse, error)
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	PurgeTask(context.Context, *PurgeTaskRequest) (*PurgeTaskResponse, error)
+	ListTaskRevisions(context.Context, *ListTaskRevisionsRequest) (*ListTaskRevisionsResponse, error)
+	DiffTaskRevisions(context.Context, *DiffTaskRevisionsRequest) (*DiffTaskRevisionsResponse, error)
+	RevertTask(context.Context, *RevertTaskRequest) (*RevertTaskResponse, error)
+	AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error)
+	RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	AddChecklistItem(context.Context, *AddChecklistItemRequest) (*AddChecklistItemResponse, error)
+	ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*ToggleChecklistItemResponse, error)
+	ReorderChecklistItems(context.Context, *ReorderChecklistItemsRequest) (*ReorderChecklistItemsResponse, error)
+	RemoveChecklistItem(context.Context, *RemoveChecklistItemRequest) (*RemoveChecklistItemResponse, error)
+	ShareTask(context.Context, *ShareTaskRequest) (*ShareTaskResponse, error)
+	UnshareTask(context.Context, *UnshareTaskRequest) (*UnshareTaskResponse, error)
+	ListTaskMembers(context.Context, *ListTaskMembersRequest) (*ListTaskMembersResponse, error)
+	AssignTask(context.Context, *AssignTaskRequest) (*AssignTaskResponse, error)
+	ListAssignedTasks(context.Context, *ListAssignedTasksRequest) (*ListAssignedTasksResponse, error)
+	AddReminder(context.Context, *AddReminderRequest) (*AddReminderResponse, error)
+	ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error)
+	RemoveReminder(context.Context, *RemoveReminderRequest) (*RemoveReminderResponse, error)
 	mustEmbedUnimplementedTasksServiceServer()
 }
 
@@ -139,12 +438,81 @@ func (UnimplementedTasksServiceServer) SearchTask(context.Context, *SearchTasksR
 func (UnimplementedTasksServiceServer) GetAllTasks(context.Context, *GetAllTasksRequest) (*GetAllTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllTasks not implemented")
 }
+func (UnimplementedTasksServiceServer) ListOverdueTasks(context.Context, *ListOverdueTasksRequest) (*ListOverdueTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdueTasks not implemented")
+}
 func (UnimplementedTasksServiceServer) UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
 }
 func (UnimplementedTasksServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTasksServiceServer) SetTaskStatus(context.Context, *SetTaskStatusRequest) (*SetTaskStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaskStatus not implemented")
+}
+func (UnimplementedTasksServiceServer) ListDeletedTasks(context.Context, *ListDeletedTasksRequest) (*ListDeletedTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedTasks not implemented")
+}
+func (UnimplementedTasksServiceServer) RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (UnimplementedTasksServiceServer) PurgeTask(context.Context, *PurgeTaskRequest) (*PurgeTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTask not implemented")
+}
+func (UnimplementedTasksServiceServer) ListTaskRevisions(context.Context, *ListTaskRevisionsRequest) (*ListTaskRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskRevisions not implemented")
+}
+func (UnimplementedTasksServiceServer) DiffTaskRevisions(context.Context, *DiffTaskRevisionsRequest) (*DiffTaskRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffTaskRevisions not implemented")
+}
+func (UnimplementedTasksServiceServer) RevertTask(context.Context, *RevertTaskRequest) (*RevertTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertTask not implemented")
+}
+func (UnimplementedTasksServiceServer) AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedTasksServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedTasksServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTasksServiceServer) AddChecklistItem(context.Context, *AddChecklistItemRequest) (*AddChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChecklistItem not implemented")
+}
+func (UnimplementedTasksServiceServer) ToggleChecklistItem(context.Context, *ToggleChecklistItemRequest) (*ToggleChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
+func (UnimplementedTasksServiceServer) ReorderChecklistItems(context.Context, *ReorderChecklistItemsRequest) (*ReorderChecklistItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderChecklistItems not implemented")
+}
+func (UnimplementedTasksServiceServer) RemoveChecklistItem(context.Context, *RemoveChecklistItemRequest) (*RemoveChecklistItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChecklistItem not implemented")
+}
+func (UnimplementedTasksServiceServer) ShareTask(context.Context, *ShareTaskRequest) (*ShareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareTask not implemented")
+}
+func (UnimplementedTasksServiceServer) UnshareTask(context.Context, *UnshareTaskRequest) (*UnshareTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareTask not implemented")
+}
+func (UnimplementedTasksServiceServer) ListTaskMembers(context.Context, *ListTaskMembersRequest) (*ListTaskMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskMembers not implemented")
+}
+func (UnimplementedTasksServiceServer) AssignTask(context.Context, *AssignTaskRequest) (*AssignTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignTask not implemented")
+}
+func (UnimplementedTasksServiceServer) ListAssignedTasks(context.Context, *ListAssignedTasksRequest) (*ListAssignedTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignedTasks not implemented")
+}
+func (UnimplementedTasksServiceServer) AddReminder(context.Context, *AddReminderRequest) (*AddReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReminder not implemented")
+}
+func (UnimplementedTasksServiceServer) ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReminders not implemented")
+}
+func (UnimplementedTasksServiceServer) RemoveReminder(context.Context, *RemoveReminderRequest) (*RemoveReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReminder not implemented")
+}
 func (UnimplementedTasksServiceServer) mustEmbedUnimplementedTasksServiceServer() {}
 func (UnimplementedTasksServiceServer) testEmbeddedByValue()                      {}
 
@@ -238,6 +606,24 @@ func _TasksService_GetAllTasks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TasksService_ListOverdueTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListOverdueTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListOverdueTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListOverdueTasks(ctx, req.(*ListOverdueTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TasksService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTaskRequest)
 	if err := dec(in); err != nil {
@@ -274,36 +660,778 @@ func _TasksService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// TasksService_ServiceDesc is the grpc.ServiceDesc for TasksService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TasksService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "tasks.TasksService",
-	HandlerType: (*TasksServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateTask",
-			Handler:    _TasksService_CreateTask_Handler,
-		},
-		{
-			MethodName: "GetTask",
-			Handler:    _TasksService_GetTask_Handler,
-		},
-		{
-			MethodName: "SearchTask",
-			Handler:    _TasksService_SearchTask_Handler,
-		},
-		{
-			MethodName: "GetAllTasks",
-			Handler:    _TasksService_GetAllTasks_Handler,
-		},
-		{
-			MethodName: "UpdateTask",
-			Handler:    _TasksService_UpdateTask_Handler,
-		},
-		{
-			MethodName: "DeleteTask",
-			Handler:    _TasksService_DeleteTask_Handler,
+func _TasksService_SetTaskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).SetTaskStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_SetTaskStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).SetTaskStatus(ctx, req.(*SetTaskStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListDeletedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListDeletedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListDeletedTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListDeletedTasks(ctx, req.(*ListDeletedTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_RestoreTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_PurgeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).PurgeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_PurgeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).PurgeTask(ctx, req.(*PurgeTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListTaskRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListTaskRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListTaskRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListTaskRevisions(ctx, req.(*ListTaskRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_DiffTaskRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffTaskRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).DiffTaskRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_DiffTaskRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).DiffTaskRevisions(ctx, req.(*DiffTaskRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_RevertTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).RevertTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_RevertTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).RevertTask(ctx, req.(*RevertTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_AddChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).AddChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_AddChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).AddChecklistItem(ctx, req.(*AddChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ToggleChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ToggleChecklistItem(ctx, req.(*ToggleChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ReorderChecklistItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderChecklistItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ReorderChecklistItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ReorderChecklistItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ReorderChecklistItems(ctx, req.(*ReorderChecklistItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_RemoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).RemoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_RemoveChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).RemoveChecklistItem(ctx, req.(*RemoveChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ShareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ShareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ShareTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ShareTask(ctx, req.(*ShareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_UnshareTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).UnshareTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_UnshareTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).UnshareTask(ctx, req.(*UnshareTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListTaskMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListTaskMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListTaskMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListTaskMembers(ctx, req.(*ListTaskMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_AssignTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).AssignTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_AssignTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).AssignTask(ctx, req.(*AssignTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListAssignedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignedTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListAssignedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListAssignedTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListAssignedTasks(ctx, req.(*ListAssignedTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_AddReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).AddReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_AddReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).AddReminder(ctx, req.(*AddReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_ListReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemindersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).ListReminders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_ListReminders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).ListReminders(ctx, req.(*ListRemindersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TasksService_RemoveReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TasksServiceServer).RemoveReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TasksService_RemoveReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TasksServiceServer).RemoveReminder(ctx, req.(*RemoveReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TasksService_ServiceDesc is the grpc.ServiceDesc for TasksService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TasksService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tasks.TasksService",
+	HandlerType: (*TasksServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTask",
+			Handler:    _TasksService_CreateTask_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _TasksService_GetTask_Handler,
+		},
+		{
+			MethodName: "SearchTask",
+			Handler:    _TasksService_SearchTask_Handler,
+		},
+		{
+			MethodName: "GetAllTasks",
+			Handler:    _TasksService_GetAllTasks_Handler,
+		},
+		{
+			MethodName: "ListOverdueTasks",
+			Handler:    _TasksService_ListOverdueTasks_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _TasksService_UpdateTask_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _TasksService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "SetTaskStatus",
+			Handler:    _TasksService_SetTaskStatus_Handler,
+		},
+		{
+			MethodName: "ListDeletedTasks",
+			Handler:    _TasksService_ListDeletedTasks_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _TasksService_RestoreTask_Handler,
+		},
+		{
+			MethodName: "PurgeTask",
+			Handler:    _TasksService_PurgeTask_Handler,
+		},
+		{
+			MethodName: "ListTaskRevisions",
+			Handler:    _TasksService_ListTaskRevisions_Handler,
+		},
+		{
+			MethodName: "DiffTaskRevisions",
+			Handler:    _TasksService_DiffTaskRevisions_Handler,
+		},
+		{
+			MethodName: "RevertTask",
+			Handler:    _TasksService_RevertTask_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _TasksService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _TasksService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _TasksService_ListTags_Handler,
+		},
+		{
+			MethodName: "AddChecklistItem",
+			Handler:    _TasksService_AddChecklistItem_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _TasksService_ToggleChecklistItem_Handler,
+		},
+		{
+			MethodName: "ReorderChecklistItems",
+			Handler:    _TasksService_ReorderChecklistItems_Handler,
+		},
+		{
+			MethodName: "RemoveChecklistItem",
+			Handler:    _TasksService_RemoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "ShareTask",
+			Handler:    _TasksService_ShareTask_Handler,
+		},
+		{
+			MethodName: "UnshareTask",
+			Handler:    _TasksService_UnshareTask_Handler,
+		},
+		{
+			MethodName: "ListTaskMembers",
+			Handler:    _TasksService_ListTaskMembers_Handler,
+		},
+		{
+			MethodName: "AssignTask",
+			Handler:    _TasksService_AssignTask_Handler,
+		},
+		{
+			MethodName: "ListAssignedTasks",
+			Handler:    _TasksService_ListAssignedTasks_Handler,
+		},
+		{
+			MethodName: "AddReminder",
+			Handler:    _TasksService_AddReminder_Handler,
+		},
+		{
+			MethodName: "ListReminders",
+			Handler:    _TasksService_ListReminders_Handler,
+		},
+		{
+			MethodName: "RemoveReminder",
+			Handler:    _TasksService_RemoveReminder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tasks.proto",
+}
+
+const (
+	ProjectsService_CreateProject_FullMethodName = "/tasks.ProjectsService/CreateProject"
+	ProjectsService_GetProject_FullMethodName    = "/tasks.ProjectsService/GetProject"
+	ProjectsService_ListProjects_FullMethodName  = "/tasks.ProjectsService/ListProjects"
+	ProjectsService_UpdateProject_FullMethodName = "/tasks.ProjectsService/UpdateProject"
+	ProjectsService_DeleteProject_FullMethodName = "/tasks.ProjectsService/DeleteProject"
+)
+
+// ProjectsServiceClient is the client API for ProjectsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProjectsServiceClient interface {
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+}
+
+type projectsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProjectsServiceClient(cc grpc.ClientConnInterface) ProjectsServiceClient {
+	return &projectsServiceClient{cc}
+}
+
+func (c *projectsServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_CreateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ProjectsService_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProjectsServiceServer is the server API for ProjectsService service.
+// All implementations must embed UnimplementedProjectsServiceServer
+// for forward compatibility.
+type ProjectsServiceServer interface {
+	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*Project, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	mustEmbedUnimplementedProjectsServiceServer()
+}
+
+// UnimplementedProjectsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProjectsServiceServer struct{}
+
+func (UnimplementedProjectsServiceServer) CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedProjectsServiceServer) GetProject(context.Context, *GetProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedProjectsServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedProjectsServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedProjectsServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedProjectsServiceServer) mustEmbedUnimplementedProjectsServiceServer() {}
+func (UnimplementedProjectsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeProjectsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProjectsServiceServer will
+// result in compilation errors.
+type UnsafeProjectsServiceServer interface {
+	mustEmbedUnimplementedProjectsServiceServer()
+}
+
+func RegisterProjectsServiceServer(s grpc.ServiceRegistrar, srv ProjectsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProjectsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProjectsService_ServiceDesc, srv)
+}
+
+func _ProjectsService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_CreateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectsService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectsService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectsService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectsService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProjectsService_ServiceDesc is the grpc.ServiceDesc for ProjectsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProjectsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tasks.ProjectsService",
+	HandlerType: (*ProjectsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProject",
+			Handler:    _ProjectsService_CreateProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _ProjectsService_GetProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _ProjectsService_ListProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _ProjectsService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _ProjectsService_DeleteProject_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
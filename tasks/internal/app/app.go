@@ -9,7 +9,10 @@ import (
 	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/interceptors"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/kafka"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/outbox"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/reminders"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/trash"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -20,11 +23,16 @@ import (
 )
 
 type Server struct {
-	cfg           *config.Config
-	gs            *grpc.Server
-	log           *slog.Logger
-	tasksService  *service.TasksService
-	emailProducer *kafka.EmailProducer
+	cfg             *config.Config
+	gs              *grpc.Server
+	log             *slog.Logger
+	tasksService    *service.TasksService
+	projectsService *service.ProjectsService
+	eventsProducer  *kafka.EventsProducer
+	purger          *trash.Purger
+	reminders       *reminders.Scheduler
+	outboxRelay     *outbox.Relay
+	stopJobs        context.CancelFunc
 }
 
 var authTimeout = 3 * time.Second
@@ -47,7 +55,7 @@ func NewServer(cfg *config.Config, log *slog.Logger) *Server {
 		panic(err)
 	}
 
-	emailProducer := kafka.NewEmailProducer(&cfg.Kafka)
+	eventsProducer := kafka.NewEventsProducer(&cfg.Kafka)
 
 	esClient, err := elasticsearch.NewClient(cfg.Elasticsearch.Addresses, cfg.Elasticsearch.Index, log)
 	if err != nil {
@@ -61,9 +69,24 @@ func NewServer(cfg *config.Config, log *slog.Logger) *Server {
 
 	log.Debug("tasks indexing", "tasks", tasks)
 
-	taskService := service.NewTasksService(cfg, log, db, emailProducer, esClient)
-
-	return &Server{cfg: cfg, gs: gs, tasksService: taskService, log: log, emailProducer: emailProducer}
+	taskService := service.NewTasksService(cfg, log, db, esClient, authClient)
+	projectsService := service.NewProjectsService(cfg, log, db)
+
+	purger := trash.NewPurger(db, &cfg.Trash, log)
+	reminderScheduler := reminders.NewScheduler(db, &cfg.Reminders, log)
+	outboxRelay := outbox.NewRelay(db, eventsProducer, esClient, &cfg.Outbox, log)
+
+	return &Server{
+		cfg:             cfg,
+		gs:              gs,
+		tasksService:    taskService,
+		projectsService: projectsService,
+		log:             log,
+		eventsProducer:  eventsProducer,
+		purger:          purger,
+		reminders:       reminderScheduler,
+		outboxRelay:     outboxRelay,
+	}
 }
 
 func (s *Server) Run() error {
@@ -73,10 +96,20 @@ func (s *Server) Run() error {
 	}
 
 	tasksPb.RegisterTasksServiceServer(s.gs, s.tasksService)
+	tasksPb.RegisterProjectsServiceServer(s.gs, s.projectsService)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	s.stopJobs = stopJobs
+	go s.purger.Run(jobsCtx)
+	go s.reminders.Run(jobsCtx)
+	go s.outboxRelay.Run(jobsCtx)
 
 	return s.gs.Serve(ln)
 }
 
 func (s *Server) Close() {
-	s.emailProducer.Close()
+	if s.stopJobs != nil {
+		s.stopJobs()
+	}
+	s.eventsProducer.Close()
 }
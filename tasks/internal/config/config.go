@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -15,10 +16,26 @@ type Config struct {
 	DB            DB            `yaml:"db" env-required:"true"`
 	Kafka         Kafka         `yaml:"kafka" env-required:"true"`
 	Elasticsearch Elasticsearch `yaml:"elasticsearch"`
+	Trash         Trash         `yaml:"trash"`
+	Reminders     Reminders     `yaml:"reminders"`
+	Outbox        Outbox        `yaml:"outbox"`
 }
 
 type Params struct {
-	Text MinMaxLen `yaml:"text"`
+	Text        MinMaxLen `yaml:"text"`
+	ProjectName MinMaxLen `yaml:"project_name"`
+	PageSize    PageSize  `yaml:"page_size"`
+	Tags        Tags      `yaml:"tags"`
+}
+
+type Tags struct {
+	MaxLength  int `yaml:"max_length" env-default:"32"`
+	MaxPerTask int `yaml:"max_per_task" env-default:"20"`
+}
+
+type PageSize struct {
+	Default int `yaml:"default" env-default:"50"`
+	Max     int `yaml:"max" env-default:"200"`
 }
 
 type MinMaxLen struct {
@@ -35,8 +52,8 @@ type DB struct {
 }
 
 type Kafka struct {
-	Brokers     []string `yaml:"brokers" env-required:"true"`
-	EventsTopic string   `yaml:"topic" env-required:"true"`
+	Brokers         []string `yaml:"brokers" env-required:"true"`
+	TaskEventsTopic string   `yaml:"task_events_topic" env-default:"task-events"`
 }
 
 type Elasticsearch struct {
@@ -44,6 +61,27 @@ type Elasticsearch struct {
 	Index     string   `yaml:"index" env-required:"true"`
 }
 
+type Trash struct {
+	Retention     time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+type Reminders struct {
+	MaxPerTask int           `yaml:"max_per_task" env-default:"10"`
+	Interval   time.Duration `yaml:"interval" env-default:"30s"`
+	BatchSize  int           `yaml:"batch_size" env-default:"100"`
+}
+
+type Outbox struct {
+	Interval      time.Duration `yaml:"interval" env-default:"1s"`
+	BatchSize     int           `yaml:"batch_size" env-default:"100"`
+	MaxRetryDelay time.Duration `yaml:"max_retry_delay" env-default:"5m"`
+	// a message failed that many times is given up on, so it doesn't hold back the next messages of its task
+	MaxAttempts int `yaml:"max_attempts" env-default:"20"`
+	// delivered messages are kept for debugging
+	Retention time.Duration `yaml:"retention" env-default:"24h"`
+}
+
 func MustLoadConfig() *Config {
 	godotenv.Load()
 
@@ -0,0 +1,71 @@
+package diff
+
+import "strings"
+
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines returns the line-level difference which turns text a into text b.
+// Deleted lines are placed before the inserted lines of the same hunk.
+func Lines(a, b string) []Line {
+	aLines := splitLines(a)
+	bLines := splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of aLines[i:] and bLines[j:]
+	lcs := make([][]int, len(aLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bLines)+1)
+	}
+	for i := len(aLines) - 1; i >= 0; i-- {
+		for j := len(bLines) - 1; j >= 0; j-- {
+			if aLines[i] == bLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(aLines), len(bLines)))
+	i, j := 0, 0
+	for i < len(aLines) && j < len(bLines) {
+		switch {
+		case aLines[i] == bLines[j]:
+			lines = append(lines, Line{Op: OpEqual, Text: aLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: OpDelete, Text: aLines[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: OpInsert, Text: bLines[j]})
+			j++
+		}
+	}
+	for ; i < len(aLines); i++ {
+		lines = append(lines, Line{Op: OpDelete, Text: aLines[i]})
+	}
+	for ; j < len(bLines); j++ {
+		lines = append(lines, Line{Op: OpInsert, Text: bLines[j]})
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []Line
+	}{
+		{
+			name: "equal",
+			a:    "buy milk\nbuy bread",
+			b:    "buy milk\nbuy bread",
+			want: []Line{{OpEqual, "buy milk"}, {OpEqual, "buy bread"}},
+		},
+		{
+			name: "both empty",
+			a:    "",
+			b:    "",
+			want: []Line{},
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "one\ntwo",
+			want: []Line{{OpInsert, "one"}, {OpInsert, "two"}},
+		},
+		{
+			name: "to empty",
+			a:    "one\ntwo",
+			b:    "",
+			want: []Line{{OpDelete, "one"}, {OpDelete, "two"}},
+		},
+		{
+			name: "changed line",
+			a:    "one\ntwo\nthree",
+			b:    "one\n2\nthree",
+			want: []Line{{OpEqual, "one"}, {OpDelete, "two"}, {OpInsert, "2"}, {OpEqual, "three"}},
+		},
+		{
+			name: "inserted and removed lines",
+			a:    "a\nb\nc\nd",
+			b:    "b\nc\ne\nd",
+			want: []Line{{OpDelete, "a"}, {OpEqual, "b"}, {OpEqual, "c"}, {OpInsert, "e"}, {OpEqual, "d"}},
+		},
+		{
+			name: "line endings and trailing newline",
+			a:    "one\r\ntwo\r\n",
+			b:    "one\ntwo",
+			want: []Line{{OpEqual, "one"}, {OpEqual, "two"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lines(tt.a, tt.b))
+		})
+	}
+}
+
+func TestLinesRebuildsBothTexts(t *testing.T) {
+	a := "title\nstep 1\nstep 2\nstep 3\nnotes"
+	b := "title\nstep 0\nstep 1\nstep 3\nnotes\nmore notes"
+
+	var gotA, gotB []string
+	for _, line := range Lines(a, b) {
+		if line.Op != OpInsert {
+			gotA = append(gotA, line.Text)
+		}
+		if line.Op != OpDelete {
+			gotB = append(gotB, line.Text)
+		}
+	}
+
+	assert.Equal(t, splitLines(a), gotA)
+	assert.Equal(t, splitLines(b), gotB)
+}
@@ -1,10 +1,12 @@
 package elasticsearch
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
@@ -13,6 +15,70 @@ import (
 	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
 )
 
+type taskDocument struct {
+	Id           int64      `json:"id"`
+	UserId       int64      `json:"user_id"`
+	Text         string     `json:"text"`
+	AuthorName   string     `json:"author_name"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Status       string     `json:"status"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
+	Priority     int        `json:"priority"`
+	Tags         []string   `json:"tags,omitempty"`
+	ProjectId    int64      `json:"project_id"`
+	AssigneeId   int64      `json:"assignee_id"`
+	AssigneeName string     `json:"assignee_name,omitempty"`
+	// the author and the members the task is shared with
+	AllowedUserIds []int64 `json:"allowed_user_ids"`
+}
+
+func newTaskDocument(task *models.Task) *taskDocument {
+	return &taskDocument{
+		Id:           task.Id,
+		UserId:       task.AuthorId,
+		Text:         task.Text,
+		AuthorName:   task.AuthorName,
+		CreatedAt:    task.CreatedAt,
+		Status:       task.Status,
+		CompletedAt:  task.CompletedAt,
+		DueAt:        task.DueAt,
+		Priority:     task.Priority,
+		Tags:         task.Tags,
+		ProjectId:    task.ProjectId,
+		AssigneeId:   task.AssigneeId,
+		AssigneeName: task.AssigneeName,
+
+		AllowedUserIds: append([]int64{task.AuthorId}, task.MemberIds...),
+	}
+}
+
+func (d *taskDocument) toTask() *models.Task {
+	var memberIds []int64
+	for _, id := range d.AllowedUserIds {
+		if id != d.UserId {
+			memberIds = append(memberIds, id)
+		}
+	}
+
+	return &models.Task{
+		Id:           d.Id,
+		AuthorId:     d.UserId,
+		Text:         d.Text,
+		AuthorName:   d.AuthorName,
+		CreatedAt:    d.CreatedAt,
+		Status:       d.Status,
+		CompletedAt:  d.CompletedAt,
+		DueAt:        d.DueAt,
+		Priority:     d.Priority,
+		Tags:         d.Tags,
+		ProjectId:    d.ProjectId,
+		MemberIds:    memberIds,
+		AssigneeId:   d.AssigneeId,
+		AssigneeName: d.AssigneeName,
+	}
+}
+
 type Client struct {
 	es    *es.Client
 	index string
@@ -41,81 +107,105 @@ func NewClient(addresses []string, index string, log *slog.Logger) (*Client, err
 	return client, nil
 }
 
-func (c *Client) Search(ctx context.Context, userId int64, query string) ([]*models.Task, error) {
-	body := fmt.Sprintf(`
-{
-  "query": {
-    "bool": {
-      "must": [
-        { "match": { "text": %q } }
-      ],
-      "filter": [
-        { "term": { "user_id": %d } }
-      ]
-    }
-  },
-  "sort": [
-    { "created_at": { "order": "desc" } }
-  ]
-}`, query, userId)
+// maxTagFacets limits the number of tags returned in the search facets.
+const maxTagFacets = 100
+
+// Search finds the tasks the user authored or is a member of matching the text query, having every tag from opts.Tags
+// and belonging to opts.ProjectId when it is set.
+// Besides the tasks it returns the number of matching tasks per tag.
+func (c *Client) Search(ctx context.Context, userId int64, opts *models.TaskSearchOptions) ([]*models.Task, []*models.TagCount, error) {
+	must := []any{}
+	if opts.Query != "" {
+		must = append(must, map[string]any{"match": map[string]any{"text": opts.Query}})
+	}
+
+	filter := []any{
+		map[string]any{"term": map[string]any{"allowed_user_ids": userId}},
+	}
+	for _, tag := range opts.Tags {
+		filter = append(filter, map[string]any{"term": map[string]any{"tags": tag}})
+	}
+	if opts.ProjectId != nil {
+		// inbox tasks are indexed with a zero project id
+		filter = append(filter, map[string]any{"term": map[string]any{"project_id": *opts.ProjectId}})
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must":   must,
+				"filter": filter,
+			},
+		},
+		"sort": []any{
+			map[string]any{"created_at": map[string]any{"order": "desc"}},
+		},
+		"aggs": map[string]any{
+			"tags": map[string]any{
+				"terms": map[string]any{"field": "tags", "size": maxTagFacets},
+			},
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal search query: %w", err)
+	}
 
 	res, err := c.es.Search(
 		c.es.Search.WithContext(ctx),
 		c.es.Search.WithIndex(c.index),
-		c.es.Search.WithBody(strings.NewReader(body)),
+		c.es.Search.WithBody(bytes.NewReader(body)),
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("es search error: %s", res.String())
+		return nil, nil, fmt.Errorf("es search error: %s", res.String())
 	}
 
 	var raw struct {
 		Hits struct {
 			Hits []struct {
-				Source struct {
-					Id         int64     `json:"id"`
-					Text       string    `json:"text"`
-					AuthorName string    `json:"author_name"`
-					CreatedAt  time.Time `json:"created_at"`
-				} `json:"_source"`
+				Source taskDocument `json:"_source"`
 			} `json:"hits"`
 		} `json:"hits"`
+		Aggregations struct {
+			Tags struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int    `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"tags"`
+		} `json:"aggregations"`
 	}
 
 	if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	tasks := make([]*models.Task, 0, len(raw.Hits.Hits))
 	for _, h := range raw.Hits.Hits {
-		tasks = append(tasks, &models.Task{
-			Id:         h.Source.Id,
-			Text:       h.Source.Text,
-			AuthorName: h.Source.AuthorName,
-			CreatedAt:  h.Source.CreatedAt,
-		})
+		tasks = append(tasks, h.Source.toTask())
 	}
 
-	return tasks, nil
+	facets := make([]*models.TagCount, 0, len(raw.Aggregations.Tags.Buckets))
+	for _, b := range raw.Aggregations.Tags.Buckets {
+		facets = append(facets, &models.TagCount{Name: b.Key, Count: b.DocCount})
+	}
+
+	return tasks, facets, nil
 }
 
 func (c *Client) IndexTask(ctx context.Context, task *models.Task) error {
-	body := fmt.Sprintf(`
-{
-  "id": %d,
-  "user_id": %d,
-  "text": %q,
-  "author_name": %q,
-  "created_at": %q
-}`, task.Id, task.AuthorId, task.Text, task.AuthorName, task.CreatedAt.Format(time.RFC3339))
+	body, err := json.Marshal(newTaskDocument(task))
+	if err != nil {
+		return fmt.Errorf("marshal task document: %w", err)
+	}
 
 	res, err := c.es.Index(
 		c.index,
-		strings.NewReader(body),
+		bytes.NewReader(body),
 		c.es.Index.WithContext(ctx),
 		c.es.Index.WithDocumentID(fmt.Sprint(task.Id)),
 	)
@@ -142,12 +232,33 @@ func (c *Client) DeleteTask(ctx context.Context, taskId int64) error {
 	}
 	defer res.Body.Close()
 
-	if res.IsError() {
+	// the document may be already removed
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
 		return fmt.Errorf("es delete error: %s", res.String())
 	}
 	return nil
 }
 
+const taskMapping = `
+{
+  "properties": {
+    "id": { "type": "long" },
+    "user_id": { "type": "long" },
+    "text": { "type": "text" },
+    "author_name": { "type": "keyword" },
+    "created_at": { "type": "date" },
+    "status": { "type": "keyword" },
+    "completed_at": { "type": "date" },
+    "due_at": { "type": "date" },
+    "priority": { "type": "integer" },
+    "tags": { "type": "keyword" },
+    "project_id": { "type": "long" },
+    "assignee_id": { "type": "long" },
+    "assignee_name": { "type": "keyword" },
+    "allowed_user_ids": { "type": "long" }
+  }
+}`
+
 func (c *Client) ensureIndex() error {
 	res, err := c.es.Indices.Exists([]string{c.index})
 	if err != nil {
@@ -157,7 +268,7 @@ func (c *Client) ensureIndex() error {
 
 	if res.StatusCode == 200 {
 		c.log.Info("elasticsearch index exists", "index", c.index)
-		return nil
+		return c.updateMapping()
 	}
 
 	if res.StatusCode != 404 {
@@ -166,18 +277,7 @@ func (c *Client) ensureIndex() error {
 
 	c.log.Info("creating elasticsearch index", "index", c.index)
 
-	mapping := `
-{
-  "mappings": {
-    "properties": {
-      "id": { "type": "long" },
-	  "user_id": { "type": "long" },
-      "text": { "type": "text" },
-      "author_name": { "type": "keyword" },
-      "created_at": { "type": "date" }
-    }
-  }
-}`
+	mapping := fmt.Sprintf(`{ "mappings": %s }`, taskMapping)
 
 	createRes, err := c.es.Indices.Create(
 		c.index,
@@ -195,3 +295,22 @@ func (c *Client) ensureIndex() error {
 	c.log.Info("elasticsearch index created", "index", c.index)
 	return nil
 }
+
+// updateMapping adds fields introduced after the index was created.
+// Elasticsearch allows new properties on an existing mapping, but not changes to existing ones.
+func (c *Client) updateMapping() error {
+	res, err := c.es.Indices.PutMapping(
+		[]string{c.index},
+		strings.NewReader(taskMapping),
+	)
+	if err != nil {
+		return fmt.Errorf("put mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("put mapping error: %s", res.String())
+	}
+
+	return nil
+}
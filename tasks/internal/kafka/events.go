@@ -2,46 +2,106 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	messagesPb "github.com/Novip1906/tasks-grpc/tasks/internal/messages_gen"
+)
+
+// The values are protobuf messages from notifications/api/proto/messages.proto, the consumers read
+// the values without these headers as JSON sent by the older versions.
+const (
+	headerContentType   = "content-type"
+	headerSchemaVersion = "schema-version"
+	contentTypeProtobuf = "application/x-protobuf"
+	schemaVersion       = "1"
 )
 
-type EmailProducer struct {
-	producer    *producer
-	eventsTopic string
+func protobufHeaders() []kafka.Header {
+	return []kafka.Header{
+		{Key: headerContentType, Value: []byte(contentTypeProtobuf)},
+		{Key: headerSchemaVersion, Value: []byte(schemaVersion)},
+	}
 }
 
-func NewEmailProducer(kafkaCfg *config.Kafka) *EmailProducer {
+type EventsProducer struct {
+	producer        *producer
+	taskEventsTopic string
+}
+
+func NewEventsProducer(kafkaCfg *config.Kafka) *EventsProducer {
 	kafkaProducer := newProducer(kafkaCfg)
-	return &EmailProducer{
-		producer:    kafkaProducer,
-		eventsTopic: kafkaCfg.EventsTopic,
+	return &EventsProducer{
+		producer:        kafkaProducer,
+		taskEventsTopic: kafkaCfg.TaskEventsTopic,
 	}
 }
 
-func (e *EmailProducer) SendEventEmail(ctx context.Context, message *models.EventMessage) error {
-	jsonData, err := json.Marshal(message)
+// PublishTaskEvent sends the event keyed by the task id, so all events of a task go to the same partition.
+func (e *EventsProducer) PublishTaskEvent(ctx context.Context, event *models.TaskEvent) error {
+	data, err := proto.Marshal(taskEventToPb(event))
 	if err != nil {
-		return fmt.Errorf("failed to marshal email events message: %w", err)
+		return fmt.Errorf("failed to marshal task event: %w", err)
 	}
 
 	err = e.producer.SendMessage(
 		ctx,
-		e.eventsTopic,
-		[]byte(message.Email),
-		jsonData,
+		e.taskEventsTopic,
+		[]byte(strconv.FormatInt(event.TaskId, 10)),
+		data,
+		protobufHeaders()...,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to send email events message: %w", err)
+		return fmt.Errorf("failed to send task event: %w", err)
 	}
 
 	return nil
 }
 
-func (e *EmailProducer) Close() {
+func (e *EventsProducer) Close() {
 	e.producer.Close()
 }
+
+func taskEventToPb(event *models.TaskEvent) *messagesPb.TaskEvent {
+	changes := make([]*messagesPb.FieldChange, 0, len(event.Changes))
+	for _, c := range event.Changes {
+		changes = append(changes, &messagesPb.FieldChange{Field: c.Field, Old: c.Old, New: c.New})
+	}
+
+	task := event.Task
+	return &messagesPb.TaskEvent{
+		Id:         event.Id,
+		Type:       event.Type,
+		Version:    int32(event.Version),
+		TaskId:     event.TaskId,
+		UserId:     event.UserId,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+		Changes:    changes,
+		Task: &messagesPb.TaskSnapshot{
+			Text:       task.Text,
+			Status:     task.Status,
+			Priority:   int32(task.Priority),
+			DueAt:      timeToPb(task.DueAt),
+			AuthorId:   task.AuthorId,
+			AssigneeId: task.AssigneeId,
+			ProjectId:  task.ProjectId,
+			CreatedAt:  timestamppb.New(task.CreatedAt),
+			UpdatedAt:  timestamppb.New(task.UpdatedAt),
+		},
+	}
+}
+
+func timeToPb(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	messagesPb "github.com/Novip1906/tasks-grpc/tasks/internal/messages_gen"
+)
+
+func TestTaskEventToPb(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	due := now.Add(24 * time.Hour)
+	event := &models.TaskEvent{
+		Id:         "event-1",
+		Type:       models.TaskEventUpdated,
+		Version:    models.TaskEventVersion,
+		TaskId:     10,
+		UserId:     1,
+		OccurredAt: now,
+		Changes:    []models.FieldChange{{Field: models.TaskFieldText, Old: "pay", New: "pay the bills"}},
+		Task:       models.TaskSnapshot{Text: "pay the bills", Status: models.StatusTodo, DueAt: &due, AuthorId: 1, CreatedAt: now, UpdatedAt: now},
+	}
+
+	data, err := proto.Marshal(taskEventToPb(event))
+	require.NoError(t, err)
+
+	var decoded messagesPb.TaskEvent
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	assert.Equal(t, "event-1", decoded.GetId())
+	assert.Equal(t, models.TaskEventUpdated, decoded.GetType())
+	assert.Equal(t, int32(1), decoded.GetVersion())
+	assert.Equal(t, int64(10), decoded.GetTaskId())
+	assert.Equal(t, now, decoded.GetOccurredAt().AsTime())
+	if assert.Len(t, decoded.GetChanges(), 1) {
+		assert.Equal(t, "pay", decoded.GetChanges()[0].GetOld())
+	}
+	assert.Equal(t, "pay the bills", decoded.GetTask().GetText())
+	assert.Equal(t, due, decoded.GetTask().GetDueAt().AsTime())
+	assert.Nil(t, taskEventToPb(&models.TaskEvent{}).GetTask().GetDueAt(), "no due date is sent as an unset field")
+}
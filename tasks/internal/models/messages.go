@@ -1,9 +1,155 @@
 package models
 
-type EventMessage struct {
-	Email       string `json:"email"`
-	Username    string `json:"username"`
-	Type        string `json:"type"`
-	TaskText    string `json:"task_text"`
-	TaskOldText string `json:"task_old_text"`
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TaskEventVersion is the version of the TaskEvent format, it's increased on incompatible changes.
+const TaskEventVersion = 1
+
+const (
+	TaskEventCreated     = "task.created"
+	TaskEventUpdated     = "task.updated"
+	TaskEventDeleted     = "task.deleted"
+	TaskEventRestored    = "task.restored"
+	TaskEventPurged      = "task.purged"
+	TaskEventReminderDue = "task.reminder_due"
+)
+
+// Fields of the task reported in TaskEvent.Changes.
+const (
+	TaskFieldText       = "text"
+	TaskFieldStatus     = "status"
+	TaskFieldPriority   = "priority"
+	TaskFieldDueAt      = "due_at"
+	TaskFieldProjectId  = "project_id"
+	TaskFieldAssigneeId = "assignee_id"
+	TaskFieldRecurrence = "recurrence"
+	TaskFieldTimezone   = "timezone"
+)
+
+// TaskEvent is a domain event of a task published to the task events topic keyed by the task id,
+// so the events of a task are consumed in order. UserId is the user who made the change,
+// or the recipient of the reminder for the "task.reminder_due" events.
+type TaskEvent struct {
+	Id         string        `json:"id"`
+	Type       string        `json:"type"`
+	Version    int           `json:"version"`
+	TaskId     int64         `json:"task_id"`
+	UserId     int64         `json:"user_id"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+	Task       TaskSnapshot  `json:"task"`
+}
+
+// FieldChange holds the old and new values of a changed field formatted as strings,
+// an empty value means the field isn't set.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// TaskSnapshot is the state of the task after the change.
+type TaskSnapshot struct {
+	Text       string     `json:"text"`
+	Status     string     `json:"status"`
+	Priority   int        `json:"priority"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	AuthorId   int64      `json:"author_id"`
+	AssigneeId int64      `json:"assignee_id,omitempty"`
+	ProjectId  int64      `json:"project_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NewTaskEvent returns the event of the task made by the user with a new random id.
+// The changes are only filled for the "task.updated" events.
+func NewTaskEvent(eventType string, userId int64, oldTask, task *Task, now time.Time) *TaskEvent {
+	event := &TaskEvent{
+		Id:         newEventId(),
+		Type:       eventType,
+		Version:    TaskEventVersion,
+		TaskId:     task.Id,
+		UserId:     userId,
+		OccurredAt: now.UTC(),
+		Task: TaskSnapshot{
+			Text:       task.Text,
+			Status:     task.Status,
+			Priority:   task.Priority,
+			DueAt:      task.DueAt,
+			AuthorId:   task.AuthorId,
+			AssigneeId: task.AssigneeId,
+			ProjectId:  task.ProjectId,
+			CreatedAt:  task.CreatedAt,
+			UpdatedAt:  task.UpdatedAt,
+		},
+	}
+	if eventType == TaskEventUpdated && oldTask != nil {
+		event.Changes = TaskChanges(oldTask, task)
+	}
+	return event
+}
+
+// TaskChanges lists the fields which differ between the two states of the task.
+func TaskChanges(oldTask, task *Task) []FieldChange {
+	var changes []FieldChange
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	add(TaskFieldText, oldTask.Text, task.Text)
+	add(TaskFieldStatus, oldTask.Status, task.Status)
+	add(TaskFieldPriority, strconv.Itoa(oldTask.Priority), strconv.Itoa(task.Priority))
+	add(TaskFieldDueAt, formatTime(oldTask.DueAt), formatTime(task.DueAt))
+	add(TaskFieldProjectId, formatId(oldTask.ProjectId), formatId(task.ProjectId))
+	add(TaskFieldAssigneeId, formatId(oldTask.AssigneeId), formatId(task.AssigneeId))
+	add(TaskFieldRecurrence, oldTask.Recurrence, task.Recurrence)
+	add(TaskFieldTimezone, oldTask.RecurrenceTz, task.RecurrenceTz)
+	return changes
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatId(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// newEventId returns a random UUID v4.
+func newEventId() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+const (
+	// OutboxKindEvent is a TaskEvent to be published to Kafka
+	OutboxKindEvent = "event"
+	// OutboxKindIndex syncs the task document in Elasticsearch with the database
+	OutboxKindIndex = "index"
+)
+
+// OutboxMessage is a side effect of a task change stored in the same transaction as the change
+// and delivered by the outbox relay after it's committed.
+type OutboxMessage struct {
+	Id       int64
+	TaskId   int64
+	Kind     string
+	Payload  []byte
+	Attempts int
 }
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskChanges(t *testing.T) {
+	due := time.Date(2026, time.May, 4, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	oldTask := &Task{Id: 1, Text: "pay", Status: StatusTodo, Priority: PriorityLow, AssigneeId: 2}
+	task := &Task{Id: 1, Text: "pay the bills", Status: StatusTodo, Priority: PriorityHigh, DueAt: &due}
+
+	assert.Equal(t, []FieldChange{
+		{Field: TaskFieldText, Old: "pay", New: "pay the bills"},
+		{Field: TaskFieldPriority, Old: "1", New: "3"},
+		{Field: TaskFieldDueAt, Old: "", New: "2026-05-04T09:00:00Z"},
+		{Field: TaskFieldAssigneeId, Old: "2", New: ""},
+	}, TaskChanges(oldTask, task))
+
+	assert.Empty(t, TaskChanges(task, task))
+}
+
+func TestNewTaskEvent(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	oldTask := &Task{Id: 5, Text: "pay", Status: StatusTodo, AuthorId: 1}
+	task := &Task{Id: 5, Text: "pay", Status: StatusDone, AuthorId: 1}
+
+	event := NewTaskEvent(TaskEventUpdated, 7, oldTask, task, now)
+
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, event.Id)
+	assert.Equal(t, TaskEventVersion, event.Version)
+	assert.Equal(t, int64(5), event.TaskId)
+	assert.Equal(t, int64(7), event.UserId)
+	assert.Equal(t, now.UTC(), event.OccurredAt)
+	assert.Equal(t, []FieldChange{{Field: TaskFieldStatus, Old: StatusTodo, New: StatusDone}}, event.Changes)
+	assert.Equal(t, StatusDone, event.Task.Status)
+
+	created := NewTaskEvent(TaskEventCreated, 7, oldTask, task, now)
+	assert.Empty(t, created.Changes, "only the update events report the changes")
+	assert.NotEqual(t, event.Id, created.Id)
+}
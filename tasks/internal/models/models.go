@@ -2,14 +2,176 @@ package models
 
 import "time"
 
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+	StatusCancelled  = "cancelled"
+)
+
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+// Task member roles, each role includes the rights of the previous ones.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByDueAt     = "due_at"
+	SortByPriority  = "priority"
+)
+
+// Task belongs to a project or, with a zero ProjectId, to the user's inbox.
+// A task with a non-zero ParentId is a subtask. MemberIds lists the users the task is shared with.
 type Task struct {
-	Id         int64
+	Id          int64
+	Text        string
+	AuthorName  string
+	AuthorId    int64
+	CreatedAt   time.Time
+	Status      string
+	CompletedAt *time.Time
+	DueAt       *time.Time
+	Priority    int
+	Version     int64
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	Tags        []string
+	ProjectId   int64
+	ParentId    int64
+	MemberIds   []int64
+	// a zero AssigneeId means nobody is assigned
+	AssigneeId   int64
+	AssigneeName string
+	// Recurrence is the RRULE expanded in the RecurrenceTz time zone starting from RecurrenceStart
+	Recurrence      string
+	RecurrenceTz    string
+	RecurrenceStart *time.Time
+	// counters of the direct checklist items and not cancelled subtasks
+	ChecklistTotal int
+	ChecklistDone  int
+	SubtasksTotal  int
+	SubtasksDone   int
+}
+
+// TaskMember is a user the task is shared with, the task author is listed as its owner.
+type TaskMember struct {
+	TaskId    int64
+	UserId    int64
+	Username  string
+	Role      string
+	CreatedAt time.Time
+}
+
+type TagCount struct {
+	Name  string
+	Count int
+}
+
+type TaskSearchOptions struct {
+	Query     string
+	Tags      []string
+	ProjectId *int64
+}
+
+type Project struct {
+	Id        int64
+	UserId    int64
+	Name      string
+	Color     string
+	Archived  bool
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProjectUpdate holds the project fields to change, nil fields are left as is.
+type ProjectUpdate struct {
+	Name     *string
+	Color    *string
+	Archived *bool
+	Position *int
+}
+
+type ChecklistItem struct {
+	Id        int64
+	TaskId    int64
+	Text      string
+	Done      bool
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Reminder is a time the user wants to be reminded of the task, every user has own reminders.
+type Reminder struct {
+	Id        int64
+	TaskId    int64
+	UserId    int64
+	RemindAt  time.Time
+	SentAt    *time.Time
+	CreatedAt time.Time
+}
+
+// DueReminder is a reminder which has to be sent with the task it's set on.
+type DueReminder struct {
+	Reminder
+	Task *Task
+}
+
+type TaskRevision struct {
+	TaskId     int64
+	Revision   int
 	Text       string
-	AuthorName string
-	AuthorId   int64
+	EditorId   int64
+	EditorName string
 	CreatedAt  time.Time
 }
 
+// TaskUpdate holds the fields to change, nil fields are left as is.
+// A zero DueAt clears the due date, a zero ProjectId moves the task to the inbox,
+// an empty Recurrence stops the recurrence, a non-zero ExpectedVersion must match the stored version.
+type TaskUpdate struct {
+	Text            *string
+	DueAt           *time.Time
+	Priority        *int
+	ProjectId       *int64
+	Recurrence      *string
+	RecurrenceTz    *string
+	ExpectedVersion int64
+}
+
+type TaskListOptions struct {
+	SortBy      string
+	SortDesc    bool
+	Priority    *int
+	DueBefore   *time.Time
+	DueAfter    *time.Time
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	TextPrefix  string
+	Tags        []string
+	ProjectId   *int64
+	Limit       int
+	After       *TaskCursor
+}
+
+// TaskCursor is the position of the last task of a page, used for keyset pagination.
+type TaskCursor struct {
+	Id        int64      `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	DueAt     *time.Time `json:"due_at,omitempty"`
+	Priority  int        `json:"priority"`
+}
+
 type TokenClaims struct {
 	UserId   int64  `json:"sub"`
 	Email    string `json:"email"`
@@ -0,0 +1,214 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+)
+
+const (
+	deliverTimeout  = 10 * time.Second
+	firstRetryDelay = time.Second
+)
+
+type OutboxStorage interface {
+	ClaimOutbox(now time.Time, limit int, lease time.Duration) ([]*models.OutboxMessage, error)
+	MarkOutboxDelivered(ids []int64, now time.Time) error
+	RetryOutbox(id int64, handleErr error, retryAt time.Time) error
+	FailOutbox(id int64, handleErr error, now time.Time) error
+	DeleteDeliveredOutbox(deliveredBefore time.Time) (int64, error)
+	GetTaskForIndexing(taskId int64) (*models.Task, error)
+}
+
+type EventPublisher interface {
+	PublishTaskEvent(ctx context.Context, event *models.TaskEvent) error
+}
+
+type Indexer interface {
+	IndexTask(ctx context.Context, task *models.Task) error
+	DeleteTask(ctx context.Context, taskId int64) error
+}
+
+// Relay delivers the messages the task changes stored in the outbox: publishes the task events to Kafka
+// and syncs the Elasticsearch documents. A message is retried with a growing delay until it's delivered
+// or fails maxAttempts times, the messages of the same task are delivered in the order they were stored.
+type Relay struct {
+	db            OutboxStorage
+	publisher     EventPublisher
+	es            Indexer
+	interval      time.Duration
+	batchSize     int
+	maxRetryDelay time.Duration
+	maxAttempts   int
+	retention     time.Duration
+	log           *slog.Logger
+	now           func() time.Time
+}
+
+func NewRelay(db OutboxStorage, publisher EventPublisher, es Indexer, cfg *config.Outbox, log *slog.Logger) *Relay {
+	return &Relay{
+		db:            db,
+		publisher:     publisher,
+		es:            es,
+		interval:      cfg.Interval,
+		batchSize:     cfg.BatchSize,
+		maxRetryDelay: cfg.MaxRetryDelay,
+		maxAttempts:   max(cfg.MaxAttempts, 1),
+		retention:     cfg.Retention,
+		log:           log.With(slog.String("job", "outbox_relay")),
+		now:           time.Now,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	r.log.Info("outbox relay started", "interval", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.deliver(ctx)
+		r.cleanup()
+
+		select {
+		case <-ctx.Done():
+			r.log.Info("outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver handles the pending messages batch by batch until none are left. A batch is claimed for long enough
+// to deliver all its messages, the claim of a relay which stopped midway runs out and another relay takes over.
+func (r *Relay) deliver(ctx context.Context) {
+	lease := time.Duration(r.batchSize) * deliverTimeout
+
+	for ctx.Err() == nil {
+		messages, err := r.db.ClaimOutbox(r.now(), r.batchSize, lease)
+		if err != nil {
+			r.log.Error("db error", logging.DbErr("ClaimOutbox", err))
+			return
+		}
+		if len(messages) == 0 {
+			return
+		}
+
+		delivered := make([]int64, 0, len(messages))
+		for _, msg := range messages {
+			if err := r.handle(ctx, msg); err != nil {
+				r.fail(msg, err)
+				continue
+			}
+			delivered = append(delivered, msg.Id)
+		}
+
+		if len(delivered) > 0 {
+			if err := r.db.MarkOutboxDelivered(delivered, r.now()); err != nil {
+				r.log.Error("db error", logging.DbErr("MarkOutboxDelivered", err))
+				return
+			}
+		}
+		r.log.Info("outbox messages processed", "delivered", len(delivered), "failed", len(messages)-len(delivered))
+	}
+}
+
+// fail schedules the next attempt of the failed message, or gives up on it after maxAttempts.
+func (r *Relay) fail(msg *models.OutboxMessage, handleErr error) {
+	log := r.log.With(slog.Int64("outbox_id", msg.Id), slog.Int64("task_id", msg.TaskId), slog.String("kind", msg.Kind))
+
+	attempts := msg.Attempts + 1
+	if attempts >= r.maxAttempts {
+		log.Error("outbox message given up", "attempts", attempts, logging.Err(handleErr))
+		if err := r.db.FailOutbox(msg.Id, handleErr, r.now()); err != nil {
+			log.Error("db error", logging.DbErr("FailOutbox", err))
+		}
+		return
+	}
+
+	if err := r.db.RetryOutbox(msg.Id, handleErr, r.now().Add(r.retryDelay(attempts))); err != nil {
+		log.Error("db error", logging.DbErr("RetryOutbox", err))
+	}
+}
+
+func (r *Relay) cleanup() {
+	count, err := r.db.DeleteDeliveredOutbox(r.now().Add(-r.retention))
+	if err != nil {
+		r.log.Error("db error", logging.DbErr("DeleteDeliveredOutbox", err))
+		return
+	}
+
+	if count > 0 {
+		r.log.Debug("delivered outbox messages removed", "count", count)
+	}
+}
+
+// retryDelay doubles the delay after every failed attempt up to maxRetryDelay.
+func (r *Relay) retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < r.maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxRetryDelay)
+}
+
+func (r *Relay) handle(ctx context.Context, msg *models.OutboxMessage) error {
+	log := r.log.With(slog.Int64("outbox_id", msg.Id), slog.Int64("task_id", msg.TaskId), slog.String("kind", msg.Kind))
+
+	ctx, cancel := context.WithTimeout(ctx, deliverTimeout)
+	defer cancel()
+
+	var err error
+	switch msg.Kind {
+	case models.OutboxKindEvent:
+		err = r.sendEvent(ctx, log, msg)
+	case models.OutboxKindIndex:
+		err = r.syncIndex(ctx, msg.TaskId)
+	default:
+		err = fmt.Errorf("unknown outbox message kind %q", msg.Kind)
+	}
+
+	if err != nil {
+		log.Error("outbox message delivery error", "attempts", msg.Attempts+1, logging.Err(err))
+	}
+	return err
+}
+
+func (r *Relay) sendEvent(ctx context.Context, log *slog.Logger, msg *models.OutboxMessage) error {
+	var event models.TaskEvent
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("unmarshal task event: %w", err)
+	}
+
+	if err := r.publisher.PublishTaskEvent(ctx, &event); err != nil {
+		return err
+	}
+
+	log.Info("kafka task event published", "event_id", event.Id, "event_type", event.Type)
+	return nil
+}
+
+// syncIndex indexes the current state of the task, so the order of the index messages doesn't matter
+// as long as the last one is delivered. The tasks in the trash or purged are removed from the index.
+func (r *Relay) syncIndex(ctx context.Context, taskId int64) error {
+	task, err := r.db.GetTaskForIndexing(taskId)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		return r.es.DeleteTask(ctx, taskId)
+	}
+	if err != nil {
+		return err
+	}
+
+	if task.DeletedAt != nil {
+		return r.es.DeleteTask(ctx, taskId)
+	}
+	return r.es.IndexTask(ctx, task)
+}
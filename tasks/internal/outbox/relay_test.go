@@ -0,0 +1,234 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox keeps the messages like the outbox table, the pending ones are in the order they were stored.
+type memoryOutbox struct {
+	pending   []*models.OutboxMessage
+	delivered []int64
+	failed    []int64
+	retryAt   map[int64]time.Time
+	tasks     map[int64]*models.Task
+}
+
+func (m *memoryOutbox) ClaimOutbox(now time.Time, limit int, lease time.Duration) ([]*models.OutboxMessage, error) {
+	var claimed []*models.OutboxMessage
+	blocked := map[string]bool{}
+	for _, msg := range m.pending {
+		key := fmt.Sprint(msg.TaskId, msg.Kind)
+		if blocked[key] {
+			continue
+		}
+		blocked[key] = true
+
+		if at, ok := m.retryAt[msg.Id]; (ok && at.After(now)) || len(claimed) >= limit {
+			continue
+		}
+		m.retryAt[msg.Id] = now.Add(lease)
+		claimed = append(claimed, msg)
+	}
+	return claimed, nil
+}
+
+func (m *memoryOutbox) MarkOutboxDelivered(ids []int64, now time.Time) error {
+	m.delivered = append(m.delivered, ids...)
+	m.pending = slices.DeleteFunc(m.pending, func(msg *models.OutboxMessage) bool { return slices.Contains(ids, msg.Id) })
+	return nil
+}
+
+func (m *memoryOutbox) RetryOutbox(id int64, handleErr error, retryAt time.Time) error {
+	i := slices.IndexFunc(m.pending, func(msg *models.OutboxMessage) bool { return msg.Id == id })
+	m.pending[i].Attempts++
+	m.retryAt[id] = retryAt
+	return nil
+}
+
+func (m *memoryOutbox) FailOutbox(id int64, handleErr error, now time.Time) error {
+	m.failed = append(m.failed, id)
+	m.pending = slices.DeleteFunc(m.pending, func(msg *models.OutboxMessage) bool { return msg.Id == id })
+	return nil
+}
+
+func (m *memoryOutbox) DeleteDeliveredOutbox(deliveredBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryOutbox) GetTaskForIndexing(taskId int64) (*models.Task, error) {
+	task, ok := m.tasks[taskId]
+	if !ok {
+		return nil, storage.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+type fakePublisher struct {
+	events []*models.TaskEvent
+	err    error
+}
+
+func (f *fakePublisher) PublishTaskEvent(ctx context.Context, event *models.TaskEvent) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, event)
+	return nil
+}
+
+type fakeIndex struct {
+	indexed []int64
+	deleted []int64
+}
+
+func (f *fakeIndex) IndexTask(ctx context.Context, task *models.Task) error {
+	f.indexed = append(f.indexed, task.Id)
+	return nil
+}
+
+func (f *fakeIndex) DeleteTask(ctx context.Context, taskId int64) error {
+	f.deleted = append(f.deleted, taskId)
+	return nil
+}
+
+func newTestRelay(db OutboxStorage, publisher EventPublisher, es Indexer, now time.Time) *Relay {
+	cfg := &config.Outbox{Interval: time.Second, BatchSize: 10, MaxRetryDelay: time.Minute, MaxAttempts: 3, Retention: time.Hour}
+	r := NewRelay(db, publisher, es, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func eventMessage(t *testing.T, id, taskId int64, eventType string) *models.OutboxMessage {
+	payload, err := json.Marshal(&models.TaskEvent{Id: "event-1", Type: eventType, TaskId: taskId, UserId: 1})
+	require.NoError(t, err)
+	return &models.OutboxMessage{Id: id, TaskId: taskId, Kind: models.OutboxKindEvent, Payload: payload}
+}
+
+func TestDeliver(t *testing.T) {
+	deletedAt := time.Now()
+	db := &memoryOutbox{
+		pending: []*models.OutboxMessage{
+			eventMessage(t, 1, 10, models.TaskEventCreated),
+			{Id: 2, TaskId: 10, Kind: models.OutboxKindIndex},
+			{Id: 3, TaskId: 11, Kind: models.OutboxKindIndex},
+			{Id: 4, TaskId: 12, Kind: models.OutboxKindIndex},
+		},
+		retryAt: map[int64]time.Time{},
+		tasks: map[int64]*models.Task{
+			10: {Id: 10},
+			11: {Id: 11, DeletedAt: &deletedAt},
+		},
+	}
+	publisher := &fakePublisher{}
+	es := &fakeIndex{}
+
+	newTestRelay(db, publisher, es, time.Now()).deliver(context.Background())
+
+	assert.Equal(t, []int64{1, 2, 3, 4}, db.delivered)
+	if assert.Len(t, publisher.events, 1) {
+		assert.Equal(t, models.TaskEventCreated, publisher.events[0].Type)
+		assert.Equal(t, "event-1", publisher.events[0].Id)
+	}
+	assert.Equal(t, []int64{10}, es.indexed)
+	assert.Equal(t, []int64{11, 12}, es.deleted, "the tasks in the trash and the purged ones are removed from the index")
+}
+
+func TestDeliver_RetriesFailedMessages(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	db := &memoryOutbox{
+		pending: []*models.OutboxMessage{eventMessage(t, 1, 10, models.TaskEventUpdated)},
+		retryAt: map[int64]time.Time{},
+	}
+	publisher := &fakePublisher{err: errors.New("kafka is down")}
+
+	newTestRelay(db, publisher, &fakeIndex{}, now).deliver(context.Background())
+
+	assert.Empty(t, db.delivered)
+	assert.Equal(t, 1, db.pending[0].Attempts, "the message is not retried before its delay passes")
+	assert.Equal(t, now.Add(time.Second), db.retryAt[1])
+
+	publisher.err = nil
+	newTestRelay(db, publisher, &fakeIndex{}, now.Add(time.Second)).deliver(context.Background())
+
+	assert.Equal(t, []int64{1}, db.delivered)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestDeliver_UnknownKindFails(t *testing.T) {
+	db := &memoryOutbox{
+		pending: []*models.OutboxMessage{{Id: 1, TaskId: 10, Kind: "unknown"}},
+		retryAt: map[int64]time.Time{},
+	}
+
+	newTestRelay(db, &fakePublisher{}, &fakeIndex{}, time.Now()).deliver(context.Background())
+
+	assert.Empty(t, db.delivered)
+	assert.Len(t, db.pending, 1)
+}
+
+func TestDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	db := &memoryOutbox{
+		pending: []*models.OutboxMessage{
+			{Id: 1, TaskId: 10, Kind: models.OutboxKindEvent, Payload: []byte("not json")},
+			eventMessage(t, 2, 10, models.TaskEventUpdated),
+		},
+		retryAt: map[int64]time.Time{},
+	}
+	publisher := &fakePublisher{}
+
+	for attempt := 1; attempt < 3; attempt++ {
+		newTestRelay(db, publisher, &fakeIndex{}, now).deliver(context.Background())
+		assert.Empty(t, db.delivered, "the failed message holds back the next event of its task")
+		now = now.Add(time.Minute)
+	}
+
+	newTestRelay(db, publisher, &fakeIndex{}, now).deliver(context.Background())
+	assert.Equal(t, []int64{1}, db.failed, "the message is given up on after the last attempt")
+	assert.Equal(t, []int64{2}, db.delivered)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestDeliver_ClaimedMessagesAreLeased(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	db := &memoryOutbox{
+		pending: []*models.OutboxMessage{eventMessage(t, 1, 10, models.TaskEventCreated)},
+		retryAt: map[int64]time.Time{},
+	}
+
+	// the relay which claimed the message stopped before delivering it
+	_, err := db.ClaimOutbox(now, 10, 10*deliverTimeout)
+	require.NoError(t, err)
+
+	publisher := &fakePublisher{}
+	newTestRelay(db, publisher, &fakeIndex{}, now.Add(time.Second)).deliver(context.Background())
+	assert.Empty(t, publisher.events, "another relay doesn't take the claimed message")
+
+	newTestRelay(db, publisher, &fakeIndex{}, now.Add(10*deliverTimeout)).deliver(context.Background())
+	assert.Len(t, publisher.events, 1, "the message is delivered once the claim runs out")
+	assert.Equal(t, []int64{1}, db.delivered)
+}
+
+func TestRetryDelay(t *testing.T) {
+	r := &Relay{maxRetryDelay: 10 * time.Second}
+
+	assert.Equal(t, time.Second, r.retryDelay(1))
+	assert.Equal(t, 2*time.Second, r.retryDelay(2))
+	assert.Equal(t, 8*time.Second, r.retryDelay(4))
+	assert.Equal(t, 10*time.Second, r.retryDelay(5))
+	assert.Equal(t, 10*time.Second, r.retryDelay(100))
+}
@@ -0,0 +1,83 @@
+package reminders
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+)
+
+type ReminderStorage interface {
+	ProcessDueReminders(now time.Time, limit int, event func(reminder *models.DueReminder) *models.TaskEvent) (int, error)
+}
+
+// Scheduler periodically sends the due reminders as "task.reminder_due" events. The events are stored
+// in the outbox together with the reminders being marked sent, and published by outbox.Relay.
+// The reminders stay in the database until they are sent, so the scheduler can run in several replicas
+// and picks up the reminders missed while it was down.
+type Scheduler struct {
+	db        ReminderStorage
+	interval  time.Duration
+	batchSize int
+	log       *slog.Logger
+	now       func() time.Time
+}
+
+func NewScheduler(db ReminderStorage, cfg *config.Reminders, log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		db:        db,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+		log:       log.With(slog.String("job", "reminder_scheduler")),
+		now:       time.Now,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	s.log.Info("reminder scheduler started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sendDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.log.Info("reminder scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue queues the events of the due reminders batch by batch until none are left.
+func (s *Scheduler) sendDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		count, err := s.db.ProcessDueReminders(s.now(), s.batchSize, s.event)
+		if count > 0 {
+			s.log.Info("reminder events queued", "count", count)
+		}
+		if err != nil {
+			s.log.Error("db error", logging.DbErr("ProcessDueReminders", err))
+			return
+		}
+		if count < s.batchSize {
+			return
+		}
+	}
+}
+
+// event returns the event of the due reminder, the reminders of the closed tasks are dropped.
+func (s *Scheduler) event(reminder *models.DueReminder) *models.TaskEvent {
+	task := reminder.Task
+	if task.Status == models.StatusDone || task.Status == models.StatusCancelled {
+		s.log.Debug("reminder of closed task skipped", "reminder_id", reminder.Id, "status", task.Status)
+		return nil
+	}
+
+	return models.NewTaskEvent(models.TaskEventReminderDue, reminder.UserId, nil, task, s.now())
+}
@@ -0,0 +1,92 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStorage hands out the pending reminders like the database does, marking them sent
+// and keeping their events like the outbox.
+type memoryStorage struct {
+	pending []*models.DueReminder
+	sent    []int64
+	events  []*models.TaskEvent
+	err     error
+}
+
+func (m *memoryStorage) ProcessDueReminders(now time.Time, limit int, event func(reminder *models.DueReminder) *models.TaskEvent) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	count := 0
+	for len(m.pending) > 0 && count < limit {
+		r := m.pending[0]
+		if r.RemindAt.After(now) {
+			break
+		}
+		if e := event(r); e != nil {
+			m.events = append(m.events, e)
+		}
+		m.pending = m.pending[1:]
+		m.sent = append(m.sent, r.Id)
+		count++
+	}
+	return count, nil
+}
+
+func newTestScheduler(db ReminderStorage, now time.Time) *Scheduler {
+	cfg := &config.Reminders{Interval: time.Minute, BatchSize: 2}
+	s := NewScheduler(db, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func dueReminder(id int64, remindAt time.Time, status string) *models.DueReminder {
+	return &models.DueReminder{
+		Reminder: models.Reminder{Id: id, TaskId: 10, UserId: 1, RemindAt: remindAt},
+		Task:     &models.Task{Id: 10, Text: "pay the bills", Status: status},
+	}
+}
+
+func TestSendDue_SendsAllBatches(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	db := &memoryStorage{pending: []*models.DueReminder{
+		dueReminder(1, now.Add(-time.Hour), models.StatusTodo),
+		dueReminder(2, now.Add(-time.Minute), models.StatusDone),
+		dueReminder(3, now, models.StatusInProgress),
+		dueReminder(4, now.Add(time.Minute), models.StatusTodo),
+	}}
+	newTestScheduler(db, now).sendDue(context.Background())
+
+	assert.Equal(t, []int64{1, 2, 3}, db.sent, "the closed task reminder is marked without sending")
+	assert.Len(t, db.pending, 1)
+	if assert.Len(t, db.events, 2) {
+		event := db.events[0]
+		assert.Equal(t, models.TaskEventReminderDue, event.Type)
+		assert.Equal(t, int64(10), event.TaskId)
+		assert.Equal(t, int64(1), event.UserId)
+		assert.Equal(t, "pay the bills", event.Task.Text)
+		assert.Equal(t, now, event.OccurredAt)
+		assert.NotEqual(t, event.Id, db.events[1].Id)
+	}
+}
+
+func TestSendDue_KeepsRemindersOnError(t *testing.T) {
+	now := time.Date(2026, time.May, 4, 9, 0, 0, 0, time.UTC)
+	db := &memoryStorage{pending: []*models.DueReminder{dueReminder(1, now, models.StatusTodo)}, err: errors.New("db is down")}
+
+	newTestScheduler(db, now).sendDue(context.Background())
+
+	assert.Empty(t, db.sent)
+	assert.Empty(t, db.events)
+	assert.Len(t, db.pending, 1, "the reminder is retried by the next run")
+}
@@ -0,0 +1,216 @@
+package rrule
+
+import (
+	"slices"
+	"time"
+)
+
+// maxEmptyPeriods stops the expansion of a rule which produces no more occurrences,
+// like BYMONTHDAY=31 with INTERVAL=12 starting in February.
+const maxEmptyPeriods = 1000
+
+// Next returns the first occurrence of the series started at start which is strictly after the given time.
+// The start is the first occurrence and counts towards COUNT. All occurrences keep the wall clock
+// of the start in its location, so a daily 09:00 series stays at 09:00 across DST changes.
+// It returns false when the series ends before that.
+func (r *Rule) Next(start, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.expand(start, func(t time.Time) bool {
+		if t.After(after) {
+			next, found = t, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Occurrences returns up to limit first occurrences of the series started at start.
+func (r *Rule) Occurrences(start time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	if limit <= 0 {
+		return occurrences
+	}
+	r.expand(start, func(t time.Time) bool {
+		occurrences = append(occurrences, t)
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// expand calls yield with the occurrences in order until it returns false or the series ends.
+func (r *Rule) expand(start time.Time, yield func(time.Time) bool) {
+	until, limited := r.untilIn(start.Location())
+
+	if limited && start.After(until) {
+		return
+	}
+	if !yield(start) {
+		return
+	}
+
+	emitted := 1
+	empty := 0
+	for period := 1; ; period++ {
+		found := false
+		for _, t := range r.candidates(start, period-1) {
+			if !t.After(start) {
+				continue
+			}
+			if limited && t.After(until) {
+				return
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+
+			found = true
+			emitted++
+			if !yield(t) {
+				return
+			}
+		}
+
+		if found {
+			empty = 0
+		} else if empty++; empty > maxEmptyPeriods {
+			return
+		}
+	}
+}
+
+// untilIn returns the last moment of the series in the location of its start.
+func (r *Rule) untilIn(loc *time.Location) (time.Time, bool) {
+	u := r.until
+	switch r.untilForm {
+	case untilUTC:
+		return u, true
+	case untilLocal:
+		return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc), true
+	case untilDate:
+		return time.Date(u.Year(), u.Month(), u.Day(), 23, 59, 59, 999999999, loc), true
+	}
+	return time.Time{}, false
+}
+
+// candidates returns the sorted occurrences of the n-th period of the rule counting from the start's period,
+// the ones before the start included.
+func (r *Rule) candidates(start time.Time, n int) []time.Time {
+	year, month, day := start.Date()
+	step := n * r.Interval
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		days = append(days, date(year, month, day+step, start))
+	case Weekly:
+		// weeks start on Monday
+		monday := day - (int(start.Weekday())+6)%7 + 7*step
+		weekdays := []time.Weekday{start.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, d := range r.ByDay {
+				weekdays = append(weekdays, d.Weekday)
+			}
+		}
+		for _, w := range weekdays {
+			days = append(days, date(year, month, monday+(int(w)+6)%7, start))
+		}
+	case Monthly:
+		first := time.Date(year, month+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		for _, d := range r.monthDays(first.Year(), first.Month(), day) {
+			days = append(days, date(first.Year(), first.Month(), d, start))
+		}
+	}
+
+	filtered := days[:0]
+	for _, t := range days {
+		if r.matches(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	slices.SortFunc(filtered, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(filtered, time.Time.Equal)
+}
+
+// monthDays returns the days of the month picked by BYMONTHDAY and BYDAY, or the start's day when none is set.
+// Days which don't exist in the month, like the 31st of April, are skipped as RFC 5545 requires.
+func (r *Rule) monthDays(year int, month time.Month, startDay int) []int {
+	daysIn := daysInMonth(year, month)
+
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay > daysIn {
+			return nil
+		}
+		return []int{startDay}
+	}
+
+	var days []int
+	if len(r.ByMonthDay) > 0 {
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = daysIn + d + 1
+			}
+			if d >= 1 && d <= daysIn {
+				days = append(days, d)
+			}
+		}
+	} else {
+		for d := 1; d <= daysIn; d++ {
+			days = append(days, d)
+		}
+	}
+
+	if len(r.ByDay) == 0 {
+		return days
+	}
+
+	firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+	picked := days[:0]
+	for _, d := range days {
+		weekday := time.Weekday((int(firstWeekday) + d - 1) % 7)
+		for _, bd := range r.ByDay {
+			if bd.Weekday != weekday {
+				continue
+			}
+			// the weekday is the nth one from the start and the nthFromEnd one from the end of the month
+			nth := (d-1)/7 + 1
+			nthFromEnd := -((daysIn-d)/7 + 1)
+			if bd.N == 0 || bd.N == nth || bd.N == nthFromEnd {
+				picked = append(picked, d)
+				break
+			}
+		}
+	}
+	return picked
+}
+
+// matches applies BYDAY and BYMONTHDAY as filters to the daily and weekly rules.
+func (r *Rule) matches(t time.Time) bool {
+	if r.Freq == Monthly {
+		return true
+	}
+	if r.Freq == Daily && len(r.ByDay) > 0 {
+		if !slices.ContainsFunc(r.ByDay, func(d WeekdayNum) bool { return d.Weekday == t.Weekday() }) {
+			return false
+		}
+	}
+	if len(r.ByMonthDay) > 0 {
+		daysIn := daysInMonth(t.Year(), t.Month())
+		if !slices.ContainsFunc(r.ByMonthDay, func(d int) bool { return d == t.Day() || daysIn+d+1 == t.Day() }) {
+			return false
+		}
+	}
+	return true
+}
+
+// date returns the day with the wall clock of the start in its location, the day may overflow the month.
+func date(year int, month time.Month, day int, start time.Time) time.Time {
+	hour, minute, sec := start.Clock()
+	return time.Date(year, month, day, hour, minute, sec, start.Nanosecond(), start.Location())
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
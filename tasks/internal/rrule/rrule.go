@@ -0,0 +1,238 @@
+// Package rrule parses and expands the subset of iCalendar (RFC 5545) recurrence rules
+// used by recurring tasks: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// WeekdayNum is a BYDAY entry. A non-zero N, only allowed in monthly rules,
+// picks the N-th weekday of the month, counting from the end when negative.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+type untilForm int
+
+const (
+	untilNone untilForm = iota
+	// UNTIL=20261231T235959Z
+	untilUTC
+	// UNTIL=20261231T235959, the wall clock in the location of the series start
+	untilLocal
+	// UNTIL=20261231, the whole day in the location of the series start
+	untilDate
+)
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	// zero means the series is not limited by the number of occurrences
+	Count int
+
+	until     time.Time
+	untilForm untilForm
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse reads a rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10", an optional "RRULE:" prefix is allowed.
+func Parse(s string) (*Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				err = fmt.Errorf("%w: unsupported frequency %s", ErrInvalidRule, value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parsePositive(key, value)
+		case "COUNT":
+			r.Count, err = parsePositive(key, value)
+		case "UNTIL":
+			r.until, r.untilForm, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count != 0 && r.untilForm != untilNone {
+		return fmt.Errorf("%w: COUNT and UNTIL can't be used together", ErrInvalidRule)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY can't be used with a weekly rule", ErrInvalidRule)
+	}
+	if r.Freq != Monthly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("%w: numbered BYDAY is only allowed in a monthly rule", ErrInvalidRule)
+			}
+		}
+	}
+	return nil
+}
+
+func parsePositive(key, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive number", ErrInvalidRule, key)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, untilForm, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, untilUTC, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, untilLocal, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, untilDate, nil
+	}
+	return time.Time{}, untilNone, fmt.Errorf("%w: malformed UNTIL %s", ErrInvalidRule, value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRule, item)
+		}
+
+		weekday, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown weekday %q", ErrInvalidRule, item)
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRule, item)
+			}
+			day.N = n
+		}
+
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("%w: malformed BYMONTHDAY %q", ErrInvalidRule, item)
+		}
+		if !slices.Contains(days, n) {
+			days = append(days, n)
+		}
+	}
+	return days, nil
+}
+
+// String returns the rule in the canonical form, Parse(r.String()) gives the same rule.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			code := weekdayNames[d.Weekday]
+			if d.N != 0 {
+				code = strconv.Itoa(d.N) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, d := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(d))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	switch r.untilForm {
+	case untilUTC:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405Z"))
+	case untilLocal:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102T150405"))
+	case untilDate:
+		parts = append(parts, "UNTIL="+r.until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
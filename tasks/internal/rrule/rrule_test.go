@@ -0,0 +1,318 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, s string) *Rule {
+	t.Helper()
+	r, err := Parse(s)
+	require.NoError(t, err, s)
+	return r
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func days(occurrences []time.Time) []string {
+	out := make([]string, 0, len(occurrences))
+	for _, t := range occurrences {
+		out = append(out, t.Format("2006-01-02 Mon"))
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	r := mustParse(t, "RRULE:freq=weekly;interval=2;byday=MO,TH;count=10")
+
+	assert.Equal(t, Weekly, r.Freq)
+	assert.Equal(t, 2, r.Interval)
+	assert.Equal(t, []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Thursday}}, r.ByDay)
+	assert.Equal(t, 10, r.Count)
+
+	r = mustParse(t, "FREQ=MONTHLY;BYDAY=-1FR,2MO")
+	assert.Equal(t, []WeekdayNum{{Weekday: time.Friday, N: -1}, {Weekday: time.Monday, N: 2}}, r.ByDay)
+	assert.Equal(t, 1, r.Interval)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	rules := []string{
+		"",
+		"RRULE:",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260101",
+		"FREQ=DAILY;UNTIL=2026-01-01",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;",
+		"FREQ=DAILY;INTERVAL",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+	}
+	for _, s := range rules {
+		_, err := Parse(s)
+		assert.True(t, errors.Is(err, ErrInvalidRule), "%q: %v", s, err)
+	}
+}
+
+func TestString_RoundTrip(t *testing.T) {
+	rules := map[string]string{
+		"freq=daily":            "FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=1": "FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,MO,FR;INTERVAL=2": "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+		"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3":             "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+		"FREQ=MONTHLY;BYMONTHDAY=-1,15":               "FREQ=MONTHLY;BYMONTHDAY=-1,15",
+		"FREQ=DAILY;UNTIL=20261231T235959Z":           "FREQ=DAILY;UNTIL=20261231T235959Z",
+		"FREQ=DAILY;UNTIL=20261231T120000":            "FREQ=DAILY;UNTIL=20261231T120000",
+		"FREQ=DAILY;UNTIL=20261231":                   "FREQ=DAILY;UNTIL=20261231",
+	}
+	for in, want := range rules {
+		r := mustParse(t, in)
+		assert.Equal(t, want, r.String(), in)
+		assert.Equal(t, want, mustParse(t, r.String()).String(), in)
+	}
+}
+
+func TestDaily(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY;INTERVAL=3;COUNT=4")
+	got := r.Occurrences(utc(2026, time.January, 30, 9, 0), 10)
+
+	assert.Equal(t, []time.Time{
+		utc(2026, time.January, 30, 9, 0),
+		utc(2026, time.February, 2, 9, 0),
+		utc(2026, time.February, 5, 9, 0),
+		utc(2026, time.February, 8, 9, 0),
+	}, got)
+}
+
+func TestDaily_ByDayFilter(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR")
+	// Friday
+	got := r.Occurrences(utc(2026, time.October, 16, 8, 0), 4)
+
+	assert.Equal(t, []string{"2026-10-16 Fri", "2026-10-19 Mon", "2026-10-20 Tue", "2026-10-21 Wed"}, days(got))
+}
+
+func TestWeekly_ByDay(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;BYDAY=TU,TH")
+	// Wednesday, so Tuesday of the first week is skipped
+	got := r.Occurrences(utc(2026, time.October, 14, 18, 30), 5)
+
+	assert.Equal(t, []string{
+		"2026-10-14 Wed", "2026-10-15 Thu", "2026-10-20 Tue", "2026-10-22 Thu", "2026-10-27 Tue",
+	}, days(got))
+	for _, o := range got {
+		assert.Equal(t, 18, o.Hour())
+		assert.Equal(t, 30, o.Minute())
+	}
+}
+
+func TestWeekly_IntervalAcrossYear(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU")
+	// Sunday, the last day of its week
+	got := r.Occurrences(utc(2026, time.December, 27, 10, 0), 5)
+
+	assert.Equal(t, []string{
+		"2026-12-27 Sun", "2027-01-04 Mon", "2027-01-10 Sun", "2027-01-18 Mon", "2027-01-24 Sun",
+	}, days(got))
+}
+
+func TestWeekly_DefaultsToStartWeekday(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;COUNT=3")
+	got := r.Occurrences(utc(2026, time.February, 26, 7, 0), 10)
+
+	assert.Equal(t, []string{"2026-02-26 Thu", "2026-03-05 Thu", "2026-03-12 Thu"}, days(got))
+}
+
+func TestMonthly_SkipsMissingDays(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;COUNT=5")
+	got := r.Occurrences(utc(2026, time.January, 31, 12, 0), 10)
+
+	// months without the 31st are skipped
+	assert.Equal(t, []string{
+		"2026-01-31 Sat", "2026-03-31 Tue", "2026-05-31 Sun", "2026-07-31 Fri", "2026-08-31 Mon",
+	}, days(got))
+}
+
+func TestMonthly_LastDayOfMonth(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYMONTHDAY=-1")
+	got := r.Occurrences(utc(2028, time.January, 31, 12, 0), 4)
+
+	// 2028 is a leap year
+	assert.Equal(t, []string{"2028-01-31 Mon", "2028-02-29 Tue", "2028-03-31 Fri", "2028-04-30 Sun"}, days(got))
+}
+
+func TestMonthly_February29(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;INTERVAL=12")
+	got := r.Occurrences(utc(2024, time.February, 29, 0, 0), 3)
+
+	assert.Equal(t, []string{"2024-02-29 Thu", "2028-02-29 Tue", "2032-02-29 Sun"}, days(got))
+}
+
+func TestMonthly_NthWeekday(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYDAY=-1FR")
+	got := r.Occurrences(utc(2026, time.January, 30, 17, 0), 4)
+
+	assert.Equal(t, []string{"2026-01-30 Fri", "2026-02-27 Fri", "2026-03-27 Fri", "2026-04-24 Fri"}, days(got))
+
+	r = mustParse(t, "FREQ=MONTHLY;INTERVAL=2;BYDAY=2MO")
+	got = r.Occurrences(utc(2026, time.January, 12, 9, 0), 3)
+
+	assert.Equal(t, []string{"2026-01-12 Mon", "2026-03-09 Mon", "2026-05-11 Mon"}, days(got))
+}
+
+func TestMonthly_FifthWeekdayIsSkipped(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYDAY=5TH")
+	got := r.Occurrences(utc(2026, time.January, 29, 9, 0), 3)
+
+	assert.Equal(t, []string{"2026-01-29 Thu", "2026-04-30 Thu", "2026-07-30 Thu"}, days(got))
+}
+
+func TestMonthly_ByDayAndByMonthDay(t *testing.T) {
+	// Friday the 13th
+	r := mustParse(t, "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13")
+	got := r.Occurrences(utc(2026, time.February, 13, 0, 0), 3)
+
+	assert.Equal(t, []string{"2026-02-13 Fri", "2026-03-13 Fri", "2026-11-13 Fri"}, days(got))
+}
+
+func TestUntil(t *testing.T) {
+	start := utc(2026, time.March, 1, 9, 0)
+
+	r := mustParse(t, "FREQ=DAILY;UNTIL=20260303T090000Z")
+	assert.Len(t, r.Occurrences(start, 10), 3, "UTC until is inclusive")
+
+	r = mustParse(t, "FREQ=DAILY;UNTIL=20260303T085959Z")
+	assert.Len(t, r.Occurrences(start, 10), 2)
+
+	r = mustParse(t, "FREQ=DAILY;UNTIL=20260303")
+	assert.Len(t, r.Occurrences(start, 10), 3, "the whole until day is included")
+
+	r = mustParse(t, "FREQ=DAILY;UNTIL=20260201")
+	assert.Empty(t, r.Occurrences(start, 10), "until before the start")
+}
+
+func TestUntil_LocalTime(t *testing.T) {
+	tokyo := mustLocation(t, "Asia/Tokyo")
+	start := time.Date(2026, time.March, 1, 7, 0, 0, 0, tokyo)
+
+	// 2026-03-03 07:00 in Tokyo is still 2026-03-02 in UTC
+	r := mustParse(t, "FREQ=DAILY;UNTIL=20260303")
+	assert.Len(t, r.Occurrences(start, 10), 3)
+
+	r = mustParse(t, "FREQ=DAILY;UNTIL=20260303T065959")
+	assert.Len(t, r.Occurrences(start, 10), 2)
+}
+
+func TestDST_WallClockIsKept(t *testing.T) {
+	for _, name := range []string{"America/New_York", "Europe/Berlin"} {
+		loc := mustLocation(t, name)
+
+		r := mustParse(t, "FREQ=DAILY")
+		// both zones switch to summer time in March and back in October/November
+		for _, start := range []time.Time{
+			time.Date(2026, time.March, 6, 9, 0, 0, 0, loc),
+			time.Date(2026, time.October, 23, 9, 0, 0, 0, loc),
+		} {
+			got := r.Occurrences(start, 30)
+			offsets := map[int]bool{}
+			for _, o := range got {
+				assert.Equal(t, 9, o.Hour(), "%s %s", name, o)
+				assert.Equal(t, 0, o.Minute())
+				_, offset := o.Zone()
+				offsets[offset] = true
+			}
+			assert.Len(t, offsets, 2, "%s: the series must cross a DST change", name)
+		}
+	}
+}
+
+func TestDST_WeeklyAcrossChange(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	r := mustParse(t, "FREQ=WEEKLY;BYDAY=SU")
+
+	// summer time starts on Sunday 2026-03-29 at 02:00
+	start := time.Date(2026, time.March, 22, 10, 0, 0, 0, berlin)
+	next, ok := r.Next(start, start)
+
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.March, 29, 10, 0, 0, 0, berlin), next)
+	assert.Equal(t, 7*24*time.Hour-time.Hour, next.Sub(start), "the week is an hour shorter")
+}
+
+func TestDST_NonexistentTimeDoesNotDrift(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	r := mustParse(t, "FREQ=DAILY")
+
+	// 02:30 doesn't exist on 2026-03-08
+	start := time.Date(2026, time.March, 7, 2, 30, 0, 0, newYork)
+	got := r.Occurrences(start, 3)
+
+	require.Len(t, got, 3)
+	assert.Equal(t, 8, got[1].Day())
+	assert.Equal(t, time.Date(2026, time.March, 9, 2, 30, 0, 0, newYork), got[2])
+}
+
+func TestNext(t *testing.T) {
+	r := mustParse(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4")
+	// Monday
+	start := utc(2026, time.October, 12, 9, 0)
+
+	next, ok := r.Next(start, start)
+	require.True(t, ok)
+	assert.Equal(t, utc(2026, time.October, 14, 9, 0), next)
+
+	next, ok = r.Next(start, utc(2026, time.October, 14, 12, 0))
+	require.True(t, ok)
+	assert.Equal(t, utc(2026, time.October, 16, 9, 0), next)
+
+	next, ok = r.Next(start, utc(2026, time.October, 16, 9, 0))
+	require.True(t, ok)
+	assert.Equal(t, utc(2026, time.October, 19, 9, 0), next, "the fourth occurrence")
+
+	_, ok = r.Next(start, utc(2026, time.October, 19, 9, 0))
+	assert.False(t, ok, "COUNT is reached")
+}
+
+func TestNext_BeforeStart(t *testing.T) {
+	r := mustParse(t, "FREQ=DAILY")
+	start := utc(2026, time.October, 12, 9, 0)
+
+	next, ok := r.Next(start, start.Add(-48*time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, start, next)
+}
+
+func TestExpand_StopsWithoutOccurrences(t *testing.T) {
+	// February never has the 30th
+	r := mustParse(t, "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30")
+	start := utc(2026, time.February, 1, 9, 0)
+
+	_, ok := r.Next(start, start)
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	authPb "github.com/Novip1906/tasks-grpc/tasks/internal/auth_gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TasksService) AssignTask(ctx context.Context, req *pb.AssignTaskRequest) (*pb.AssignTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+	username := processText(req.GetUsername())
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "username", username)
+
+	assignee := &authPb.GetUserByUsernameResponse{}
+	if username != "" {
+		user, err := s.findUser(ctx, log, username)
+		if err != nil {
+			return nil, err
+		}
+		assignee = user
+	}
+
+	_, task, err := s.db.AssignTask(tokenClaims.UserId, taskId, assignee.GetUserId())
+	if err != nil {
+		return nil, memberError(log, "AssignTask", err)
+	}
+
+	log.Info("task assigned", "assignee_id", assignee.GetUserId())
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return &pb.AssignTaskResponse{Task: taskToPb(task)}, nil
+}
+
+func (s *TasksService) ListAssignedTasks(ctx context.Context, req *pb.ListAssignedTasksRequest) (*pb.ListAssignedTasksResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("list assigned tasks attempt")
+
+	tasks, err := s.db.ListAssignedTasks(tokenClaims.UserId, req.GetIncludeClosed())
+	if err != nil {
+		log.Error("db error", logging.DbErr("ListAssignedTasks", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbTasks := make([]*pb.Task, 0, len(tasks))
+	for _, task := range tasks {
+		pbTasks = append(pbTasks, taskToPb(task))
+	}
+
+	return &pb.ListAssignedTasksResponse{Tasks: pbTasks}, nil
+}
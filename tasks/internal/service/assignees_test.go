@@ -0,0 +1,128 @@
+package service
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAssigneeStorage keeps a single task shared with an editor and a viewer.
+type fakeAssigneeStorage struct {
+	TasksStorage
+	task  models.Task
+	roles map[int64]string
+}
+
+func newFakeAssigneeStorage() *fakeAssigneeStorage {
+	return &fakeAssigneeStorage{
+		task: models.Task{Id: 10, Text: "pay", AuthorId: ownerId, Status: models.StatusTodo, Version: 1},
+		roles: map[int64]string{
+			ownerId:  models.RoleOwner,
+			editorId: models.RoleEditor,
+			viewerId: models.RoleViewer,
+		},
+	}
+}
+
+func (f *fakeAssigneeStorage) AssignTask(userId, taskId, assigneeId int64) (oldTask, newTask *models.Task, err error) {
+	if taskId != f.task.Id {
+		return nil, nil, storage.ErrTaskNotFound
+	}
+	if f.roles[userId] != models.RoleOwner && f.roles[userId] != models.RoleEditor {
+		return nil, nil, storage.ErrTaskAccessDenied
+	}
+	// only the owners share the task with a new member by assigning it
+	if _, member := f.roles[assigneeId]; assigneeId != 0 && !member && f.roles[userId] != models.RoleOwner {
+		return nil, nil, storage.ErrTaskAccessDenied
+	}
+
+	old := f.task
+	f.task.AssigneeId = assigneeId
+	f.task.Version++
+	task := f.task
+	return &old, &task, nil
+}
+
+func (f *fakeAssigneeStorage) ListAssignedTasks(userId int64, includeClosed bool) ([]*models.Task, error) {
+	if f.task.AssigneeId != userId || (!includeClosed && f.task.Status == models.StatusDone) {
+		return nil, nil
+	}
+	task := f.task
+	return []*models.Task{&task}, nil
+}
+
+func newAssigneeTestService() (*TasksService, *fakeAssigneeStorage) {
+	db := newFakeAssigneeStorage()
+	auth := &fakeAuth{users: map[string]int64{"owner": ownerId, "editor": editorId, "viewer": viewerId, "bob": bobId}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewTasksService(nil, log, db, nil, auth), db
+}
+
+func TestAssignTask(t *testing.T) {
+	s, db := newAssigneeTestService()
+
+	resp, err := s.AssignTask(userContext(ownerId), &pb.AssignTaskRequest{TaskId: 10, Username: " bob "})
+	require.NoError(t, err)
+	assert.Equal(t, int64(bobId), db.task.AssigneeId)
+	assert.Equal(t, int64(2), resp.GetTask().GetVersion())
+
+	_, err = s.AssignTask(userContext(editorId), &pb.AssignTaskRequest{TaskId: 10, Username: "viewer"})
+	require.NoError(t, err, "an editor reassigns the task")
+	assert.Equal(t, int64(viewerId), db.task.AssigneeId)
+
+	_, err = s.AssignTask(userContext(ownerId), &pb.AssignTaskRequest{TaskId: 10})
+	require.NoError(t, err, "an empty username clears the assignee")
+	assert.Zero(t, db.task.AssigneeId)
+}
+
+func TestAssignTask_Errors(t *testing.T) {
+	s, db := newAssigneeTestService()
+
+	_, err := s.AssignTask(userContext(ownerId), &pb.AssignTaskRequest{TaskId: 10, Username: "ghost"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ErrUserNotFoundMessage, status.Convert(err).Message())
+
+	_, err = s.AssignTask(userContext(viewerId), &pb.AssignTaskRequest{TaskId: 10, Username: "viewer"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "a viewer can't assign the task")
+
+	_, err = s.AssignTask(userContext(editorId), &pb.AssignTaskRequest{TaskId: 10, Username: "bob"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "an editor can't give the access to a new user")
+
+	_, err = s.AssignTask(userContext(ownerId), &pb.AssignTaskRequest{TaskId: 11, Username: "bob"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ErrTaskNotFoundMessage, status.Convert(err).Message())
+
+	assert.Zero(t, db.task.AssigneeId)
+}
+
+func TestListAssignedTasks(t *testing.T) {
+	s, db := newAssigneeTestService()
+	_, err := s.AssignTask(userContext(ownerId), &pb.AssignTaskRequest{TaskId: 10, Username: "bob"})
+	require.NoError(t, err)
+
+	resp, err := s.ListAssignedTasks(userContext(bobId), &pb.ListAssignedTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetTasks(), 1)
+	assert.Equal(t, int64(10), resp.GetTasks()[0].GetId())
+
+	db.task.Status = models.StatusDone
+	resp, err = s.ListAssignedTasks(userContext(bobId), &pb.ListAssignedTasksRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetTasks(), "the closed tasks are skipped")
+
+	resp, err = s.ListAssignedTasks(userContext(bobId), &pb.ListAssignedTasksRequest{IncludeClosed: true})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetTasks(), 1)
+
+	resp, err = s.ListAssignedTasks(userContext(viewerId), &pb.ListAssignedTasksRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetTasks())
+}
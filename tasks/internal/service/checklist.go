@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TasksService) AddChecklistItem(ctx context.Context, req *pb.AddChecklistItemRequest) (*pb.AddChecklistItemResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt")
+
+	text := processText(req.GetText())
+	if !textIsValid(text, s.cfg) {
+		log.Error("text len invalid")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTextMessage)
+	}
+
+	task, items, err := s.db.AddChecklistItem(tokenClaims.UserId, taskId, text)
+	if err != nil {
+		return nil, checklistError(log, "AddChecklistItem", err)
+	}
+
+	log.Info("checklist item added")
+
+	return &pb.AddChecklistItemResponse{Task: s.checklistTaskToPb(ctx, log, task, items)}, nil
+}
+
+func (s *TasksService) ToggleChecklistItem(ctx context.Context, req *pb.ToggleChecklistItemRequest) (*pb.ToggleChecklistItemResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId), slog.Int64("item_id", req.GetItemId()))
+
+	log.Debug("attempt")
+
+	task, items, err := s.db.ToggleChecklistItem(tokenClaims.UserId, taskId, req.GetItemId(), req.Done)
+	if err != nil {
+		return nil, checklistError(log, "ToggleChecklistItem", err)
+	}
+
+	log.Info("checklist item toggled")
+
+	return &pb.ToggleChecklistItemResponse{Task: s.checklistTaskToPb(ctx, log, task, items)}, nil
+}
+
+func (s *TasksService) ReorderChecklistItems(ctx context.Context, req *pb.ReorderChecklistItemsRequest) (*pb.ReorderChecklistItemsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "item_ids", req.GetItemIds())
+
+	task, items, err := s.db.ReorderChecklistItems(tokenClaims.UserId, taskId, req.GetItemIds())
+	if err != nil {
+		return nil, checklistError(log, "ReorderChecklistItems", err)
+	}
+
+	log.Info("checklist reordered")
+
+	return &pb.ReorderChecklistItemsResponse{Task: s.checklistTaskToPb(ctx, log, task, items)}, nil
+}
+
+func (s *TasksService) RemoveChecklistItem(ctx context.Context, req *pb.RemoveChecklistItemRequest) (*pb.RemoveChecklistItemResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId), slog.Int64("item_id", req.GetItemId()))
+
+	log.Debug("attempt")
+
+	task, items, err := s.db.RemoveChecklistItem(tokenClaims.UserId, taskId, req.GetItemId())
+	if err != nil {
+		return nil, checklistError(log, "RemoveChecklistItem", err)
+	}
+
+	log.Info("checklist item removed")
+
+	return &pb.RemoveChecklistItemResponse{Task: s.checklistTaskToPb(ctx, log, task, items)}, nil
+}
+
+// checklistTaskToPb sets the new task version as ETag and returns the task with its checklist.
+func (s *TasksService) checklistTaskToPb(ctx context.Context, log *slog.Logger, task *models.Task, items []*models.ChecklistItem) *pb.Task {
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	pbTask := taskToPb(task)
+	pbTask.Checklist = checklistToPb(items)
+	return pbTask
+}
+
+// checklistError logs the storage error and converts it into a gRPC status.
+func checklistError(log *slog.Logger, method string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrTaskNotFound):
+		log.Error("task not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	case errors.Is(err, storage.ErrTaskAccessDenied):
+		log.Error("task access denied", logging.Err(err))
+		return status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	case errors.Is(err, storage.ErrChecklistItemNotFound):
+		log.Error("checklist item not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrChecklistItemNotFoundMessage)
+	case errors.Is(err, storage.ErrInvalidChecklistOrder):
+		log.Error("checklist order invalid", logging.Err(err))
+		return status.Error(codes.InvalidArgument, ErrInvalidChecklistOrderMessage)
+	default:
+		log.Error("db error", logging.DbErr(method, err))
+		return status.Error(codes.Internal, ErrInternalMessage)
+	}
+}
+
+func checklistToPb(items []*models.ChecklistItem) []*pb.ChecklistItem {
+	pbItems := make([]*pb.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &pb.ChecklistItem{
+			Id:       item.Id,
+			Text:     item.Text,
+			Done:     item.Done,
+			Position: int32(item.Position),
+		})
+	}
+	return pbItems
+}
+
+// subtaskTree nests the descendants of the root task under their parents, keeping their order.
+func subtaskTree(rootId int64, subtasks []*models.Task) []*pb.Task {
+	children := make(map[int64][]*models.Task)
+	for _, task := range subtasks {
+		children[task.ParentId] = append(children[task.ParentId], task)
+	}
+
+	var build func(parentId int64) []*pb.Task
+	build = func(parentId int64) []*pb.Task {
+		var pbTasks []*pb.Task
+		for _, task := range children[parentId] {
+			pbTask := taskToPb(task)
+			pbTask.Subtasks = build(task.Id)
+			pbTasks = append(pbTasks, pbTask)
+		}
+		return pbTasks
+	}
+
+	return build(rootId)
+}
+
+// taskProgress returns the share of done checklist items and direct subtasks in percent.
+// Cancelled subtasks aren't counted, a task without items and subtasks is either 0 or 100 done.
+func taskProgress(task *models.Task) int {
+	total := task.ChecklistTotal + task.SubtasksTotal
+	if total == 0 {
+		if task.Status == models.StatusDone {
+			return 100
+		}
+		return 0
+	}
+	return (task.ChecklistDone + task.SubtasksDone) * 100 / total
+}
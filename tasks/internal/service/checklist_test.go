@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		task models.Task
+		want int
+	}{
+		{"empty todo", models.Task{Status: models.StatusTodo}, 0},
+		{"empty done", models.Task{Status: models.StatusDone}, 100},
+		{"checklist", models.Task{ChecklistTotal: 4, ChecklistDone: 1}, 25},
+		{"subtasks", models.Task{SubtasksTotal: 3, SubtasksDone: 2}, 66},
+		{"both", models.Task{ChecklistTotal: 2, ChecklistDone: 2, SubtasksTotal: 2, SubtasksDone: 0}, 50},
+		{"all done", models.Task{Status: models.StatusTodo, ChecklistTotal: 1, ChecklistDone: 1}, 100},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, taskProgress(&tt.task), tt.name)
+	}
+}
+
+func TestSubtaskTree(t *testing.T) {
+	subtasks := []*models.Task{
+		{Id: 2, ParentId: 1},
+		{Id: 3, ParentId: 2},
+		{Id: 4, ParentId: 1},
+		{Id: 5, ParentId: 3},
+	}
+
+	tree := subtaskTree(1, subtasks)
+
+	assert.Len(t, tree, 2)
+	assert.Equal(t, int64(2), tree[0].GetId())
+	assert.Equal(t, int64(4), tree[1].GetId())
+	assert.Empty(t, tree[1].GetSubtasks())
+
+	assert.Len(t, tree[0].GetSubtasks(), 1)
+	assert.Equal(t, int64(3), tree[0].GetSubtasks()[0].GetId())
+	assert.Equal(t, int64(5), tree[0].GetSubtasks()[0].GetSubtasks()[0].GetId())
+
+	assert.Empty(t, subtaskTree(1, nil))
+}
@@ -1,8 +1,45 @@
 package service
 
 var (
-	ErrTaskNotFoundMessage  = "Task not found"
-	ErrNotTaskAuthorMessage = "You aren`t the task`s author"
-	ErrInvalidTextMessage   = "Text is invalid"
-	ErrInternalMessage      = "Server internal error"
+	ErrTaskNotFoundMessage             = "Task not found"
+	ErrNotTaskAuthorMessage            = "You aren`t the task`s author"
+	ErrTaskAccessDeniedMessage         = "You don`t have enough rights for the task"
+	ErrInvalidRoleMessage              = "Role is invalid, expected viewer, editor or owner"
+	ErrInvalidUsernameMessage          = "Username is invalid"
+	ErrUserNotFoundMessage             = "User not found"
+	ErrMemberNotFoundMessage           = "User isn`t a member of the task"
+	ErrMemberIsAuthorMessage           = "Task author is always its owner"
+	ErrInvalidTextMessage              = "Text is invalid"
+	ErrInvalidStatusMessage            = "Status is invalid"
+	ErrStatusTransitionMessage         = "Task status can`t be changed to this status"
+	ErrTaskStatusChangedMessage        = "Task status was changed by someone else, reload it and try again"
+	ErrInvalidPriorityMessage          = "Priority is invalid"
+	ErrInvalidDueDateMessage           = "Due date is invalid"
+	ErrInvalidListOptionsMessage       = "Sorting or filter options are invalid"
+	ErrInvalidPageTokenMessage         = "Page token is invalid"
+	ErrInvalidUpdateMaskMessage        = "Update mask is invalid"
+	ErrInvalidVersionMessage           = "Task version is invalid"
+	ErrVersionMismatchMessage          = "Task was changed by someone else, reload it and try again"
+	ErrInvalidRevisionMessage          = "Revision is invalid"
+	ErrRevisionNotFoundMessage         = "Task revision not found"
+	ErrInvalidTagsMessage              = "Tags are invalid"
+	ErrTooManyTagsMessage              = "Task has too many tags"
+	ErrInvalidProjectMessage           = "Project is invalid"
+	ErrProjectNotFoundMessage          = "Project not found"
+	ErrNotProjectOwnerMessage          = "You aren`t the project`s owner"
+	ErrProjectArchivedMessage          = "Project is archived"
+	ErrInvalidProjectNameMessage       = "Project name is invalid"
+	ErrInvalidColorMessage             = "Color is invalid, expected #rrggbb"
+	ErrInvalidPositionMessage          = "Position is invalid"
+	ErrParentNotFoundMessage           = "Parent task not found"
+	ErrParentDeletedMessage            = "Parent task is in the trash, restore it first"
+	ErrChecklistItemNotFoundMessage    = "Checklist item not found"
+	ErrInvalidChecklistOrderMessage    = "Checklist order must list every item once"
+	ErrInvalidRecurrenceMessage        = "Recurrence rule is invalid, expected RRULE like FREQ=WEEKLY;BYDAY=MO"
+	ErrInvalidTimezoneMessage          = "Timezone is invalid, expected IANA name like Europe/Berlin"
+	ErrRecurrenceWithoutDueDateMessage = "Recurring task must have a due date"
+	ErrInvalidReminderTimeMessage      = "Reminder time must be in the future"
+	ErrReminderNotFoundMessage         = "Reminder not found"
+	ErrTooManyRemindersMessage         = "Task has too many reminders"
+	ErrInternalMessage                 = "Server internal error"
 )
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// The gateway forwards the HTTP If-Match header as metadata and
+// returns the etag header metadata to the client as ETag.
+const (
+	ifMatchHeader = "if-match"
+	etagHeader    = "etag"
+)
+
+func formatETag(version int64) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(version, 10))
+}
+
+func setETag(ctx context.Context, version int64) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(etagHeader, formatETag(version)))
+}
+
+// versionFromIfMatch returns the task version expected by the If-Match header, 0 if there is none.
+func versionFromIfMatch(ctx context.Context) (int64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+
+	values := md.Get(ifMatchHeader)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	value := strings.TrimSpace(values[0])
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
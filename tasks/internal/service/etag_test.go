@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// headerStream records the headers the handler sets.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// fakeCreateStorage creates the tasks like the database, with the first version and its own timestamps.
+type fakeCreateStorage struct {
+	TasksStorage
+	now time.Time
+}
+
+func (f *fakeCreateStorage) CreateTask(task *models.Task) (int64, error) {
+	task.Version = 1
+	task.CreatedAt = f.now
+	task.UpdatedAt = f.now
+	return 7, nil
+}
+
+func TestVersionFromIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int64
+		wantErr bool
+	}{
+		{`"3"`, 3, false},
+		{`W/"7"`, 7, false},
+		{`12`, 12, false},
+		{`*`, 0, false},
+		{`"abc"`, 0, true},
+		{`"-1"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ifMatchHeader, tt.header))
+		version, err := versionFromIfMatch(ctx)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, errInvalidIfMatch, tt.header)
+			continue
+		}
+		assert.NoError(t, err, tt.header)
+		assert.Equal(t, tt.version, version, tt.header)
+	}
+
+	version, err := versionFromIfMatch(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, version)
+}
+
+func TestFormatETag(t *testing.T) {
+	assert.Equal(t, `"5"`, formatETag(5))
+}
+
+func TestUpdateFields_WithoutMask(t *testing.T) {
+	priority := int32(2)
+
+	fields, ok := updateFields(&pb.UpdateTaskRequest{NewText: "new text"})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]bool{
+		updateFieldText:       true,
+		updateFieldDueAt:      false,
+		updateFieldPriority:   false,
+		updateFieldProjectId:  false,
+		updateFieldRecurrence: false,
+		updateFieldTimezone:   false,
+	}, fields)
+
+	fields, ok = updateFields(&pb.UpdateTaskRequest{Priority: &priority})
+	assert.True(t, ok)
+	assert.False(t, fields[updateFieldText])
+	assert.True(t, fields[updateFieldPriority])
+
+	projectId := int64(0)
+	fields, ok = updateFields(&pb.UpdateTaskRequest{ProjectId: &projectId})
+	assert.True(t, ok)
+	assert.False(t, fields[updateFieldText])
+	assert.True(t, fields[updateFieldProjectId])
+
+	recurrence := ""
+	fields, ok = updateFields(&pb.UpdateTaskRequest{Recurrence: &recurrence})
+	assert.True(t, ok)
+	assert.False(t, fields[updateFieldText])
+	assert.True(t, fields[updateFieldRecurrence])
+}
+
+func TestUpdateFields_WithMask(t *testing.T) {
+	fields, ok := updateFields(&pb.UpdateTaskRequest{
+		NewText:    "ignored",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"dueAt"}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]bool{updateFieldDueAt: true}, fields)
+
+	_, ok = updateFields(&pb.UpdateTaskRequest{
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"authorName"}},
+	})
+	assert.False(t, ok)
+}
+
+func TestCreateTask_ReturnsStoredVersion(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Params: config.Params{Text: config.MinMaxLen{Min: 1, Max: 100}}}
+	s := NewTasksService(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), &fakeCreateStorage{now: now}, nil, nil)
+
+	stream := &headerStream{}
+	ctx := contextkeys.WithTokenClaims(context.Background(), &contextkeys.TokenClaims{UserId: 1})
+	ctx = contextkeys.WithLogger(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+	resp, err := s.CreateTask(ctx, &pb.CreateTaskRequest{Text: "pay"})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), resp.GetTask().GetId())
+	assert.Equal(t, int64(1), resp.GetTask().GetVersion())
+	assert.Equal(t, now.Unix(), resp.GetTask().GetCreatedAt())
+	assert.Equal(t, now.Unix(), resp.GetTask().GetUpdatedAt())
+	assert.Equal(t, []string{`"1"`}, stream.header.Get(etagHeader), "the etag matches the stored version")
+}
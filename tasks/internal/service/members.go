@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	authPb "github.com/Novip1906/tasks-grpc/tasks/internal/auth_gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var authTimeout = 3 * time.Second
+
+func (s *TasksService) ShareTask(ctx context.Context, req *pb.ShareTaskRequest) (*pb.ShareTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+	role := processText(req.GetRole())
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "username", req.GetUsername(), "role", role)
+
+	if !roleIsValid(role) {
+		log.Error("role invalid", "role", role)
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidRoleMessage)
+	}
+
+	user, err := s.findUser(ctx, log, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	_, member, err := s.db.ShareTask(tokenClaims.UserId, taskId, user.GetUserId(), role)
+	if err != nil {
+		return nil, memberError(log, "ShareTask", err)
+	}
+
+	log.Info("task shared", "member_id", user.GetUserId(), "role", role)
+
+	member.Username = user.GetUsername()
+	return &pb.ShareTaskResponse{Member: memberToPb(member)}, nil
+}
+
+func (s *TasksService) UnshareTask(ctx context.Context, req *pb.UnshareTaskRequest) (*pb.UnshareTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "username", req.GetUsername())
+
+	user, err := s.findUser(ctx, log, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.UnshareTask(tokenClaims.UserId, taskId, user.GetUserId())
+	if err != nil {
+		return nil, memberError(log, "UnshareTask", err)
+	}
+
+	log.Info("task unshared", "member_id", user.GetUserId())
+
+	return &pb.UnshareTaskResponse{}, nil
+}
+
+func (s *TasksService) ListTaskMembers(ctx context.Context, req *pb.ListTaskMembersRequest) (*pb.ListTaskMembersResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt")
+
+	members, err := s.db.ListTaskMembers(tokenClaims.UserId, taskId)
+	if err != nil {
+		return nil, memberError(log, "ListTaskMembers", err)
+	}
+
+	pbMembers := make([]*pb.TaskMember, 0, len(members))
+	for _, m := range members {
+		pbMembers = append(pbMembers, memberToPb(m))
+	}
+
+	return &pb.ListTaskMembersResponse{Members: pbMembers}, nil
+}
+
+// findUser resolves the username through the auth service.
+func (s *TasksService) findUser(ctx context.Context, log *slog.Logger, username string) (*authPb.GetUserByUsernameResponse, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		log.Error("username empty")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidUsernameMessage)
+	}
+
+	authCtx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+
+	user, err := s.auth.GetUserByUsername(authCtx, &authPb.GetUserByUsernameRequest{Username: username})
+	if status.Code(err) == codes.NotFound {
+		log.Error("user not found", "username", username)
+		return nil, status.Error(codes.NotFound, ErrUserNotFoundMessage)
+	}
+	if err != nil {
+		log.Error("auth request error", logging.Err(err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	return user, nil
+}
+
+// memberError logs the storage error and converts it into a gRPC status.
+func memberError(log *slog.Logger, method string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrTaskNotFound):
+		log.Error("task not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	case errors.Is(err, storage.ErrTaskAccessDenied):
+		log.Error("task access denied", logging.Err(err))
+		return status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	case errors.Is(err, storage.ErrMemberNotFound):
+		log.Error("member not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrMemberNotFoundMessage)
+	case errors.Is(err, storage.ErrMemberIsAuthor):
+		log.Error("member is task author", logging.Err(err))
+		return status.Error(codes.FailedPrecondition, ErrMemberIsAuthorMessage)
+	default:
+		log.Error("db error", logging.DbErr(method, err))
+		return status.Error(codes.Internal, ErrInternalMessage)
+	}
+}
+
+func roleIsValid(role string) bool {
+	switch role {
+	case models.RoleViewer, models.RoleEditor, models.RoleOwner:
+		return true
+	}
+	return false
+}
+
+func memberToPb(member *models.TaskMember) *pb.TaskMember {
+	return &pb.TaskMember{
+		Username: member.Username,
+		Role:     member.Role,
+		AddedAt:  member.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	authPb "github.com/Novip1906/tasks-grpc/tasks/internal/auth_gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ownerId  = 1
+	editorId = 2
+	viewerId = 3
+	bobId    = 4
+)
+
+// fakeAuth knows the users by their usernames.
+type fakeAuth struct {
+	authPb.AuthServiceClient
+	users map[string]int64
+}
+
+func (a *fakeAuth) GetUserByUsername(ctx context.Context, req *authPb.GetUserByUsernameRequest,
+	opts ...grpc.CallOption) (*authPb.GetUserByUsernameResponse, error) {
+	id, ok := a.users[req.GetUsername()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &authPb.GetUserByUsernameResponse{UserId: id, Username: req.GetUsername()}, nil
+}
+
+func userContext(userId int64) context.Context {
+	ctx := contextkeys.WithTokenClaims(context.Background(), &contextkeys.TokenClaims{UserId: userId})
+	return contextkeys.WithLogger(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRoleIsValid(t *testing.T) {
+	for _, role := range []string{"viewer", "editor", "owner"} {
+		assert.True(t, roleIsValid(role), role)
+	}
+
+	for _, role := range []string{"", "admin", "Owner", " viewer"} {
+		assert.False(t, roleIsValid(role), role)
+	}
+}
+
+// fakeMemberStorage fails the member changes with err.
+type fakeMemberStorage struct {
+	TasksStorage
+	err error
+}
+
+func (f *fakeMemberStorage) ShareTask(userId, taskId, memberId int64, role string) (*models.Task, *models.TaskMember, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return &models.Task{Id: taskId}, &models.TaskMember{TaskId: taskId, UserId: memberId, Role: role}, nil
+}
+
+func (f *fakeMemberStorage) UnshareTask(userId, taskId, memberId int64) (*models.Task, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.Task{Id: taskId}, nil
+}
+
+func newMemberTestService(err error) *TasksService {
+	auth := &fakeAuth{users: map[string]int64{"owner": ownerId, "editor": editorId, "viewer": viewerId, "bob": bobId}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewTasksService(nil, log, &fakeMemberStorage{err: err}, nil, auth)
+}
+
+func TestShareTask(t *testing.T) {
+	s := newMemberTestService(nil)
+	resp, err := s.ShareTask(userContext(ownerId), &pb.ShareTaskRequest{TaskId: 10, Username: "bob", Role: " editor "})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", resp.GetMember().GetUsername())
+	assert.Equal(t, models.RoleEditor, resp.GetMember().GetRole())
+
+	_, err = s.ShareTask(userContext(ownerId), &pb.ShareTaskRequest{TaskId: 10, Username: "bob", Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ShareTask(userContext(ownerId), &pb.ShareTaskRequest{TaskId: 10, Username: "ghost", Role: "viewer"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ErrUserNotFoundMessage, status.Convert(err).Message())
+}
+
+func TestMemberErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{storage.ErrTaskAccessDenied, codes.PermissionDenied},
+		{storage.ErrTaskNotFound, codes.NotFound},
+		{storage.ErrMemberNotFound, codes.NotFound},
+		{storage.ErrMemberIsAuthor, codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		s := newMemberTestService(tt.err)
+
+		_, err := s.ShareTask(userContext(editorId), &pb.ShareTaskRequest{TaskId: 10, Username: "bob", Role: "viewer"})
+		assert.Equal(t, tt.code, status.Code(err), "share: %v", tt.err)
+
+		_, err = s.UnshareTask(userContext(editorId), &pb.UnshareTaskRequest{TaskId: 10, Username: "viewer"})
+		assert.Equal(t, tt.code, status.Code(err), "unshare: %v", tt.err)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is serialized into the opaque token returned to clients.
+// Sort options are kept to reject tokens used with another ordering.
+type pageToken struct {
+	SortBy   string             `json:"s"`
+	SortDesc bool               `json:"d"`
+	After    *models.TaskCursor `json:"a"`
+}
+
+func encodePageToken(opts *models.TaskListOptions, last *models.Task) (string, error) {
+	data, err := json.Marshal(pageToken{
+		SortBy:   opts.SortBy,
+		SortDesc: opts.SortDesc,
+		After: &models.TaskCursor{
+			Id:        last.Id,
+			CreatedAt: last.CreatedAt,
+			DueAt:     last.DueAt,
+			Priority:  last.Priority,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string, opts *models.TaskListOptions) (*models.TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.After == nil {
+		return nil, errInvalidPageToken
+	}
+
+	if t.SortBy != opts.SortBy || t.SortDesc != opts.SortDesc {
+		return nil, errInvalidPageToken
+	}
+
+	return t.After, nil
+}
+
+func pageSize(requested int32, def, max int) int {
+	if requested <= 0 {
+		return def
+	}
+	if int(requested) > max {
+		return max
+	}
+	return int(requested)
+}
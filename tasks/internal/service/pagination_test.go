@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	dueAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	last := &models.Task{
+		Id:        42,
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		DueAt:     &dueAt,
+		Priority:  models.PriorityHigh,
+	}
+	opts := &models.TaskListOptions{SortBy: models.SortByDueAt}
+
+	token, err := encodePageToken(opts, last)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	cursor, err := decodePageToken(token, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, last.Id, cursor.Id)
+	assert.True(t, last.CreatedAt.Equal(cursor.CreatedAt))
+	assert.True(t, dueAt.Equal(*cursor.DueAt))
+	assert.Equal(t, last.Priority, cursor.Priority)
+}
+
+func TestPageToken_OtherSortRejected(t *testing.T) {
+	last := &models.Task{Id: 1, CreatedAt: time.Now()}
+
+	token, err := encodePageToken(&models.TaskListOptions{SortBy: models.SortByCreatedAt, SortDesc: true}, last)
+	assert.NoError(t, err)
+
+	_, err = decodePageToken(token, &models.TaskListOptions{SortBy: models.SortByCreatedAt})
+	assert.ErrorIs(t, err, errInvalidPageToken)
+
+	_, err = decodePageToken(token, &models.TaskListOptions{SortBy: models.SortByPriority, SortDesc: true})
+	assert.ErrorIs(t, err, errInvalidPageToken)
+}
+
+func TestPageToken_Garbage(t *testing.T) {
+	opts := &models.TaskListOptions{SortBy: models.SortByCreatedAt}
+
+	for _, token := range []string{"not base64!", "e30", "bnVsbA"} {
+		_, err := decodePageToken(token, opts)
+		assert.ErrorIs(t, err, errInvalidPageToken, token)
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	assert.Equal(t, 50, pageSize(0, 50, 200))
+	assert.Equal(t, 50, pageSize(-5, 50, 200))
+	assert.Equal(t, 10, pageSize(10, 50, 200))
+	assert.Equal(t, 200, pageSize(1000, 50, 200))
+}
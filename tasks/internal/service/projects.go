@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ProjectsStorage interface {
+	CreateProject(project *models.Project) (*models.Project, error)
+	GetProject(userId, projectId int64) (*models.Project, error)
+	ListProjects(userId int64, includeArchived bool) ([]*models.Project, error)
+	UpdateProject(userId, projectId int64, upd *models.ProjectUpdate) (*models.Project, error)
+	DeleteProject(userId, projectId int64, deleteTasks bool) (*models.Project, []*models.Task, error)
+}
+
+type ProjectsService struct {
+	pb.UnimplementedProjectsServiceServer
+	cfg *config.Config
+	log *slog.Logger
+	db  ProjectsStorage
+}
+
+func NewProjectsService(config *config.Config, log *slog.Logger, db ProjectsStorage) *ProjectsService {
+	return &ProjectsService{cfg: config, log: log, db: db}
+}
+
+func (s *ProjectsService) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.CreateProjectResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("attempt")
+
+	name := processText(req.GetName())
+	if !projectNameIsValid(name, s.cfg) {
+		log.Error("project name len invalid")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidProjectNameMessage)
+	}
+
+	if !colorIsValid(req.GetColor()) {
+		log.Error("color invalid", "color", req.GetColor())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidColorMessage)
+	}
+
+	project, err := s.db.CreateProject(&models.Project{
+		UserId: tokenClaims.UserId,
+		Name:   name,
+		Color:  req.GetColor(),
+	})
+	if err != nil {
+		log.Error("db error", logging.DbErr("CreateProject", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("project created", slog.Int64("project_id", project.Id))
+
+	return &pb.CreateProjectResponse{Project: projectToPb(project)}, nil
+}
+
+func (s *ProjectsService) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.Project, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	projectId := req.GetProjectId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("project_id", projectId))
+
+	log.Debug("attempt")
+
+	project, err := s.db.GetProject(tokenClaims.UserId, projectId)
+	if err != nil {
+		return nil, projectError(log, "GetProject", err)
+	}
+
+	return projectToPb(project), nil
+}
+
+func (s *ProjectsService) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("list projects attempt")
+
+	projects, err := s.db.ListProjects(tokenClaims.UserId, req.GetIncludeArchived())
+	if err != nil {
+		log.Error("db error", logging.DbErr("ListProjects", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbProjects := make([]*pb.Project, 0, len(projects))
+	for _, project := range projects {
+		pbProjects = append(pbProjects, projectToPb(project))
+	}
+
+	return &pb.ListProjectsResponse{Projects: pbProjects}, nil
+}
+
+func (s *ProjectsService) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.UpdateProjectResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	projectId := req.GetProjectId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("project_id", projectId))
+
+	log.Debug("attempt")
+
+	var upd models.ProjectUpdate
+
+	if req.Name != nil {
+		name := processText(req.GetName())
+		if !projectNameIsValid(name, s.cfg) {
+			log.Error("project name len invalid")
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidProjectNameMessage)
+		}
+		upd.Name = &name
+	}
+
+	if req.Color != nil {
+		if !colorIsValid(req.GetColor()) {
+			log.Error("color invalid", "color", req.GetColor())
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidColorMessage)
+		}
+		upd.Color = req.Color
+	}
+
+	upd.Archived = req.Archived
+
+	if req.Position != nil {
+		if req.GetPosition() < 0 {
+			log.Error("position invalid", "position", req.GetPosition())
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidPositionMessage)
+		}
+		position := int(req.GetPosition())
+		upd.Position = &position
+	}
+
+	project, err := s.db.UpdateProject(tokenClaims.UserId, projectId, &upd)
+	if err != nil {
+		return nil, projectError(log, "UpdateProject", err)
+	}
+
+	log.Info("project updated")
+
+	return &pb.UpdateProjectResponse{Project: projectToPb(project)}, nil
+}
+
+func (s *ProjectsService) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*pb.DeleteProjectResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	projectId := req.GetProjectId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("project_id", projectId))
+
+	log.Debug("attempt", "delete_tasks", req.GetDeleteTasks())
+
+	project, tasks, err := s.db.DeleteProject(tokenClaims.UserId, projectId, req.GetDeleteTasks())
+	if err != nil {
+		return nil, projectError(log, "DeleteProject", err)
+	}
+
+	log.Info("project deleted", "delete_tasks", req.GetDeleteTasks(), "tasks", len(tasks))
+
+	return &pb.DeleteProjectResponse{Project: projectToPb(project), AffectedTasks: int64(len(tasks))}, nil
+}
+
+// projectError logs the storage error and converts it into a gRPC status.
+func projectError(log *slog.Logger, method string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrProjectNotFound):
+		log.Error("project not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrProjectNotFoundMessage)
+	case errors.Is(err, storage.ErrNotProjectOwner):
+		log.Error("user is not project owner", logging.Err(err))
+		return status.Error(codes.PermissionDenied, ErrNotProjectOwnerMessage)
+	default:
+		log.Error("db error", logging.DbErr(method, err))
+		return status.Error(codes.Internal, ErrInternalMessage)
+	}
+}
+
+func projectToPb(project *models.Project) *pb.Project {
+	return &pb.Project{
+		Id:        project.Id,
+		Name:      project.Name,
+		Color:     project.Color,
+		Archived:  project.Archived,
+		Position:  int32(project.Position),
+		CreatedAt: project.CreatedAt.Unix(),
+		UpdatedAt: project.UpdatedAt.Unix(),
+	}
+}
+
+func projectNameIsValid(name string, cfg *config.Config) bool {
+	return len(name) >= cfg.Params.ProjectName.Min && len(name) <= cfg.Params.ProjectName.Max
+}
+
+var colorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// colorIsValid accepts an empty colour or a #rrggbb hex one.
+func colorIsValid(color string) bool {
+	return color == "" || colorRegexp.MatchString(color)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorIsValid(t *testing.T) {
+	for _, color := range []string{"", "#1e90ff", "#FFFFFF", "#000000"} {
+		assert.True(t, colorIsValid(color), color)
+	}
+
+	for _, color := range []string{"1e90ff", "#1e90f", "#1e90ffa", "#gggggg", "red", " #1e90ff"} {
+		assert.False(t, colorIsValid(color), color)
+	}
+}
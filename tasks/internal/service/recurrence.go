@@ -0,0 +1,72 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/rrule"
+)
+
+const defaultTimezone = "UTC"
+
+// normalizeRecurrence returns the rule in the canonical form, an empty rule stays empty.
+func normalizeRecurrence(rule string) (string, bool) {
+	rule = processText(rule)
+	if rule == "" {
+		return "", true
+	}
+
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		return "", false
+	}
+	return r.String(), true
+}
+
+// normalizeTimezone checks the IANA time zone name, an empty name means UTC.
+func normalizeTimezone(tz string) (string, bool) {
+	tz = processText(tz)
+	if tz == "" {
+		return defaultTimezone, true
+	}
+	// "Local" depends on the server settings
+	if tz == "Local" {
+		return "", false
+	}
+
+	if _, err := time.LoadLocation(tz); err != nil {
+		return "", false
+	}
+	return tz, true
+}
+
+// nextDueAt returns the due date of the occurrence following the recurring task, nil when the series has ended.
+// The series is expanded in the task's time zone, so the wall clock of the due date is kept across DST changes.
+func nextDueAt(task *models.Task) (*time.Time, error) {
+	if task.Recurrence == "" || task.DueAt == nil {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(task.RecurrenceTz)
+	if err != nil {
+		return nil, err
+	}
+
+	start := *task.DueAt
+	if task.RecurrenceStart != nil {
+		start = *task.RecurrenceStart
+	}
+
+	next, ok := rule.Next(start.In(loc), task.DueAt.In(loc))
+	if !ok {
+		return nil, nil
+	}
+
+	next = next.UTC()
+	return &next, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRecurrence(t *testing.T) {
+	rule, ok := normalizeRecurrence(" rrule:freq=weekly;byday=mo ")
+	assert.True(t, ok)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", rule)
+
+	rule, ok = normalizeRecurrence("")
+	assert.True(t, ok)
+	assert.Empty(t, rule)
+
+	_, ok = normalizeRecurrence("FREQ=YEARLY")
+	assert.False(t, ok)
+}
+
+func TestNormalizeTimezone(t *testing.T) {
+	for in, want := range map[string]string{"": "UTC", "Europe/Berlin": "Europe/Berlin", " UTC ": "UTC"} {
+		tz, ok := normalizeTimezone(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, tz, in)
+	}
+
+	for _, tz := range []string{"Local", "Mars/Olympus", "+03:00"} {
+		_, ok := normalizeTimezone(tz)
+		assert.False(t, ok, tz)
+	}
+}
+
+func TestNextDueAt(t *testing.T) {
+	// Monday 08:00 in Berlin is 07:00 UTC in winter and 06:00 UTC in summer
+	start := time.Date(2026, time.March, 23, 7, 0, 0, 0, time.UTC)
+	task := &models.Task{
+		DueAt:           &start,
+		Recurrence:      "FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+		RecurrenceTz:    "Europe/Berlin",
+		RecurrenceStart: &start,
+	}
+
+	next, err := nextDueAt(task)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2026, time.March, 30, 6, 0, 0, 0, time.UTC), *next)
+
+	task.DueAt = next
+	next, err = nextDueAt(task)
+	require.NoError(t, err)
+	assert.Nil(t, next, "COUNT is reached")
+}
+
+func TestNextDueAt_NotRecurring(t *testing.T) {
+	due := time.Now()
+
+	next, err := nextDueAt(&models.Task{DueAt: &due})
+	assert.NoError(t, err)
+	assert.Nil(t, next)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TasksService) AddReminder(ctx context.Context, req *pb.AddReminderRequest) (*pb.AddReminderResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "remind_at", req.GetRemindAt())
+
+	remindAt := time.Unix(req.GetRemindAt(), 0)
+	if !reminderTimeIsValid(remindAt, time.Now()) {
+		log.Error("remind time invalid", "remind_at", req.GetRemindAt())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidReminderTimeMessage)
+	}
+
+	reminder, err := s.db.AddReminder(tokenClaims.UserId, taskId, remindAt, s.cfg.Reminders.MaxPerTask)
+	if err != nil {
+		return nil, reminderError(log, "AddReminder", err)
+	}
+
+	log.Info("reminder added", "reminder_id", reminder.Id)
+
+	return &pb.AddReminderResponse{Reminder: reminderToPb(reminder)}, nil
+}
+
+func (s *TasksService) ListReminders(ctx context.Context, req *pb.ListRemindersRequest) (*pb.ListRemindersResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt")
+
+	reminders, err := s.db.ListReminders(tokenClaims.UserId, taskId)
+	if err != nil {
+		return nil, reminderError(log, "ListReminders", err)
+	}
+
+	pbReminders := make([]*pb.Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		pbReminders = append(pbReminders, reminderToPb(reminder))
+	}
+
+	return &pb.ListRemindersResponse{Reminders: pbReminders}, nil
+}
+
+func (s *TasksService) RemoveReminder(ctx context.Context, req *pb.RemoveReminderRequest) (*pb.RemoveReminderResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId), slog.Int64("reminder_id", req.GetReminderId()))
+
+	log.Debug("attempt")
+
+	if err := s.db.RemoveReminder(tokenClaims.UserId, taskId, req.GetReminderId()); err != nil {
+		return nil, reminderError(log, "RemoveReminder", err)
+	}
+
+	log.Info("reminder removed")
+
+	return &pb.RemoveReminderResponse{}, nil
+}
+
+// reminderTimeIsValid allows only the reminders in the future.
+func reminderTimeIsValid(remindAt, now time.Time) bool {
+	return remindAt.After(now)
+}
+
+func reminderError(log *slog.Logger, method string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrTaskNotFound):
+		log.Error("task not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	case errors.Is(err, storage.ErrTaskAccessDenied):
+		log.Error("task access denied", logging.Err(err))
+		return status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	case errors.Is(err, storage.ErrReminderNotFound):
+		log.Error("reminder not found", logging.Err(err))
+		return status.Error(codes.NotFound, ErrReminderNotFoundMessage)
+	case errors.Is(err, storage.ErrTooManyReminders):
+		log.Error("too many reminders", logging.Err(err))
+		return status.Error(codes.FailedPrecondition, ErrTooManyRemindersMessage)
+	default:
+		log.Error("db error", logging.DbErr(method, err))
+		return status.Error(codes.Internal, ErrInternalMessage)
+	}
+}
+
+func reminderToPb(reminder *models.Reminder) *pb.Reminder {
+	pbReminder := &pb.Reminder{
+		Id:       reminder.Id,
+		RemindAt: reminder.RemindAt.Unix(),
+	}
+	if reminder.SentAt != nil {
+		pbReminder.SentAt = reminder.SentAt.Unix()
+	}
+	return pbReminder
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderTimeIsValid(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, reminderTimeIsValid(now.Add(time.Minute), now))
+	assert.False(t, reminderTimeIsValid(now, now))
+	assert.False(t, reminderTimeIsValid(now.Add(-time.Minute), now))
+}
+
+func TestReminderToPb(t *testing.T) {
+	remindAt := time.Unix(1780000000, 0)
+
+	pbReminder := reminderToPb(&models.Reminder{Id: 3, RemindAt: remindAt})
+	assert.Equal(t, int64(3), pbReminder.GetId())
+	assert.Equal(t, remindAt.Unix(), pbReminder.GetRemindAt())
+	assert.Zero(t, pbReminder.GetSentAt())
+
+	sentAt := remindAt.Add(time.Second)
+	pbReminder = reminderToPb(&models.Reminder{Id: 3, RemindAt: remindAt, SentAt: &sentAt})
+	assert.Equal(t, sentAt.Unix(), pbReminder.GetSentAt())
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/diff"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TasksService) ListTaskRevisions(ctx context.Context, req *pb.ListTaskRevisionsRequest) (*pb.ListTaskRevisionsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt")
+
+	revisions, err := s.db.GetTaskRevisions(tokenClaims.UserId, taskId)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("GetTaskRevisions", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbRevisions := make([]*pb.TaskRevision, 0, len(revisions))
+	for _, rev := range revisions {
+		pbRevisions = append(pbRevisions, revisionToPb(rev))
+	}
+
+	return &pb.ListTaskRevisionsResponse{Revisions: pbRevisions}, nil
+}
+
+func (s *TasksService) DiffTaskRevisions(ctx context.Context, req *pb.DiffTaskRevisionsRequest) (*pb.DiffTaskRevisionsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "from_revision", req.GetFromRevision(), "to_revision", req.GetToRevision())
+
+	if req.GetFromRevision() <= 0 || req.GetToRevision() < 0 {
+		log.Error("revision invalid")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidRevisionMessage)
+	}
+
+	var revisions [2]*models.TaskRevision
+	for i, revision := range []int32{req.GetFromRevision(), req.GetToRevision()} {
+		rev, err := s.db.GetTaskRevision(tokenClaims.UserId, taskId, int(revision))
+		if errors.Is(err, storage.ErrTaskNotFound) {
+			log.Error("task not found", logging.Err(err))
+			return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+		}
+		if errors.Is(err, storage.ErrRevisionNotFound) {
+			log.Error("revision not found", "revision", revision, logging.Err(err))
+			return nil, status.Error(codes.NotFound, ErrRevisionNotFoundMessage)
+		}
+		if errors.Is(err, storage.ErrTaskAccessDenied) {
+			log.Error("task access denied", logging.Err(err))
+			return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+		}
+		if err != nil {
+			log.Error("db error", logging.DbErr("GetTaskRevision", err))
+			return nil, status.Error(codes.Internal, ErrInternalMessage)
+		}
+		revisions[i] = rev
+	}
+
+	lines := diff.Lines(revisions[0].Text, revisions[1].Text)
+
+	pbLines := make([]*pb.DiffLine, 0, len(lines))
+	for _, line := range lines {
+		pbLines = append(pbLines, &pb.DiffLine{Op: string(line.Op), Text: line.Text})
+	}
+
+	return &pb.DiffTaskRevisionsResponse{
+		From:  revisionToPb(revisions[0]),
+		To:    revisionToPb(revisions[1]),
+		Lines: pbLines,
+	}, nil
+}
+
+func (s *TasksService) RevertTask(ctx context.Context, req *pb.RevertTaskRequest) (*pb.RevertTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+	revision := int(req.GetRevision())
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "revision", revision)
+
+	if revision <= 0 {
+		log.Error("revision invalid")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidRevisionMessage)
+	}
+
+	expectedVersion := req.GetExpectedVersion()
+	if expectedVersion == 0 {
+		version, err := versionFromIfMatch(ctx)
+		if err != nil {
+			log.Error("if-match invalid", logging.Err(err))
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidVersionMessage)
+		}
+		expectedVersion = version
+	}
+
+	_, task, err := s.db.RevertTask(tokenClaims.UserId, taskId, revision, expectedVersion)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrRevisionNotFound) {
+		log.Error("revision not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrRevisionNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		log.Error("task version mismatch", "expected_version", expectedVersion, logging.Err(err))
+		return nil, status.Error(codes.Aborted, ErrVersionMismatchMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("RevertTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("task reverted", "revision", revision, "version", task.Version)
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return &pb.RevertTaskResponse{Task: taskToPb(task)}, nil
+}
+
+func revisionToPb(rev *models.TaskRevision) *pb.TaskRevision {
+	return &pb.TaskRevision{
+		Revision:   int32(rev.Revision),
+		Text:       rev.Text,
+		EditorName: rev.EditorName,
+		CreatedAt:  rev.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import "github.com/Novip1906/tasks-grpc/tasks/internal/models"
+
+// statusTransitions lists the statuses a task may move to from each status.
+// Finished tasks can be reopened, cancelled ones only go back to the backlog.
+var statusTransitions = map[string][]string{
+	models.StatusTodo:       {models.StatusInProgress, models.StatusDone, models.StatusCancelled},
+	models.StatusInProgress: {models.StatusTodo, models.StatusDone, models.StatusCancelled},
+	models.StatusDone:       {models.StatusTodo, models.StatusInProgress},
+	models.StatusCancelled:  {models.StatusTodo},
+}
+
+func statusIsValid(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+func statusTransitionAllowed(from, to string) bool {
+	for _, s := range statusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusIsValid(t *testing.T) {
+	for _, status := range []string{models.StatusTodo, models.StatusInProgress, models.StatusDone, models.StatusCancelled} {
+		assert.True(t, statusIsValid(status), status)
+	}
+
+	assert.False(t, statusIsValid(""))
+	assert.False(t, statusIsValid("finished"))
+	assert.False(t, statusIsValid("DONE"))
+}
+
+func TestStatusTransitionAllowed(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{models.StatusTodo, models.StatusInProgress, true},
+		{models.StatusTodo, models.StatusDone, true},
+		{models.StatusInProgress, models.StatusDone, true},
+		{models.StatusInProgress, models.StatusCancelled, true},
+		{models.StatusDone, models.StatusTodo, true},
+		{models.StatusDone, models.StatusCancelled, false},
+		{models.StatusCancelled, models.StatusTodo, true},
+		{models.StatusCancelled, models.StatusDone, false},
+		{models.StatusCancelled, models.StatusInProgress, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, statusTransitionAllowed(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/Novip1906/tasks-grpc/tasks/internal/storage"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TasksService) AddTags(ctx context.Context, req *pb.AddTagsRequest) (*pb.AddTagsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "tags", req.GetTags())
+
+	tags, ok := normalizeTags(req.GetTags(), s.cfg.Params.Tags.MaxLength)
+	if !ok || len(tags) == 0 {
+		log.Error("tags invalid", "tags", req.GetTags())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTagsMessage)
+	}
+
+	task, err := s.db.AddTags(tokenClaims.UserId, taskId, tags, s.cfg.Params.Tags.MaxPerTask)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if errors.Is(err, storage.ErrTooManyTags) {
+		log.Error("too many tags", logging.Err(err))
+		return nil, status.Error(codes.InvalidArgument, ErrTooManyTagsMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("AddTags", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("tags added", "tags", tags)
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return &pb.AddTagsResponse{Task: taskToPb(task)}, nil
+}
+
+func (s *TasksService) RemoveTags(ctx context.Context, req *pb.RemoveTagsRequest) (*pb.RemoveTagsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt", "tags", req.GetTags())
+
+	tags, ok := normalizeTags(req.GetTags(), s.cfg.Params.Tags.MaxLength)
+	if !ok || len(tags) == 0 {
+		log.Error("tags invalid", "tags", req.GetTags())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTagsMessage)
+	}
+
+	task, err := s.db.RemoveTags(tokenClaims.UserId, taskId, tags)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("RemoveTags", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("tags removed", "tags", tags)
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return &pb.RemoveTagsResponse{Task: taskToPb(task)}, nil
+}
+
+func (s *TasksService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("list tags attempt")
+
+	tags, err := s.db.ListTags(tokenClaims.UserId)
+	if err != nil {
+		log.Error("db error", logging.DbErr("ListTags", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	return &pb.ListTagsResponse{Tags: tagsToPb(tags)}, nil
+}
+
+// normalizeTags lowercases and trims the tags and drops duplicates keeping the order.
+// It fails when a tag is empty or longer than maxLength.
+func normalizeTags(tags []string, maxLength int) ([]string, bool) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > maxLength || strings.ContainsAny(tag, ",\n\t") {
+			return nil, false
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized, true
+}
+
+func tagsToPb(tags []*models.TagCount) []*pb.Tag {
+	pbTags := make([]*pb.Tag, 0, len(tags))
+	for _, tag := range tags {
+		pbTags = append(pbTags, &pb.Tag{Name: tag.Name, TaskCount: int64(tag.Count)})
+	}
+	return pbTags
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, ok := normalizeTags([]string{" Work ", "home", "work", "HOME", "срочно"}, 32)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"work", "home", "срочно"}, tags)
+
+	tags, ok = normalizeTags(nil, 32)
+	assert.True(t, ok)
+	assert.Empty(t, tags)
+
+	for _, invalid := range [][]string{
+		{""},
+		{"  "},
+		{"work", strings.Repeat("a", 33)},
+		{"a,b"},
+	} {
+		_, ok := normalizeTags(invalid, 32)
+		assert.False(t, ok, "%q", invalid)
+	}
+
+	_, ok = normalizeTags([]string{strings.Repeat("я", 32)}, 32)
+	assert.True(t, ok)
+}
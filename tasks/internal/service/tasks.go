@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pb "github.com/Novip1906/tasks-grpc/tasks/api/proto/gen"
+	authPb "github.com/Novip1906/tasks-grpc/tasks/internal/auth_gen"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/contextkeys"
 	"github.com/Novip1906/tasks-grpc/tasks/internal/elasticsearch"
@@ -19,28 +20,51 @@ import (
 )
 
 type TasksStorage interface {
-	CreateTask(userId int64, text string) (id int64, err error)
+	CreateTask(task *models.Task) (id int64, err error)
 	GetTaskById(userId int64, taskId int64) (*models.Task, error)
-	GetAllUserTasks(userId int64) ([]*models.Task, error)
-	UpdateTask(userId, taskId int64, newText string) (oldTask *models.Task, err error)
-	DeleteTask(userId, taskId int64) (deletedTask *models.Task, err error)
-}
-
-type EmailSender interface {
-	SendEventEmail(ctx context.Context, message *models.EventMessage) error
+	GetAllUserTasks(userId int64, opts *models.TaskListOptions) (tasks []*models.Task, total int, err error)
+	GetOverdueTasks(userId int64, now time.Time) ([]*models.Task, error)
+	UpdateTask(userId, taskId int64, upd *models.TaskUpdate) (oldTask, newTask *models.Task, err error)
+	DeleteTask(userId, taskId int64) (deletedTask *models.Task, subtaskIds []int64, err error)
+	SetTaskStatus(userId, taskId int64, oldStatus, status string, nextDueAt *time.Time) (task, next *models.Task, err error)
+	GetDeletedUserTasks(userId int64) ([]*models.Task, error)
+	RestoreTask(userId, taskId int64) (task *models.Task, subtasks []*models.Task, err error)
+	PurgeTask(userId, taskId int64) (*models.Task, error)
+	GetTaskRevisions(userId, taskId int64) ([]*models.TaskRevision, error)
+	GetTaskRevision(userId, taskId int64, revision int) (*models.TaskRevision, error)
+	RevertTask(userId, taskId int64, revision int, expectedVersion int64) (oldTask, newTask *models.Task, err error)
+	AddTags(userId, taskId int64, tags []string, maxTags int) (*models.Task, error)
+	RemoveTags(userId, taskId int64, tags []string) (*models.Task, error)
+	ListTags(userId int64) ([]*models.TagCount, error)
+	GetSubtasks(taskId int64) ([]*models.Task, error)
+	GetChecklistItems(taskId int64) ([]*models.ChecklistItem, error)
+	ShareTask(userId, taskId, memberId int64, role string) (*models.Task, *models.TaskMember, error)
+	UnshareTask(userId, taskId, memberId int64) (*models.Task, error)
+	ListTaskMembers(userId, taskId int64) ([]*models.TaskMember, error)
+	AssignTask(userId, taskId, assigneeId int64) (oldTask, newTask *models.Task, err error)
+	ListAssignedTasks(userId int64, includeClosed bool) ([]*models.Task, error)
+	AddChecklistItem(userId, taskId int64, text string) (*models.Task, []*models.ChecklistItem, error)
+	ToggleChecklistItem(userId, taskId, itemId int64, done *bool) (*models.Task, []*models.ChecklistItem, error)
+	ReorderChecklistItems(userId, taskId int64, itemIds []int64) (*models.Task, []*models.ChecklistItem, error)
+	RemoveChecklistItem(userId, taskId, itemId int64) (*models.Task, []*models.ChecklistItem, error)
+	AddReminder(userId, taskId int64, remindAt time.Time, maxReminders int) (*models.Reminder, error)
+	ListReminders(userId, taskId int64) ([]*models.Reminder, error)
+	RemoveReminder(userId, taskId, reminderId int64) error
 }
 
 type TasksService struct {
 	pb.UnimplementedTasksServiceServer
-	cfg         *config.Config
-	log         *slog.Logger
-	db          TasksStorage
-	emailSender EmailSender
-	es          *elasticsearch.Client
+	cfg  *config.Config
+	log  *slog.Logger
+	db   TasksStorage
+	es   *elasticsearch.Client
+	auth authPb.AuthServiceClient
 }
 
-func NewTasksService(config *config.Config, log *slog.Logger, db TasksStorage, emailSender EmailSender, esClient *elasticsearch.Client) *TasksService {
-	return &TasksService{cfg: config, log: log, db: db, emailSender: emailSender, es: esClient}
+// NewTasksService creates the service, the events and search index updates of the task changes
+// are stored by the storage in the outbox and delivered by outbox.Relay.
+func NewTasksService(config *config.Config, log *slog.Logger, db TasksStorage, esClient *elasticsearch.Client, authClient authPb.AuthServiceClient) *TasksService {
+	return &TasksService{cfg: config, log: log, db: db, es: esClient, auth: authClient}
 }
 
 func (s *TasksService) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.CreateTaskResponse, error) {
@@ -61,52 +85,77 @@ func (s *TasksService) CreateTask(ctx context.Context, req *pb.CreateTaskRequest
 		return nil, status.Error(codes.InvalidArgument, ErrInvalidTextMessage)
 	}
 
-	taskId, err := s.db.CreateTask(tokenClaims.UserId, text)
-	if err != nil {
-		log.Error("db error", logging.DbErr("CreateTask", err))
-		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	if req.Priority != nil && !priorityIsValid(int(req.GetPriority())) {
+		log.Error("priority invalid", "priority", req.GetPriority())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidPriorityMessage)
 	}
 
-	log.Info("task created")
+	if req.GetDueAt() < 0 {
+		log.Error("due date invalid", "due_at", req.GetDueAt())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidDueDateMessage)
+	}
 
-	task := pb.Task{
-		Id:         taskId,
-		Text:       text,
-		AuthorName: tokenClaims.Username,
-		CreatedAt:  time.Now().Unix(),
+	if req.GetProjectId() < 0 {
+		log.Error("project id invalid", "project_id", req.GetProjectId())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidProjectMessage)
 	}
 
-	if err := s.es.IndexTask(ctx, &models.Task{
-		Id:         task.Id,
-		Text:       task.Text,
-		AuthorName: task.AuthorName,
-		AuthorId:   tokenClaims.UserId,
-		CreatedAt:  time.Now(),
-	}); err != nil {
-		log.Error("es index error", logging.Err(err))
+	recurrence, ok := normalizeRecurrence(req.GetRecurrence())
+	if !ok {
+		log.Error("recurrence invalid", "recurrence", req.GetRecurrence())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidRecurrenceMessage)
 	}
 
-	if tokenClaims.Email == "" {
-		return &pb.CreateTaskResponse{Task: &task}, nil
+	timezone, ok := normalizeTimezone(req.GetTimezone())
+	if !ok {
+		log.Error("timezone invalid", "timezone", req.GetTimezone())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTimezoneMessage)
 	}
 
-	asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if recurrence != "" && req.GetDueAt() == 0 {
+		log.Error("recurrence without due date")
+		return nil, status.Error(codes.InvalidArgument, ErrRecurrenceWithoutDueDateMessage)
+	}
 
-	err = s.emailSender.SendEventEmail(asyncCtx, &models.EventMessage{
-		Email:    tokenClaims.Email,
-		Username: tokenClaims.Username,
-		Type:     "create",
-		TaskText: text,
-	})
+	task := &models.Task{
+		Text:         text,
+		AuthorName:   tokenClaims.Username,
+		AuthorId:     tokenClaims.UserId,
+		Status:       models.StatusTodo,
+		DueAt:        unixToTime(req.GetDueAt()),
+		Priority:     int(req.GetPriority()),
+		ProjectId:    req.GetProjectId(),
+		ParentId:     req.GetParentId(),
+		Recurrence:   recurrence,
+		RecurrenceTz: timezone,
+	}
 
+	taskId, err := s.db.CreateTask(task)
+	if errors.Is(err, storage.ErrParentNotFound) {
+		log.Error("parent task not found", "parent_id", task.ParentId, logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrParentNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrProjectNotFound) || errors.Is(err, storage.ErrNotProjectOwner) {
+		log.Error("project not found", "project_id", task.ProjectId, logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrProjectNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrProjectArchived) {
+		log.Error("project archived", "project_id", task.ProjectId, logging.Err(err))
+		return nil, status.Error(codes.FailedPrecondition, ErrProjectArchivedMessage)
+	}
 	if err != nil {
-		log.Error("kafka error", "email", tokenClaims.Email, logging.Err(err))
-	} else {
-		log.Info("kafka event message sent", "email", tokenClaims.Email, "event-type", "create")
+		log.Error("db error", logging.DbErr("CreateTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	task.Id = taskId
+
+	log.Info("task created")
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
 	}
 
-	return &pb.CreateTaskResponse{Task: &task}, nil
+	return &pb.CreateTaskResponse{Task: taskToPb(task)}, nil
 
 }
 
@@ -126,21 +175,38 @@ func (s *TasksService) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb
 		log.Error("task not found", logging.Err(err))
 		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
 	}
-	if errors.Is(err, storage.ErrNotTaskAuthor) {
-		log.Error("user is not task author", logging.Err(err))
-		return nil, status.Error(codes.PermissionDenied, ErrNotTaskAuthorMessage)
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
 	}
 	if err != nil {
 		log.Error("db error", logging.DbErr("GetTask", err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
 
-	return &pb.Task{
-		Id:         task.Id,
-		Text:       task.Text,
-		AuthorName: task.AuthorName,
-		CreatedAt:  task.CreatedAt.Unix(),
-	}, nil
+	checklist, err := s.db.GetChecklistItems(task.Id)
+	if err != nil {
+		log.Error("db error", logging.DbErr("GetChecklistItems", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbTask := taskToPb(task)
+	pbTask.Checklist = checklistToPb(checklist)
+
+	if req.GetWithSubtasks() {
+		subtasks, err := s.db.GetSubtasks(task.Id)
+		if err != nil {
+			log.Error("db error", logging.DbErr("GetSubtasks", err))
+			return nil, status.Error(codes.Internal, ErrInternalMessage)
+		}
+		pbTask.Subtasks = subtaskTree(task.Id, subtasks)
+	}
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return pbTask, nil
 
 }
 
@@ -153,9 +219,24 @@ func (s *TasksService) SearchTask(ctx context.Context, req *pb.SearchTasksReques
 
 	query := req.GetQuery()
 
-	log.Debug("search tasks attempt", "query", query)
+	log.Debug("search tasks attempt", "query", query, "tags", req.GetTags())
+
+	tags, ok := normalizeTags(req.GetTags(), s.cfg.Params.Tags.MaxLength)
+	if !ok {
+		log.Error("tags invalid", "tags", req.GetTags())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTagsMessage)
+	}
+
+	if req.GetProjectId() < 0 {
+		log.Error("project id invalid", "project_id", req.GetProjectId())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidProjectMessage)
+	}
 
-	tasks, err := s.es.Search(ctx, tokenClaims.UserId, query)
+	tasks, facets, err := s.es.Search(ctx, tokenClaims.UserId, &models.TaskSearchOptions{
+		Query:     query,
+		Tags:      tags,
+		ProjectId: req.ProjectId,
+	})
 	if err != nil {
 		log.Error("es search error", logging.Err(err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
@@ -163,15 +244,10 @@ func (s *TasksService) SearchTask(ctx context.Context, req *pb.SearchTasksReques
 
 	pbTasks := make([]*pb.Task, 0, len(tasks))
 	for _, t := range tasks {
-		pbTasks = append(pbTasks, &pb.Task{
-			Id:         t.Id,
-			Text:       t.Text,
-			AuthorName: t.AuthorName,
-			CreatedAt:  t.CreatedAt.Unix(),
-		})
+		pbTasks = append(pbTasks, taskToPb(t))
 	}
 
-	return &pb.SearchTasksResponse{Tasks: pbTasks}, nil
+	return &pb.SearchTasksResponse{Tasks: pbTasks, TagFacets: tagsToPb(facets)}, nil
 }
 
 func (s *TasksService) GetAllTasks(ctx context.Context, req *pb.GetAllTasksRequest) (*pb.GetAllTasksResponse, error) {
@@ -183,25 +259,81 @@ func (s *TasksService) GetAllTasks(ctx context.Context, req *pb.GetAllTasksReque
 
 	log.Debug("get all tasks attempt")
 
-	tasks, err := s.db.GetAllUserTasks(tokenClaims.UserId)
+	opts, ok := listOptionsFromRequest(req)
+	if !ok {
+		log.Error("list options invalid", "sort_by", req.GetSortBy(), "sort_order", req.GetSortOrder())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidListOptionsMessage)
+	}
+
+	opts.Tags, ok = normalizeTags(req.GetTags(), s.cfg.Params.Tags.MaxLength)
+	if !ok {
+		log.Error("tags invalid", "tags", req.GetTags())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidTagsMessage)
+	}
+
+	if req.GetPageToken() != "" {
+		after, err := decodePageToken(req.GetPageToken(), opts)
+		if err != nil {
+			log.Error("page token invalid", logging.Err(err))
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidPageTokenMessage)
+		}
+		opts.After = after
+	}
+
+	size := pageSize(req.GetPageSize(), s.cfg.Params.PageSize.Default, s.cfg.Params.PageSize.Max)
+	// one extra task tells whether there is a next page
+	opts.Limit = size + 1
+
+	tasks, total, err := s.db.GetAllUserTasks(tokenClaims.UserId, opts)
 	if err != nil {
 		log.Error("db error", logging.DbErr("GetAllTasks", err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
 
+	var nextPageToken string
+	if len(tasks) > size {
+		tasks = tasks[:size]
+		nextPageToken, err = encodePageToken(opts, tasks[len(tasks)-1])
+		if err != nil {
+			log.Error("page token encode error", logging.Err(err))
+			return nil, status.Error(codes.Internal, ErrInternalMessage)
+		}
+	}
+
 	pbTasks := make([]*pb.Task, 0, len(tasks))
 	for _, task := range tasks {
-		pbTask := &pb.Task{
-			Id:         task.Id,
-			Text:       task.Text,
-			AuthorName: task.AuthorName,
-			CreatedAt:  task.CreatedAt.Unix(),
-		}
-		pbTasks = append(pbTasks, pbTask)
+		pbTasks = append(pbTasks, taskToPb(task))
 	}
 
-	return &pb.GetAllTasksResponse{Tasks: pbTasks}, nil
+	return &pb.GetAllTasksResponse{
+		Tasks:         pbTasks,
+		NextPageToken: nextPageToken,
+		TotalCount:    int64(total),
+	}, nil
+
+}
+
+func (s *TasksService) ListOverdueTasks(ctx context.Context, req *pb.ListOverdueTasksRequest) (*pb.ListOverdueTasksResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("list overdue tasks attempt")
+
+	tasks, err := s.db.GetOverdueTasks(tokenClaims.UserId, time.Now())
+	if err != nil {
+		log.Error("db error", logging.DbErr("GetOverdueTasks", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbTasks := make([]*pb.Task, 0, len(tasks))
+	for _, task := range tasks {
+		pbTasks = append(pbTasks, taskToPb(task))
+	}
 
+	return &pb.ListOverdueTasksResponse{Tasks: pbTasks}, nil
 }
 
 func (s *TasksService) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskResponse, error) {
@@ -216,58 +348,174 @@ func (s *TasksService) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest
 
 	log.Debug("attempt")
 
-	newText = processText(newText)
+	var upd models.TaskUpdate
 
-	if !textIsValid(newText, s.cfg) {
-		log.Error("text len invalid")
-		return nil, status.Error(codes.InvalidArgument, ErrInvalidTextMessage)
+	fields, ok := updateFields(req)
+	if !ok {
+		log.Error("update mask invalid", "paths", req.GetUpdateMask().GetPaths())
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidUpdateMaskMessage)
+	}
+
+	if fields[updateFieldText] {
+		newText = processText(newText)
+		if !textIsValid(newText, s.cfg) {
+			log.Error("text len invalid")
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidTextMessage)
+		}
+		upd.Text = &newText
+	}
+
+	if fields[updateFieldPriority] {
+		priority := int(req.GetPriority())
+		if !priorityIsValid(priority) {
+			log.Error("priority invalid", "priority", priority)
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidPriorityMessage)
+		}
+		upd.Priority = &priority
+	}
+
+	if fields[updateFieldDueAt] {
+		if req.GetDueAt() < 0 {
+			log.Error("due date invalid", "due_at", req.GetDueAt())
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidDueDateMessage)
+		}
+		var dueAt time.Time
+		if req.GetDueAt() != 0 {
+			dueAt = time.Unix(req.GetDueAt(), 0).UTC()
+		}
+		upd.DueAt = &dueAt
+	}
+
+	if fields[updateFieldProjectId] {
+		projectId := req.GetProjectId()
+		if projectId < 0 {
+			log.Error("project id invalid", "project_id", projectId)
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidProjectMessage)
+		}
+		upd.ProjectId = &projectId
+	}
+
+	if fields[updateFieldRecurrence] {
+		recurrence, ok := normalizeRecurrence(req.GetRecurrence())
+		if !ok {
+			log.Error("recurrence invalid", "recurrence", req.GetRecurrence())
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidRecurrenceMessage)
+		}
+		upd.Recurrence = &recurrence
+	}
+
+	if fields[updateFieldTimezone] {
+		timezone, ok := normalizeTimezone(req.GetTimezone())
+		if !ok {
+			log.Error("timezone invalid", "timezone", req.GetTimezone())
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidTimezoneMessage)
+		}
+		upd.RecurrenceTz = &timezone
 	}
 
-	oldTask, err := s.db.UpdateTask(tokenClaims.UserId, taskId, newText)
+	upd.ExpectedVersion = req.GetExpectedVersion()
+	if upd.ExpectedVersion == 0 {
+		version, err := versionFromIfMatch(ctx)
+		if err != nil {
+			log.Error("if-match invalid", logging.Err(err))
+			return nil, status.Error(codes.InvalidArgument, ErrInvalidVersionMessage)
+		}
+		upd.ExpectedVersion = version
+	}
+
+	_, task, err := s.db.UpdateTask(tokenClaims.UserId, taskId, &upd)
 	if errors.Is(err, storage.ErrTaskNotFound) {
 		log.Error("task not found", logging.Err(err))
 		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
 	}
-	if errors.Is(err, storage.ErrNotTaskAuthor) {
-		log.Error("user is not task author", logging.Err(err))
-		return nil, status.Error(codes.PermissionDenied, ErrNotTaskAuthorMessage)
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		log.Error("task version mismatch", "expected_version", upd.ExpectedVersion, logging.Err(err))
+		return nil, status.Error(codes.Aborted, ErrVersionMismatchMessage)
+	}
+	if errors.Is(err, storage.ErrProjectNotFound) || errors.Is(err, storage.ErrNotProjectOwner) {
+		log.Error("project not found", "project_id", req.GetProjectId(), logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrProjectNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrProjectArchived) {
+		log.Error("project archived", "project_id", req.GetProjectId(), logging.Err(err))
+		return nil, status.Error(codes.FailedPrecondition, ErrProjectArchivedMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if errors.Is(err, storage.ErrRecurrenceWithoutDueDate) {
+		log.Error("recurrence without due date", logging.Err(err))
+		return nil, status.Error(codes.FailedPrecondition, ErrRecurrenceWithoutDueDateMessage)
 	}
 	if err != nil {
 		log.Error("db error", logging.DbErr("UpdateTask", err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
 
-	log.Info("task updated")
+	log.Info("task updated", "version", task.Version)
 
-	if err := s.es.IndexTask(ctx, oldTask); err != nil {
-		log.Error("es index error", logging.Err(err))
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
 	}
 
-	if tokenClaims.Email == "" {
-		return &pb.UpdateTaskResponse{}, nil
+	return &pb.UpdateTaskResponse{Task: taskToPb(task)}, nil
+}
+
+func (s *TasksService) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
+	taskId := req.GetTaskId()
 
-	asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
 
-	err = s.emailSender.SendEventEmail(asyncCtx, &models.EventMessage{
-		Email:       tokenClaims.Email,
-		Username:    tokenClaims.Username,
-		Type:        "update",
-		TaskText:    newText,
-		TaskOldText: oldTask.Text,
-	})
+	log.Debug("attempt")
 
+	task, subtaskIds, err := s.db.DeleteTask(tokenClaims.UserId, taskId)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
 	if err != nil {
-		log.Error("kafka error", "email", tokenClaims.Email, logging.Err(err))
-	} else {
-		log.Info("kafka event message sent", "email", tokenClaims.Email, "event-type", "update")
+		log.Error("db error", logging.DbErr("DeleteTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
 
-	return &pb.UpdateTaskResponse{}, nil
+	log.Info("task deleted", "subtasks", len(subtaskIds))
+
+	return &pb.DeleteTaskResponse{Task: taskToPb(task), DeletedSubtasks: int64(len(subtaskIds))}, nil
 }
 
-func (s *TasksService) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+func (s *TasksService) ListDeletedTasks(ctx context.Context, req *pb.ListDeletedTasksRequest) (*pb.ListDeletedTasksResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	log := contextkeys.GetLogger(ctx)
+
+	log.Debug("list deleted tasks attempt")
+
+	tasks, err := s.db.GetDeletedUserTasks(tokenClaims.UserId)
+	if err != nil {
+		log.Error("db error", logging.DbErr("GetDeletedUserTasks", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	pbTasks := make([]*pb.Task, 0, len(tasks))
+	for _, task := range tasks {
+		pbTasks = append(pbTasks, taskToPb(task))
+	}
+
+	return &pb.ListDeletedTasksResponse{Tasks: pbTasks}, nil
+}
+
+func (s *TasksService) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.RestoreTaskResponse, error) {
 	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
 	if !ok {
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
@@ -278,52 +526,280 @@ func (s *TasksService) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest
 
 	log.Debug("attempt")
 
-	task, err := s.db.DeleteTask(tokenClaims.UserId, taskId)
+	task, subtasks, err := s.db.RestoreTask(tokenClaims.UserId, taskId)
 	if errors.Is(err, storage.ErrTaskNotFound) {
-		log.Error("task not found", logging.Err(err))
+		log.Error("task not found in trash", logging.Err(err))
 		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
 	}
 	if errors.Is(err, storage.ErrNotTaskAuthor) {
 		log.Error("user is not task author", logging.Err(err))
 		return nil, status.Error(codes.PermissionDenied, ErrNotTaskAuthorMessage)
 	}
+	if errors.Is(err, storage.ErrParentDeleted) {
+		log.Error("parent task is in trash", logging.Err(err))
+		return nil, status.Error(codes.FailedPrecondition, ErrParentDeletedMessage)
+	}
 	if err != nil {
-		log.Error("db error", logging.DbErr("DeleteTask", err))
+		log.Error("db error", logging.DbErr("RestoreTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("task restored", "subtasks", len(subtasks))
+
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	return &pb.RestoreTaskResponse{Task: taskToPb(task), RestoredSubtasks: int64(len(subtasks))}, nil
+}
+
+func (s *TasksService) PurgeTask(ctx context.Context, req *pb.PurgeTaskRequest) (*pb.PurgeTaskResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId))
+
+	log.Debug("attempt")
+
+	task, err := s.db.PurgeTask(tokenClaims.UserId, taskId)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found in trash", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrNotTaskAuthor) {
+		log.Error("user is not task author", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrNotTaskAuthorMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("PurgeTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	log.Info("task purged")
+
+	return &pb.PurgeTaskResponse{Task: taskToPb(task)}, nil
+}
+
+func (s *TasksService) SetTaskStatus(ctx context.Context, req *pb.SetTaskStatusRequest) (*pb.SetTaskStatusResponse, error) {
+	tokenClaims, ok := contextkeys.GetTokenClaims(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+	taskId := req.GetTaskId()
+	newStatus := processText(req.GetStatus())
+
+	log := contextkeys.GetLogger(ctx).With(slog.Int64("task_id", taskId), slog.String("status", newStatus))
+
+	log.Debug("attempt")
+
+	if !statusIsValid(newStatus) {
+		log.Error("status invalid")
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidStatusMessage)
+	}
+
+	task, err := s.db.GetTaskById(tokenClaims.UserId, taskId)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("GetTask", err))
+		return nil, status.Error(codes.Internal, ErrInternalMessage)
+	}
+
+	if task.Status == newStatus {
+		return &pb.SetTaskStatusResponse{Task: taskToPb(task)}, nil
+	}
+
+	if !statusTransitionAllowed(task.Status, newStatus) {
+		log.Error("status transition not allowed", "old_status", task.Status)
+		return nil, status.Error(codes.FailedPrecondition, ErrStatusTransitionMessage)
+	}
+
+	oldStatus := task.Status
+
+	var dueAt *time.Time
+	if newStatus == models.StatusDone {
+		// a broken rule must not prevent closing the task, the series just ends
+		dueAt, err = nextDueAt(task)
+		if err != nil {
+			log.Error("next occurrence error", "recurrence", task.Recurrence, logging.Err(err))
+		}
+	}
+
+	task, next, err := s.db.SetTaskStatus(tokenClaims.UserId, taskId, oldStatus, newStatus, dueAt)
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		log.Error("task not found", logging.Err(err))
+		return nil, status.Error(codes.NotFound, ErrTaskNotFoundMessage)
+	}
+	if errors.Is(err, storage.ErrTaskStatusChanged) {
+		log.Error("task status changed concurrently", "old_status", oldStatus, logging.Err(err))
+		return nil, status.Error(codes.FailedPrecondition, ErrTaskStatusChangedMessage)
+	}
+	if errors.Is(err, storage.ErrTaskAccessDenied) {
+		log.Error("task access denied", logging.Err(err))
+		return nil, status.Error(codes.PermissionDenied, ErrTaskAccessDeniedMessage)
+	}
+	if err != nil {
+		log.Error("db error", logging.DbErr("SetTaskStatus", err))
 		return nil, status.Error(codes.Internal, ErrInternalMessage)
 	}
 
-	log.Info("task deleted")
+	log.Info("task status changed", "old_status", oldStatus)
 
-	responseTask := pb.Task{
+	if err := setETag(ctx, task.Version); err != nil {
+		log.Error("set etag error", logging.Err(err))
+	}
+
+	resp := &pb.SetTaskStatusResponse{Task: taskToPb(task)}
+	if next != nil {
+		log.Info("next occurrence created", "next_task_id", next.Id, "due_at", next.DueAt)
+		resp.NextTask = taskToPb(next)
+	}
+
+	return resp, nil
+}
+
+func taskToPb(task *models.Task) *pb.Task {
+	pbTask := &pb.Task{
 		Id:         task.Id,
 		Text:       task.Text,
 		AuthorName: task.AuthorName,
 		CreatedAt:  task.CreatedAt.Unix(),
+		Status:     task.Status,
+	}
+	if task.CompletedAt != nil {
+		pbTask.CompletedAt = task.CompletedAt.Unix()
+	}
+	if task.DueAt != nil {
+		pbTask.DueAt = task.DueAt.Unix()
+	}
+	pbTask.Priority = int32(task.Priority)
+	pbTask.Version = task.Version
+	if !task.UpdatedAt.IsZero() {
+		pbTask.UpdatedAt = task.UpdatedAt.Unix()
+	}
+	if task.DeletedAt != nil {
+		pbTask.DeletedAt = task.DeletedAt.Unix()
+	}
+	pbTask.Tags = task.Tags
+	pbTask.ProjectId = task.ProjectId
+	pbTask.ParentId = task.ParentId
+	pbTask.Progress = int32(taskProgress(task))
+	pbTask.AssigneeName = task.AssigneeName
+	if task.Recurrence != "" {
+		pbTask.Recurrence = task.Recurrence
+		pbTask.Timezone = task.RecurrenceTz
+	}
+	return pbTask
+}
+
+const (
+	updateFieldText       = "newText"
+	updateFieldDueAt      = "dueAt"
+	updateFieldPriority   = "priority"
+	updateFieldProjectId  = "projectId"
+	updateFieldRecurrence = "recurrence"
+	updateFieldTimezone   = "timezone"
+)
+
+// updateFields returns the fields UpdateTask has to change.
+// Without an update mask the text is replaced unless only the due date, priority, project or recurrence are passed.
+func updateFields(req *pb.UpdateTaskRequest) (map[string]bool, bool) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		fields := map[string]bool{
+			updateFieldDueAt:      req.DueAt != nil,
+			updateFieldPriority:   req.Priority != nil,
+			updateFieldProjectId:  req.ProjectId != nil,
+			updateFieldRecurrence: req.Recurrence != nil,
+			updateFieldTimezone:   req.Timezone != nil,
+		}
+		fields[updateFieldText] = req.GetNewText() != "" ||
+			(req.DueAt == nil && req.Priority == nil && req.ProjectId == nil && req.Recurrence == nil && req.Timezone == nil)
+		return fields, true
+	}
+
+	fields := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		switch path {
+		case updateFieldText, updateFieldDueAt, updateFieldPriority, updateFieldProjectId, updateFieldRecurrence, updateFieldTimezone:
+			fields[path] = true
+		default:
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+func listOptionsFromRequest(req *pb.GetAllTasksRequest) (*models.TaskListOptions, bool) {
+	opts := &models.TaskListOptions{
+		SortBy:      req.GetSortBy(),
+		DueBefore:   unixToTime(req.GetDueBefore()),
+		DueAfter:    unixToTime(req.GetDueAfter()),
+		CreatedFrom: unixToTime(req.GetCreatedFrom()),
+		CreatedTo:   unixToTime(req.GetCreatedTo()),
+		TextPrefix:  processText(req.GetTextPrefix()),
 	}
 
-	if err := s.es.DeleteTask(ctx, task.Id); err != nil {
-		log.Error("es delete error", logging.Err(err))
+	if req.GetDueBefore() < 0 || req.GetDueAfter() < 0 || req.GetCreatedFrom() < 0 || req.GetCreatedTo() < 0 {
+		return nil, false
 	}
 
-	if tokenClaims.Email != "" {
-		asyncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	switch opts.SortBy {
+	case "":
+		opts.SortBy = models.SortByCreatedAt
+	case models.SortByCreatedAt, models.SortByDueAt, models.SortByPriority:
+	default:
+		return nil, false
+	}
 
-		err = s.emailSender.SendEventEmail(asyncCtx, &models.EventMessage{
-			Email:    tokenClaims.Email,
-			Username: tokenClaims.Username,
-			Type:     "delete",
-			TaskText: task.Text,
-		})
+	switch strings.ToLower(req.GetSortOrder()) {
+	case "":
+		// nearest due dates first, newest and most important tasks first otherwise
+		opts.SortDesc = opts.SortBy != models.SortByDueAt
+	case "asc":
+	case "desc":
+		opts.SortDesc = true
+	default:
+		return nil, false
+	}
 
-		if err != nil {
-			log.Error("kafka error", "email", tokenClaims.Email, logging.Err(err))
-		} else {
-			log.Info("kafka event message sent", "email", tokenClaims.Email, "event-type", "delete")
+	if req.Priority != nil {
+		priority := int(req.GetPriority())
+		if !priorityIsValid(priority) {
+			return nil, false
 		}
+		opts.Priority = &priority
 	}
 
-	return &pb.DeleteTaskResponse{Task: &responseTask}, nil
+	if req.ProjectId != nil {
+		if req.GetProjectId() < 0 {
+			return nil, false
+		}
+		opts.ProjectId = req.ProjectId
+	}
+
+	return opts, true
+}
+
+func priorityIsValid(priority int) bool {
+	return priority >= models.PriorityNone && priority <= models.PriorityHigh
+}
+
+func unixToTime(ts int64) *time.Time {
+	if ts <= 0 {
+		return nil
+	}
+	t := time.Unix(ts, 0).UTC()
+	return &t
 }
 
 func textIsValid(text string, cfg *config.Config) bool {
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+)
+
+// AssignTask makes the user responsible for the task, a zero assignee id removes the assignee.
+// The editors can assign the task to its members, only the owners can assign it to other users, as
+// the assignee becomes an editor of the task unless they already have more rights. The rights given
+// by the assignment are taken back when the user stops being the assignee: the member added by it
+// is removed and the viewer gets the role back, unless the role was shared explicitly since.
+func (s *PostgresStorage) AssignTask(userId, taskId, assigneeId int64) (oldTask, newTask *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		oldTask, err = getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err := checkAssignRole(tx.QueryRow, oldTask, userId, assigneeId); err != nil {
+			return err
+		}
+
+		query := `
+		UPDATE tasks
+		SET assignee_id=$1, version=version+1, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2`
+		if _, err := tx.Exec(query, sql.NullInt64{Int64: assigneeId, Valid: assigneeId != 0}, taskId); err != nil {
+			return err
+		}
+
+		if assigneeId != oldTask.AssigneeId {
+			if oldTask.AssigneeId != 0 {
+				if err := revokeAssignmentGrant(tx, taskId, oldTask.AssigneeId); err != nil {
+					return err
+				}
+			}
+			if assigneeId != 0 && assigneeId != oldTask.AuthorId {
+				if err := grantAssignee(tx, taskId, assigneeId); err != nil {
+					return err
+				}
+			}
+		}
+
+		// the user may have lost the access by unassigning themselves
+		newTask, err = scanTask(tx.QueryRow(selectTasks+" WHERE t.id=$1", taskId))
+		if err != nil {
+			return err
+		}
+
+		if err := enqueueIndex(tx, taskId); err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventUpdated, oldTask, newTask)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oldTask, newTask, nil
+}
+
+// checkAssignRole makes sure the user may assign the task to the assignee, assigning it to someone
+// who isn't a member shares the task with them, which only the owners can do.
+func checkAssignRole(queryRow func(query string, args ...any) *sql.Row, task *models.Task, userId, assigneeId int64) error {
+	if assigneeId == 0 || assigneeId == task.AuthorId || assigneeId == task.AssigneeId {
+		return nil
+	}
+
+	var role string
+	err := queryRow("SELECT role FROM task_members WHERE task_id=$1 AND user_id=$2", task.Id, assigneeId).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return checkTaskRole(queryRow, task, userId, models.RoleOwner)
+	}
+	return err
+}
+
+// grantAssignee makes the assignee an editor, assignment_grant keeps the role it had before:
+// empty when the assignment added the member.
+func grantAssignee(tx *sql.Tx, taskId, assigneeId int64) error {
+	query := `
+	INSERT INTO task_members (task_id, user_id, role, assignment_grant) VALUES ($1, $2, $3, '')
+	ON CONFLICT (task_id, user_id) DO UPDATE SET role = EXCLUDED.role, assignment_grant = task_members.role
+	WHERE task_members.role = $4`
+	_, err := tx.Exec(query, taskId, assigneeId, models.RoleEditor, models.RoleViewer)
+	return err
+}
+
+// revokeAssignmentGrant takes back the rights the assignment gave to the former assignee.
+func revokeAssignmentGrant(tx *sql.Tx, taskId, userId int64) error {
+	query := "DELETE FROM task_members WHERE task_id=$1 AND user_id=$2 AND assignment_grant = ''"
+	res, err := tx.Exec(query, taskId, userId)
+	if err != nil {
+		return err
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		return deletePendingReminders(tx, taskId, userId)
+	}
+
+	query = `
+	UPDATE task_members SET role = assignment_grant, assignment_grant = NULL
+	WHERE task_id=$1 AND user_id=$2 AND assignment_grant <> ''`
+	_, err = tx.Exec(query, taskId, userId)
+	return err
+}
+
+// ListAssignedTasks returns the tasks assigned to the user they can still see, the closest due dates first.
+// Done and cancelled tasks are skipped unless includeClosed is set.
+func (s *PostgresStorage) ListAssignedTasks(userId int64, includeClosed bool) ([]*models.Task, error) {
+	var where whereBuilder
+
+	where.add("t.assignee_id=?", userId)
+	where.add(taskVisibleTo, userId, userId)
+	where.add("t.deleted_at IS NULL")
+	if !includeClosed {
+		where.add("t.status NOT IN (?, ?)", models.StatusDone, models.StatusCancelled)
+	}
+
+	query := selectTasks + " WHERE " + where.String() + " ORDER BY t.due_at ASC NULLS LAST, t.id ASC"
+	rows, err := s.db.Query(query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+)
+
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func getChecklistItems(q queryer, taskId int64) ([]*models.ChecklistItem, error) {
+	query := `
+	SELECT id, task_id, text, done, position, created_at, updated_at
+	FROM checklist_items
+	WHERE task_id=$1
+	ORDER BY position, id`
+
+	rows, err := q.Query(query, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*models.ChecklistItem
+	for rows.Next() {
+		var item models.ChecklistItem
+		err := rows.Scan(&item.Id, &item.TaskId, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetChecklistItems returns the task checklist in display order, the caller must check the task access.
+func (s *PostgresStorage) GetChecklistItems(taskId int64) ([]*models.ChecklistItem, error) {
+	return getChecklistItems(s.db, taskId)
+}
+
+// changeChecklist runs fn on the locked task and returns the task with the new version and its checklist.
+func (s *PostgresStorage) changeChecklist(userId, taskId int64, fn func(tx *sql.Tx) error) (*models.Task, []*models.ChecklistItem, error) {
+	var (
+		task  *models.Task
+		items []*models.ChecklistItem
+	)
+	err := s.withTx(func(tx *sql.Tx) error {
+		if _, err := getTaskForUpdate(tx, userId, taskId, models.RoleEditor); err != nil {
+			return err
+		}
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		var err error
+		if task, err = touchTask(tx, userId, taskId); err != nil {
+			return err
+		}
+		items, err = getChecklistItems(tx, taskId)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, items, nil
+}
+
+// AddChecklistItem appends an item to the end of the task checklist.
+func (s *PostgresStorage) AddChecklistItem(userId, taskId int64, text string) (*models.Task, []*models.ChecklistItem, error) {
+	return s.changeChecklist(userId, taskId, func(tx *sql.Tx) error {
+		query := `
+		INSERT INTO checklist_items (task_id, text, position)
+		SELECT $1, $2, COALESCE(MAX(position) + 1, 0) FROM checklist_items WHERE task_id=$1`
+
+		_, err := tx.Exec(query, taskId, text)
+		return err
+	})
+}
+
+// ToggleChecklistItem sets the item done flag, a nil done flips it.
+func (s *PostgresStorage) ToggleChecklistItem(userId, taskId, itemId int64, done *bool) (*models.Task, []*models.ChecklistItem, error) {
+	return s.changeChecklist(userId, taskId, func(tx *sql.Tx) error {
+		query := `
+		UPDATE checklist_items
+		SET done=COALESCE($3, NOT done), updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND task_id=$2`
+
+		var doneArg sql.NullBool
+		if done != nil {
+			doneArg = sql.NullBool{Bool: *done, Valid: true}
+		}
+
+		res, err := tx.Exec(query, itemId, taskId, doneArg)
+		if err != nil {
+			return err
+		}
+		return checkItemAffected(res)
+	})
+}
+
+// ReorderChecklistItems puts the items in the given order, which must list every item of the checklist once.
+func (s *PostgresStorage) ReorderChecklistItems(userId, taskId int64, itemIds []int64) (*models.Task, []*models.ChecklistItem, error) {
+	return s.changeChecklist(userId, taskId, func(tx *sql.Tx) error {
+		items, err := getChecklistItems(tx, taskId)
+		if err != nil {
+			return err
+		}
+
+		if len(items) != len(itemIds) {
+			return ErrInvalidChecklistOrder
+		}
+		known := make(map[int64]bool, len(items))
+		for _, item := range items {
+			known[item.Id] = true
+		}
+		for _, id := range itemIds {
+			if !known[id] {
+				return ErrInvalidChecklistOrder
+			}
+			delete(known, id)
+		}
+
+		query := "UPDATE checklist_items SET position=$1, updated_at=CURRENT_TIMESTAMP WHERE id=$2"
+		for position, id := range itemIds {
+			if _, err := tx.Exec(query, position, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveChecklistItem deletes the item and closes the gap in the positions.
+func (s *PostgresStorage) RemoveChecklistItem(userId, taskId, itemId int64) (*models.Task, []*models.ChecklistItem, error) {
+	return s.changeChecklist(userId, taskId, func(tx *sql.Tx) error {
+		var position int
+		query := "DELETE FROM checklist_items WHERE id=$1 AND task_id=$2 RETURNING position"
+		err := tx.QueryRow(query, itemId, taskId).Scan(&position)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrChecklistItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		query = "UPDATE checklist_items SET position=position-1 WHERE task_id=$1 AND position>$2"
+		_, err = tx.Exec(query, taskId, position)
+		return err
+	})
+}
+
+func checkItemAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrChecklistItemNotFound
+	}
+	return nil
+}
@@ -3,6 +3,23 @@ package storage
 import "errors"
 
 var (
-	ErrTaskNotFound  = errors.New("task not found")
-	ErrNotTaskAuthor = errors.New("user is not the task author")
+	ErrTaskNotFound             = errors.New("task not found")
+	ErrNotTaskAuthor            = errors.New("user is not the task author")
+	ErrTaskAccessDenied         = errors.New("user has no access to the task")
+	ErrMemberNotFound           = errors.New("task member not found")
+	ErrMemberIsAuthor           = errors.New("task author can't be a member")
+	ErrVersionMismatch          = errors.New("task version mismatch")
+	ErrTaskStatusChanged        = errors.New("task status changed concurrently")
+	ErrRevisionNotFound         = errors.New("task revision not found")
+	ErrTooManyTags              = errors.New("too many tags on the task")
+	ErrProjectNotFound          = errors.New("project not found")
+	ErrNotProjectOwner          = errors.New("user is not the project owner")
+	ErrProjectArchived          = errors.New("project is archived")
+	ErrParentNotFound           = errors.New("parent task not found")
+	ErrParentDeleted            = errors.New("parent task is deleted")
+	ErrChecklistItemNotFound    = errors.New("checklist item not found")
+	ErrInvalidChecklistOrder    = errors.New("checklist order doesn't match the items")
+	ErrRecurrenceWithoutDueDate = errors.New("recurring task has no due date")
+	ErrReminderNotFound         = errors.New("reminder not found")
+	ErrTooManyReminders         = errors.New("too many reminders on the task")
 )
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+)
+
+// taskVisibleTo matches the tasks the user authored or is a member of, both ? take the user id.
+const taskVisibleTo = "(t.author_id=? OR EXISTS (SELECT 1 FROM task_members m WHERE m.task_id = t.id AND m.user_id=?))"
+
+var roleRanks = map[string]int{
+	models.RoleViewer: 1,
+	models.RoleEditor: 2,
+	models.RoleOwner:  3,
+}
+
+// roleAtLeast reports whether the role grants the rights of minRole.
+func roleAtLeast(role, minRole string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[minRole]
+}
+
+// checkTaskRole makes sure the user has at least minRole on the task, the author is always its owner.
+func checkTaskRole(queryRow func(query string, args ...any) *sql.Row, task *models.Task, userId int64, minRole string) error {
+	role := models.RoleOwner
+	if userId != task.AuthorId {
+		query := "SELECT role FROM task_members WHERE task_id=$1 AND user_id=$2"
+		err := queryRow(query, task.Id, userId).Scan(&role)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTaskAccessDenied
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !roleAtLeast(role, minRole) {
+		return ErrTaskAccessDenied
+	}
+	return nil
+}
+
+// ShareTask gives the member the role on the task or changes the role of an existing member.
+// Only the task owners can share it.
+func (s *PostgresStorage) ShareTask(userId, taskId, memberId int64, role string) (task *models.Task, member *models.TaskMember, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		task, err = getTaskForUpdate(tx, userId, taskId, models.RoleOwner)
+		if err != nil {
+			return err
+		}
+		if memberId == task.AuthorId {
+			return ErrMemberIsAuthor
+		}
+
+		// the explicitly shared role is kept when the member stops being the assignee
+		query := `
+		INSERT INTO task_members (task_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (task_id, user_id) DO UPDATE SET role = EXCLUDED.role, assignment_grant = NULL
+		RETURNING created_at`
+		member = &models.TaskMember{TaskId: taskId, UserId: memberId, Role: role}
+		if err := tx.QueryRow(query, taskId, memberId, role).Scan(&member.CreatedAt); err != nil {
+			return err
+		}
+		if err := enqueueIndex(tx, taskId); err != nil {
+			return err
+		}
+
+		task, err = getTaskForUpdate(tx, userId, taskId, models.RoleOwner)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, member, nil
+}
+
+// UnshareTask takes the access to the task away from the member. The owners can remove anyone,
+// other members can only leave the task themselves. A member who was the assignee is unassigned.
+func (s *PostgresStorage) UnshareTask(userId, taskId, memberId int64) (task *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		oldTask, err := getTaskForUpdate(tx, userId, taskId, unshareRole(userId, memberId))
+		if err != nil {
+			return err
+		}
+		if memberId == oldTask.AuthorId {
+			return ErrMemberIsAuthor
+		}
+
+		res, err := tx.Exec("DELETE FROM task_members WHERE task_id=$1 AND user_id=$2", taskId, memberId)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrMemberNotFound
+		}
+
+		if err := deletePendingReminders(tx, taskId, memberId); err != nil {
+			return err
+		}
+
+		unassigned := oldTask.AssigneeId == memberId
+		if unassigned {
+			query := "UPDATE tasks SET assignee_id=NULL, version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1"
+			if _, err := tx.Exec(query, taskId); err != nil {
+				return err
+			}
+		}
+		if err := enqueueIndex(tx, taskId); err != nil {
+			return err
+		}
+
+		task, err = scanTask(tx.QueryRow(selectTasks+" WHERE t.id=$1", taskId))
+		if err != nil || !unassigned {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventUpdated, oldTask, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// unshareRole returns the least role the user needs to remove the member, anyone can leave the task.
+func unshareRole(userId, memberId int64) string {
+	if memberId == userId {
+		return models.RoleViewer
+	}
+	return models.RoleOwner
+}
+
+// deletePendingReminders removes the reminders of the user who can't see the task anymore, they must not be sent.
+func deletePendingReminders(tx *sql.Tx, taskId, userId int64) error {
+	query := "DELETE FROM reminders WHERE task_id=$1 AND user_id=$2 AND sent_at IS NULL"
+	_, err := tx.Exec(query, taskId, userId)
+	return err
+}
+
+// ListTaskMembers returns the task author followed by the members in the order they were added.
+func (s *PostgresStorage) ListTaskMembers(userId, taskId int64) ([]*models.TaskMember, error) {
+	task, err := s.GetTaskById(userId, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT m.task_id, m.user_id, u.username, m.role, m.created_at
+	FROM task_members m
+	JOIN users u ON m.user_id = u.id
+	WHERE m.task_id=$1
+	ORDER BY m.created_at, m.user_id`
+
+	rows, err := s.db.Query(query, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*models.TaskMember{{
+		TaskId:    task.Id,
+		UserId:    task.AuthorId,
+		Username:  task.AuthorName,
+		Role:      models.RoleOwner,
+		CreatedAt: task.CreatedAt,
+	}}
+	for rows.Next() {
+		var member models.TaskMember
+		if err := rows.Scan(&member.TaskId, &member.UserId, &member.Username, &member.Role, &member.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	return members, rows.Err()
+}
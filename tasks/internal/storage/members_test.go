@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	authorId = 1
+	ownerId  = 2
+	editorId = 3
+	viewerId = 4
+	bobId    = 5
+)
+
+// membersConnector answers the queries of the role of a member, the only query it knows,
+// with the roles keyed by the task and user ids.
+type membersConnector struct {
+	roles map[[2]int64]string
+}
+
+func (c *membersConnector) Connect(ctx context.Context) (driver.Conn, error) { return c, nil }
+func (c *membersConnector) Driver() driver.Driver                            { return nil }
+func (c *membersConnector) Prepare(query string) (driver.Stmt, error)        { return c, nil }
+func (c *membersConnector) Begin() (driver.Tx, error)                        { return nil, driver.ErrSkip }
+func (c *membersConnector) Close() error                                     { return nil }
+func (c *membersConnector) NumInput() int                                    { return -1 }
+
+func (c *membersConnector) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("unexpected exec")
+}
+
+func (c *membersConnector) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("unexpected query args %v", args)
+	}
+	role, ok := c.roles[[2]int64{args[0].(int64), args[1].(int64)}]
+	return &roleRows{role: role, done: !ok}, nil
+}
+
+type roleRows struct {
+	role string
+	done bool
+}
+
+func (r *roleRows) Columns() []string { return []string{"role"} }
+func (r *roleRows) Close() error      { return nil }
+
+func (r *roleRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.role
+	r.done = true
+	return nil
+}
+
+// newMembersDB returns the task of the author shared with an owner, an editor and a viewer.
+func newMembersDB(t *testing.T) (*sql.DB, *models.Task) {
+	task := &models.Task{Id: 10, AuthorId: authorId}
+	db := sql.OpenDB(&membersConnector{roles: map[[2]int64]string{
+		{task.Id, ownerId}:  models.RoleOwner,
+		{task.Id, editorId}: models.RoleEditor,
+		{task.Id, viewerId}: models.RoleViewer,
+	}})
+	t.Cleanup(func() { db.Close() })
+	return db, task
+}
+
+func TestCheckTaskRole(t *testing.T) {
+	db, task := newMembersDB(t)
+
+	tests := []struct {
+		name   string
+		userId int64
+		// the least role the action takes
+		minRole string
+		allowed bool
+	}{
+		{"author updates", authorId, models.RoleEditor, true},
+		{"owner updates", ownerId, models.RoleEditor, true},
+		{"editor updates", editorId, models.RoleEditor, true},
+		{"viewer updates", viewerId, models.RoleEditor, false},
+		{"stranger reads", bobId, models.RoleViewer, false},
+		{"viewer reads", viewerId, models.RoleViewer, true},
+
+		{"author shares", authorId, models.RoleOwner, true},
+		{"owner shares", ownerId, models.RoleOwner, true},
+		{"editor shares", editorId, models.RoleOwner, false},
+		{"viewer shares", viewerId, models.RoleOwner, false},
+
+		{"owner removes a member", ownerId, unshareRole(ownerId, viewerId), true},
+		{"editor removes a member", editorId, unshareRole(editorId, viewerId), false},
+		{"viewer removes a member", viewerId, unshareRole(viewerId, editorId), false},
+		{"editor leaves", editorId, unshareRole(editorId, editorId), true},
+		{"viewer leaves", viewerId, unshareRole(viewerId, viewerId), true},
+		{"stranger leaves", bobId, unshareRole(bobId, bobId), false},
+
+		{"owner assigns", ownerId, models.RoleEditor, true},
+		{"editor assigns", editorId, models.RoleEditor, true},
+		{"viewer assigns", viewerId, models.RoleEditor, false},
+	}
+
+	for _, tt := range tests {
+		err := checkTaskRole(db.QueryRow, task, tt.userId, tt.minRole)
+		if tt.allowed {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorIs(t, err, ErrTaskAccessDenied, tt.name)
+		}
+	}
+}
+
+func TestCheckAssignRole(t *testing.T) {
+	db, task := newMembersDB(t)
+
+	tests := []struct {
+		name       string
+		userId     int64
+		assigneeId int64
+		allowed    bool
+	}{
+		{"editor assigns a member", editorId, viewerId, true},
+		{"editor assigns the author", editorId, authorId, true},
+		{"editor unassigns", editorId, 0, true},
+		{"editor assigns a stranger", editorId, bobId, false},
+		{"owner assigns a stranger", ownerId, bobId, true},
+		{"author assigns a stranger", authorId, bobId, true},
+	}
+
+	for _, tt := range tests {
+		err := checkAssignRole(db.QueryRow, task, tt.userId, tt.assigneeId)
+		if tt.allowed {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorIs(t, err, ErrTaskAccessDenied, tt.name)
+		}
+	}
+
+	task.AssigneeId = bobId
+	assert.NoError(t, checkAssignRole(db.QueryRow, task, editorId, bobId), "the assignee is kept")
+}
+
+func TestRoleAtLeast(t *testing.T) {
+	assert.True(t, roleAtLeast(models.RoleOwner, models.RoleEditor))
+	assert.True(t, roleAtLeast(models.RoleEditor, models.RoleEditor))
+	assert.False(t, roleAtLeast(models.RoleViewer, models.RoleEditor))
+	assert.False(t, roleAtLeast("admin", models.RoleViewer), "an unknown role grants nothing")
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/lib/pq"
+)
+
+// enqueueIndex asks the outbox relay to sync the tasks documents in Elasticsearch after the transaction commits.
+func enqueueIndex(tx *sql.Tx, taskIds ...int64) error {
+	query := "INSERT INTO outbox (task_id, kind) SELECT unnest($1::int[]), $2"
+	_, err := tx.Exec(query, pq.Array(taskIds), models.OutboxKindIndex)
+	return err
+}
+
+// enqueueEvent stores the event to be published to Kafka by the outbox relay after the transaction commits.
+func enqueueEvent(tx *sql.Tx, event *models.TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO outbox (task_id, kind, payload) VALUES ($1, $2, $3)"
+	_, err = tx.Exec(query, event.TaskId, models.OutboxKindEvent, payload)
+	return err
+}
+
+// enqueueTaskEvent stores the event of the task change made by the user. The old task is only used
+// by the update events, an update which changes none of the reported fields produces no event.
+func enqueueTaskEvent(tx *sql.Tx, userId int64, eventType string, oldTask, task *models.Task) error {
+	event := models.NewTaskEvent(eventType, userId, oldTask, task, time.Now())
+	if eventType == models.TaskEventUpdated && len(event.Changes) == 0 {
+		return nil
+	}
+	return enqueueEvent(tx, event)
+}
+
+// GetTaskForIndexing returns the task in any state, the tasks in the trash included,
+// so the outbox relay can tell whether its document has to be indexed or deleted.
+func (s *PostgresStorage) GetTaskForIndexing(taskId int64) (*models.Task, error) {
+	task, err := scanTask(s.db.QueryRow(selectTasks+" WHERE t.id=$1", taskId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ClaimOutbox returns up to limit pending outbox messages and holds them back from the other relays
+// until now+lease, so a message the relay didn't finish, as it stopped, is delivered again after the lease.
+// Only the oldest pending message of each task and kind is picked, so a message which isn't delivered yet
+// holds back the following messages of its task and they are delivered in order. The failed messages
+// don't hold them back anymore. The messages are claimed with SKIP LOCKED in a single statement,
+// so several relays never claim the same message and no transaction stays open during the delivery.
+func (s *PostgresStorage) ClaimOutbox(now time.Time, limit int, lease time.Duration) ([]*models.OutboxMessage, error) {
+	now = now.UTC()
+
+	query := `
+	WITH claimed AS (
+		SELECT o.id
+		FROM outbox o
+		WHERE o.delivered_at IS NULL AND o.failed_at IS NULL
+			AND (o.next_attempt_at IS NULL OR o.next_attempt_at <= $1)
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.task_id = o.task_id AND p.kind = o.kind AND p.id < o.id
+					AND p.delivered_at IS NULL AND p.failed_at IS NULL
+			)
+		ORDER BY o.id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE outbox o SET next_attempt_at=$3
+	FROM claimed
+	WHERE o.id = claimed.id
+	RETURNING o.id, o.task_id, o.kind, o.payload, o.attempts`
+
+	rows, err := s.db.Query(query, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*models.OutboxMessage
+	for rows.Next() {
+		var msg models.OutboxMessage
+		if err := rows.Scan(&msg.Id, &msg.TaskId, &msg.Kind, &msg.Payload, &msg.Attempts); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(messages, func(a, b *models.OutboxMessage) int { return cmp.Compare(a.Id, b.Id) })
+	return messages, nil
+}
+
+// MarkOutboxDelivered marks the claimed messages as delivered.
+func (s *PostgresStorage) MarkOutboxDelivered(ids []int64, now time.Time) error {
+	_, err := s.db.Exec("UPDATE outbox SET delivered_at=$1 WHERE id = ANY($2)", now.UTC(), pq.Array(ids))
+	return err
+}
+
+// RetryOutbox records the failed attempt of the claimed message and schedules the next one.
+func (s *PostgresStorage) RetryOutbox(id int64, handleErr error, retryAt time.Time) error {
+	query := "UPDATE outbox SET attempts=attempts+1, last_error=$1, next_attempt_at=$2 WHERE id=$3"
+	_, err := s.db.Exec(query, handleErr.Error(), retryAt.UTC(), id)
+	return err
+}
+
+// FailOutbox records the last failed attempt of the claimed message, it's not retried anymore
+// and stops holding back the following messages of its task.
+func (s *PostgresStorage) FailOutbox(id int64, handleErr error, now time.Time) error {
+	query := "UPDATE outbox SET attempts=attempts+1, last_error=$1, failed_at=$2 WHERE id=$3"
+	_, err := s.db.Exec(query, handleErr.Error(), now.UTC(), id)
+	return err
+}
+
+// DeleteDeliveredOutbox removes the messages delivered before the given time.
+func (s *PostgresStorage) DeleteDeliveredOutbox(deliveredBefore time.Time) (int64, error) {
+	res, err := s.db.Exec("DELETE FROM outbox WHERE delivered_at < $1", deliveredBefore.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
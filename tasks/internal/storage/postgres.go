@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type PostgresStorage struct {
@@ -46,30 +48,309 @@ func (s *PostgresStorage) init() error {
 		text TEXT,
 		author_id INT REFERENCES users (id),
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);`
-	_, err := s.db.Exec(schema)
-	return err
+	);
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'todo';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_at TIMESTAMP;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS tasks_author_due_at_idx ON tasks (author_id, due_at);
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+	CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+	CREATE TABLE IF NOT EXISTS task_revisions (
+		id BIGSERIAL PRIMARY KEY,
+		task_id INT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		revision INT NOT NULL,
+		text TEXT,
+		editor_id INT REFERENCES users (id),
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (task_id, revision)
+	);
+
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		name TEXT PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS tags (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL REFERENCES users (id),
+		name TEXT NOT NULL,
+		UNIQUE (user_id, name)
+	);
+	CREATE TABLE IF NOT EXISTS task_tags (
+		task_id INT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+		PRIMARY KEY (task_id, tag_id)
+	);
+	CREATE INDEX IF NOT EXISTS task_tags_tag_id_idx ON task_tags (tag_id);
+
+	CREATE TABLE IF NOT EXISTS projects (
+		id SERIAL PRIMARY KEY,
+		user_id INT NOT NULL REFERENCES users (id),
+		name TEXT NOT NULL,
+		color TEXT NOT NULL DEFAULT '',
+		archived BOOLEAN NOT NULL DEFAULT FALSE,
+		position INT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS projects_user_id_idx ON projects (user_id, position);
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INT REFERENCES projects (id) ON DELETE SET NULL;
+	CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
+
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES tasks (id) ON DELETE CASCADE;
+	CREATE INDEX IF NOT EXISTS tasks_parent_id_idx ON tasks (parent_id);
+	CREATE TABLE IF NOT EXISTS checklist_items (
+		id SERIAL PRIMARY KEY,
+		task_id INT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		text TEXT NOT NULL,
+		done BOOLEAN NOT NULL DEFAULT FALSE,
+		position INT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS checklist_items_task_id_idx ON checklist_items (task_id, position);
+
+	CREATE TABLE IF NOT EXISTS task_members (
+		task_id INT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		role TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (task_id, user_id)
+	);
+	CREATE INDEX IF NOT EXISTS task_members_user_id_idx ON task_members (user_id);
+	ALTER TABLE task_members ADD COLUMN IF NOT EXISTS assignment_grant TEXT;
+
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignee_id INT REFERENCES users (id) ON DELETE SET NULL;
+	CREATE INDEX IF NOT EXISTS tasks_assignee_id_idx ON tasks (assignee_id) WHERE assignee_id IS NOT NULL;
+
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_tz TEXT NOT NULL DEFAULT 'UTC';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_start TIMESTAMP;
+
+	CREATE TABLE IF NOT EXISTS reminders (
+		id SERIAL PRIMARY KEY,
+		task_id INT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+		remind_at TIMESTAMP NOT NULL,
+		sent_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS reminders_task_id_idx ON reminders (task_id, user_id);
+	CREATE INDEX IF NOT EXISTS reminders_pending_idx ON reminders (remind_at) WHERE sent_at IS NULL;
+
+	CREATE TABLE IF NOT EXISTS outbox (
+		id BIGSERIAL PRIMARY KEY,
+		task_id INT NOT NULL,
+		kind TEXT NOT NULL,
+		payload JSONB NOT NULL DEFAULT '{}',
+		attempts INT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		delivered_at TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (task_id, kind, id) WHERE delivered_at IS NULL;
+	CREATE INDEX IF NOT EXISTS outbox_delivered_at_idx ON outbox (delivered_at) WHERE delivered_at IS NOT NULL;
+	ALTER TABLE outbox ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;`
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// the tasks created before the revision history get their text as revision 1
+	return s.migrateOnce("task_revisions_backfill", `
+	INSERT INTO task_revisions (task_id, revision, text, editor_id, created_at)
+	SELECT t.id, 1, t.text, t.author_id, t.updated_at FROM tasks t
+	WHERE NOT EXISTS (SELECT 1 FROM task_revisions r WHERE r.task_id = t.id)`)
 }
 
-func (s *PostgresStorage) CreateTask(userId int64, text string) (id int64, err error) {
-	query := "INSERT INTO tasks (text, author_id) VALUES ($1, $2) RETURNING id"
-	err = s.db.QueryRow(query, text, userId).Scan(&id)
+// migrateOnce runs the data migration unless it's recorded in schema_migrations. The marker row is
+// locked until the migration commits, so the instances starting together don't run it twice.
+func (s *PostgresStorage) migrateOnce(name, query string) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec("INSERT INTO schema_migrations (name) VALUES ($1) ON CONFLICT DO NOTHING", name)
+		if err != nil {
+			return err
+		}
+		applied, err := res.RowsAffected()
+		if err != nil || applied == 0 {
+			return err
+		}
+
+		_, err = tx.Exec(query)
+		return err
+	})
+}
+
+func (s *PostgresStorage) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const selectTasks = `
+	SELECT t.id, t.text, u.username, t.author_id, t.created_at, t.status, t.completed_at, t.due_at, t.priority,
+		t.version, t.updated_at, t.deleted_at, COALESCE(t.project_id, 0), COALESCE(t.parent_id, 0),
+		ARRAY(
+			SELECT tg.name FROM task_tags tt JOIN tags tg ON tt.tag_id = tg.id
+			WHERE tt.task_id = t.id ORDER BY tg.name
+		),
+		ARRAY(SELECT m.user_id FROM task_members m WHERE m.task_id = t.id ORDER BY m.user_id),
+		COALESCE(t.assignee_id, 0), COALESCE((SELECT a.username FROM users a WHERE a.id = t.assignee_id), ''),
+		t.recurrence, t.recurrence_tz, t.recurrence_start,
+		progress.checklist_total, progress.checklist_done, progress.subtasks_total, progress.subtasks_done
+	FROM tasks t
+	JOIN users u ON t.author_id = u.id
+	LEFT JOIN LATERAL (
+		SELECT
+			COUNT(*) FILTER (WHERE p.checklist) AS checklist_total,
+			COUNT(*) FILTER (WHERE p.checklist AND p.done) AS checklist_done,
+			COUNT(*) FILTER (WHERE NOT p.checklist) AS subtasks_total,
+			COUNT(*) FILTER (WHERE NOT p.checklist AND p.done) AS subtasks_done
+		FROM (
+			SELECT TRUE AS checklist, ci.done FROM checklist_items ci WHERE ci.task_id = t.id
+			UNION ALL
+			SELECT FALSE, st.status = 'done' FROM tasks st
+			WHERE st.parent_id = t.id AND st.deleted_at IS NULL AND st.status <> 'cancelled'
+		) p
+	) progress ON TRUE`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row rowScanner) (*models.Task, error) {
+	var (
+		task            models.Task
+		completedAt     sql.NullTime
+		dueAt           sql.NullTime
+		deletedAt       sql.NullTime
+		recurrenceStart sql.NullTime
+	)
+	err := row.Scan(
+		&task.Id, &task.Text, &task.AuthorName, &task.AuthorId, &task.CreatedAt,
+		&task.Status, &completedAt, &dueAt, &task.Priority,
+		&task.Version, &task.UpdatedAt, &deletedAt, &task.ProjectId, &task.ParentId, pq.Array(&task.Tags),
+		pq.Array(&task.MemberIds), &task.AssigneeId, &task.AssigneeName,
+		&task.Recurrence, &task.RecurrenceTz, &recurrenceStart, &task.ChecklistTotal, &task.ChecklistDone, &task.SubtasksTotal, &task.SubtasksDone,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+	if dueAt.Valid {
+		task.DueAt = &dueAt.Time
+	}
+	if deletedAt.Valid {
+		task.DeletedAt = &deletedAt.Time
+	}
+	if recurrenceStart.Valid {
+		task.RecurrenceStart = &recurrenceStart.Time
+	}
+	return &task, nil
+}
+
+func scanTasks(rows *sql.Rows) ([]*models.Task, error) {
+	defer rows.Close()
+
+	var tasks []*models.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateTask inserts the task and fills its id, version and timestamps with the stored ones.
+func (s *PostgresStorage) CreateTask(task *models.Task) (id int64, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		if err := checkTaskProject(tx, task.AuthorId, task.ProjectId); err != nil {
+			return err
+		}
+		if err := checkTaskParent(tx, task.AuthorId, task.ParentId); err != nil {
+			return err
+		}
+
+		if task.Recurrence != "" {
+			task.RecurrenceStart = task.DueAt
+		}
+
+		query := `
+		INSERT INTO tasks (text, author_id, due_at, priority, project_id, parent_id, recurrence, recurrence_tz, recurrence_start)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, version, created_at, updated_at`
+		err := tx.QueryRow(query, task.Text, task.AuthorId, task.DueAt, task.Priority,
+			nullProjectId(task.ProjectId), nullTaskId(task.ParentId),
+			task.Recurrence, task.RecurrenceTz, task.RecurrenceStart).Scan(&id, &task.Version, &task.CreatedAt, &task.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := insertRevision(tx, id, task.Text, task.AuthorId); err != nil {
+			return err
+		}
+
+		task.Id = id
+		if err := enqueueIndex(tx, id); err != nil {
+			return err
+		}
+
+		created, err := scanTask(tx.QueryRow(selectTasks+" WHERE t.id=$1", id))
+		if err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, task.AuthorId, models.TaskEventCreated, nil, created)
+	})
 	if err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-func (s *PostgresStorage) GetTaskById(userId, taskId int64) (*models.Task, error) {
-	query := `
-        SELECT t.id, t.text, u.username, t.created_at, u.id
-        FROM tasks t 
-        JOIN users u ON t.author_id = u.id 
-        WHERE t.id=$1`
+// getTaskForUpdate loads the task and locks its row until the transaction ends.
+// The user must have at least minRole on the task.
+func getTaskForUpdate(tx *sql.Tx, userId, taskId int64, minRole string) (*models.Task, error) {
+	query := selectTasks + " WHERE t.id=$1 AND t.deleted_at IS NULL FOR UPDATE OF t"
 
-	var task models.Task
+	task, err := scanTask(tx.QueryRow(query, taskId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	err := s.db.QueryRow(query, taskId).Scan(&task.Id, &task.Text, &task.AuthorName, &task.CreatedAt, &task.AuthorId)
+	if err := checkTaskRole(tx.QueryRow, task, userId, minRole); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *PostgresStorage) GetTaskById(userId, taskId int64) (*models.Task, error) {
+	query := selectTasks + " WHERE t.id=$1 AND t.deleted_at IS NULL"
+
+	task, err := scanTask(s.db.QueryRow(query, taskId))
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrTaskNotFound
 	}
@@ -77,104 +358,497 @@ func (s *PostgresStorage) GetTaskById(userId, taskId int64) (*models.Task, error
 		return nil, err
 	}
 
-	if userId != task.AuthorId {
-		return nil, ErrNotTaskAuthor
+	if err := checkTaskRole(s.db.QueryRow, task, userId, models.RoleViewer); err != nil {
+		return nil, err
 	}
 
-	return &task, nil
+	return task, nil
 }
 
-func (s *PostgresStorage) GetAllUserTasks(userId int64) ([]*models.Task, error) {
-	query := `
-	SELECT t.id, t.text, u.username, t.created_at, t.author_id
-	FROM tasks t 
-	JOIN users u ON t.author_id = u.id 
-	WHERE t.author_id=$1 
-	ORDER BY t.created_at DESC`
+var sortColumns = map[string]string{
+	models.SortByCreatedAt: "t.created_at",
+	models.SortByDueAt:     "t.due_at",
+	models.SortByPriority:  "t.priority",
+}
+
+// whereBuilder collects AND-ed conditions, each ? in a condition is replaced by the next $n placeholder.
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
 
-	rows, err := s.db.Query(query, userId)
+func (b *whereBuilder) add(cond string, args ...any) {
+	for _, arg := range args {
+		b.args = append(b.args, arg)
+		cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(b.args)), 1)
+	}
+	b.conds = append(b.conds, cond)
+}
+
+func (b *whereBuilder) String() string {
+	return strings.Join(b.conds, " AND ")
+}
+
+func (s *PostgresStorage) GetAllUserTasks(userId int64, opts *models.TaskListOptions) (tasks []*models.Task, total int, err error) {
+	var where whereBuilder
+
+	where.add(taskVisibleTo, userId, userId)
+	where.add("t.deleted_at IS NULL")
+
+	if opts.Priority != nil {
+		where.add("t.priority=?", *opts.Priority)
+	}
+	if opts.DueBefore != nil {
+		where.add("t.due_at<?", *opts.DueBefore)
+	}
+	if opts.DueAfter != nil {
+		where.add("t.due_at>=?", *opts.DueAfter)
+	}
+	if opts.CreatedFrom != nil {
+		where.add("t.created_at>=?", *opts.CreatedFrom)
+	}
+	if opts.CreatedTo != nil {
+		where.add("t.created_at<?", *opts.CreatedTo)
+	}
+	if opts.TextPrefix != "" {
+		where.add(`t.text ILIKE ? ESCAPE '\'`, escapeLike(opts.TextPrefix)+"%")
+	}
+	if opts.ProjectId != nil {
+		if *opts.ProjectId == 0 {
+			where.add("t.project_id IS NULL")
+		} else {
+			where.add("t.project_id=?", *opts.ProjectId)
+		}
+	}
+	if len(opts.Tags) > 0 {
+		// the task must have every requested tag
+		where.add(`t.id IN (
+			SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tt.tag_id = tg.id
+			WHERE tg.name = ANY(?)
+			GROUP BY tt.task_id HAVING COUNT(*)=?)`, pq.Array(opts.Tags), len(opts.Tags))
+	}
+
+	countQuery := "SELECT COUNT(*) FROM tasks t WHERE " + where.String()
+	if err := s.db.QueryRow(countQuery, where.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if opts.After != nil {
+		addKeysetCondition(&where, opts)
+	}
+
+	column, ok := sortColumns[opts.SortBy]
+	if !ok {
+		column = sortColumns[models.SortByCreatedAt]
+	}
+	direction := "ASC"
+	if opts.SortDesc {
+		direction = "DESC"
+	}
+
+	query := selectTasks + `
+	WHERE ` + where.String() + `
+	ORDER BY ` + column + " " + direction + " NULLS LAST, t.id " + direction
+
+	args := where.args
+	if opts.Limit > 0 {
+		args = append(args, opts.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err = scanTasks(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// addKeysetCondition restricts the list to the rows that follow opts.After in the (sort column, id) order.
+func addKeysetCondition(where *whereBuilder, opts *models.TaskListOptions) {
+	after := opts.After
+
+	cmp := ">"
+	if opts.SortDesc {
+		cmp = "<"
+	}
+
+	switch opts.SortBy {
+	case models.SortByDueAt:
+		// tasks without a due date always go last
+		if after.DueAt == nil {
+			where.add("(t.due_at IS NULL AND t.id "+cmp+" ?)", after.Id)
+			return
+		}
+		where.add("(t.due_at IS NULL OR (t.due_at, t.id) "+cmp+" (?, ?))", *after.DueAt, after.Id)
+	case models.SortByPriority:
+		where.add("(t.priority, t.id) "+cmp+" (?, ?)", after.Priority, after.Id)
+	default:
+		where.add("(t.created_at, t.id) "+cmp+" (?, ?)", after.CreatedAt, after.Id)
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// GetOverdueTasks returns the open tasks of the user due before now, the due dates are kept in UTC.
+func (s *PostgresStorage) GetOverdueTasks(userId int64, now time.Time) ([]*models.Task, error) {
+	query := selectTasks + `
+	WHERE (t.author_id=$1 OR EXISTS (SELECT 1 FROM task_members m WHERE m.task_id = t.id AND m.user_id=$1))
+		AND t.deleted_at IS NULL
+		AND t.due_at < $4
+		AND t.status NOT IN ($2, $3)
+	ORDER BY t.due_at ASC, t.id ASC`
+
+	rows, err := s.db.Query(query, userId, models.StatusDone, models.StatusCancelled, now.UTC())
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanTasks(rows)
+}
 
-	var tasks []*models.Task
-	for rows.Next() {
-		var (
-			task models.Task
-		)
-		err := rows.Scan(&task.Id, &task.Text, &task.AuthorName, &task.CreatedAt, &task.AuthorId)
+func (s *PostgresStorage) UpdateTask(userId, taskId int64, upd *models.TaskUpdate) (oldTask, newTask *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		oldTask, err = getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
 		if err != nil {
+			return err
+		}
+
+		if upd.ExpectedVersion != 0 && upd.ExpectedVersion != oldTask.Version {
+			return ErrVersionMismatch
+		}
+
+		newTask, err = applyTaskUpdate(tx, userId, oldTask, upd)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oldTask, newTask, nil
+}
+
+// applyTaskUpdate saves the changed task fields and records a new revision when the text changes.
+// The task row must be locked by the caller.
+func applyTaskUpdate(tx *sql.Tx, editorId int64, oldTask *models.Task, upd *models.TaskUpdate) (*models.Task, error) {
+	task := *oldTask
+	if upd.Text != nil {
+		task.Text = *upd.Text
+	}
+	if upd.DueAt != nil {
+		task.DueAt = upd.DueAt
+		if upd.DueAt.IsZero() {
+			task.DueAt = nil
+		}
+	}
+	if upd.Priority != nil {
+		task.Priority = *upd.Priority
+	}
+	if upd.ProjectId != nil && *upd.ProjectId != oldTask.ProjectId {
+		if err := checkTaskProject(tx, oldTask.AuthorId, *upd.ProjectId); err != nil {
 			return nil, err
 		}
+		task.ProjectId = *upd.ProjectId
+	}
+	if upd.Recurrence != nil {
+		task.Recurrence = *upd.Recurrence
+	}
+	if upd.RecurrenceTz != nil {
+		task.RecurrenceTz = *upd.RecurrenceTz
+	}
+	if task.Recurrence != "" && task.DueAt == nil {
+		return nil, ErrRecurrenceWithoutDueDate
+	}
+	// a new rule or due date starts the series over
+	if task.Recurrence == "" {
+		task.RecurrenceStart = nil
+	} else if task.Recurrence != oldTask.Recurrence || upd.DueAt != nil {
+		task.RecurrenceStart = task.DueAt
+	}
+
+	query := `
+	UPDATE tasks
+	SET text=$1, due_at=$2, priority=$3, project_id=$4, recurrence=$5, recurrence_tz=$6, recurrence_start=$7,
+		version=version+1, updated_at=CURRENT_TIMESTAMP
+	WHERE id=$8
+	RETURNING version, updated_at`
+
+	err := tx.QueryRow(query, task.Text, task.DueAt, task.Priority, nullProjectId(task.ProjectId),
+		task.Recurrence, task.RecurrenceTz, task.RecurrenceStart, task.Id).
+		Scan(&task.Version, &task.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
 
-		tasks = append(tasks, &task)
+	if task.Text != oldTask.Text {
+		if err := insertRevision(tx, task.Id, task.Text, editorId); err != nil {
+			return nil, err
+		}
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := enqueueIndex(tx, task.Id); err != nil {
 		return nil, err
 	}
-	return tasks, nil
+	if err := enqueueTaskEvent(tx, editorId, models.TaskEventUpdated, oldTask, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
 }
 
-func (s *PostgresStorage) UpdateTask(userId, taskId int64, newText string) (*models.Task, error) {
-	task, err := s.GetTaskById(userId, taskId)
+// SetTaskStatus changes the task status from oldStatus, the status the transition was checked against.
+// ErrTaskStatusChanged is returned when the status was changed since, and the task is returned unchanged
+// when it's already in the status. When a recurring task is done and nextDueAt is set,
+// the next occurrence is created and the recurrence moves to it.
+func (s *PostgresStorage) SetTaskStatus(userId, taskId int64, oldStatus, status string, nextDueAt *time.Time) (task, next *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		task, err = getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+		if err != nil {
+			return err
+		}
+		// a concurrent request already set the status, it's kept as is
+		if task.Status == status {
+			return nil
+		}
+		// the transition was only allowed from the status read before the row was locked
+		if task.Status != oldStatus {
+			return ErrTaskStatusChanged
+		}
+		oldTask := *task
+
+		query := `
+		UPDATE tasks
+		SET status=$1,
+			completed_at = CASE WHEN $1 = 'done' THEN CURRENT_TIMESTAMP ELSE NULL END,
+			version=version+1,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2
+		RETURNING completed_at, version, updated_at`
+
+		var completedAt sql.NullTime
+		if err := tx.QueryRow(query, status, taskId).Scan(&completedAt, &task.Version, &task.UpdatedAt); err != nil {
+			return err
+		}
+
+		task.Status = status
+		task.CompletedAt = nil
+		if completedAt.Valid {
+			task.CompletedAt = &completedAt.Time
+		}
+
+		if err := enqueueIndex(tx, taskId); err != nil {
+			return err
+		}
+
+		if status != models.StatusDone || nextDueAt == nil || task.Recurrence == "" {
+			return enqueueTaskEvent(tx, userId, models.TaskEventUpdated, &oldTask, task)
+		}
+
+		next, err = createNextOccurrence(tx, task, *nextDueAt)
+		if err != nil {
+			return err
+		}
+
+		query = "UPDATE tasks SET recurrence='', recurrence_start=NULL WHERE id=$1"
+		if _, err := tx.Exec(query, taskId); err != nil {
+			return err
+		}
+		task.Recurrence = ""
+		task.RecurrenceStart = nil
+
+		if err := enqueueTaskEvent(tx, userId, models.TaskEventUpdated, &oldTask, task); err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventCreated, nil, next)
+	})
 	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, next, nil
+}
+
+// createNextOccurrence copies the recurring task with its tags, members and unchecked checklist to the new due date.
+func createNextOccurrence(tx *sql.Tx, task *models.Task, dueAt time.Time) (*models.Task, error) {
+	query := `
+	INSERT INTO tasks (text, author_id, due_at, priority, project_id, parent_id, assignee_id,
+		recurrence, recurrence_tz, recurrence_start)
+	SELECT text, author_id, $2, priority, project_id, parent_id, assignee_id,
+		recurrence, recurrence_tz, recurrence_start
+	FROM tasks WHERE id=$1
+	RETURNING id`
+
+	var id int64
+	if err := tx.QueryRow(query, task.Id, dueAt).Scan(&id); err != nil {
 		return nil, err
 	}
 
-	if task.Text == newText {
-		return task, nil
+	copies := []string{
+		"INSERT INTO task_tags (task_id, tag_id) SELECT $1, tag_id FROM task_tags WHERE task_id=$2",
+		`INSERT INTO task_members (task_id, user_id, role, assignment_grant)
+		SELECT $1, user_id, role, assignment_grant FROM task_members WHERE task_id=$2`,
+		"INSERT INTO checklist_items (task_id, text, position) SELECT $1, text, position FROM checklist_items WHERE task_id=$2",
+	}
+	for _, query := range copies {
+		if _, err := tx.Exec(query, id, task.Id); err != nil {
+			return nil, err
+		}
 	}
 
-	query := "UPDATE tasks SET text=$1 WHERE id=$2"
-	_, err = s.db.Exec(query, newText, taskId)
-	if err != nil {
+	// the pending reminders keep their offset from the due date, the sent ones stay with the done task
+	query = `
+	INSERT INTO reminders (task_id, user_id, remind_at)
+	SELECT $1, user_id, remind_at + ($3::timestamp - $4::timestamp) FROM reminders WHERE task_id=$2 AND sent_at IS NULL`
+	if _, err := tx.Exec(query, id, task.Id, dueAt.UTC(), task.DueAt.UTC()); err != nil {
 		return nil, err
 	}
 
-	return task, nil
+	if err := insertRevision(tx, id, task.Text, task.AuthorId); err != nil {
+		return nil, err
+	}
+	if err := enqueueIndex(tx, id); err != nil {
+		return nil, err
+	}
+
+	return scanTask(tx.QueryRow(selectTasks+" WHERE t.id=$1", id))
 }
 
 func (s *PostgresStorage) GetAllTasksForIndexing() ([]*models.Task, error) {
-	query := `
-	SELECT t.id, t.text, u.username, t.author_id, t.created_at
-	FROM tasks t
-	JOIN users u ON t.author_id = u.id`
-
-	rows, err := s.db.Query(query)
+	rows, err := s.db.Query(selectTasks + " WHERE t.deleted_at IS NULL")
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanTasks(rows)
+}
 
-	var tasks []*models.Task
-	for rows.Next() {
-		var task models.Task
-		if err := rows.Scan(
-			&task.Id,
-			&task.Text,
-			&task.AuthorName,
-			&task.AuthorId,
-			&task.CreatedAt,
-		); err != nil {
-			return nil, err
+// DeleteTask moves the task with its whole subtree to the trash. The subtasks share the deletion time
+// with the task, which lets RestoreTask bring them back together.
+func (s *PostgresStorage) DeleteTask(userId, taskId int64) (deletedTask *models.Task, subtaskIds []int64, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		deletedTask, err = getTaskForUpdate(tx, userId, taskId, models.RoleOwner)
+		if err != nil {
+			return err
+		}
+
+		query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id=$1
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks
+		SET deleted_at=CURRENT_TIMESTAMP, version=version+1, updated_at=CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING id, deleted_at, version, updated_at`
+
+		rows, err := tx.Query(query, taskId)
+		if err != nil {
+			return err
 		}
-		tasks = append(tasks, &task)
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				id        int64
+				deletedAt time.Time
+				version   int64
+				updatedAt time.Time
+			)
+			if err := rows.Scan(&id, &deletedAt, &version, &updatedAt); err != nil {
+				return err
+			}
+			if id != taskId {
+				subtaskIds = append(subtaskIds, id)
+				continue
+			}
+			deletedTask.DeletedAt = &deletedAt
+			deletedTask.Version = version
+			deletedTask.UpdatedAt = updatedAt
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		if err := enqueueIndex(tx, append([]int64{taskId}, subtaskIds...)...); err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventDeleted, nil, deletedTask)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	return tasks, nil
+
+	return deletedTask, subtaskIds, nil
 }
 
-func (s *PostgresStorage) DeleteTask(userId, taskId int64) (deletedTask *models.Task, err error) {
-	task, err := s.GetTaskById(userId, taskId)
+// GetSubtasks returns all the not deleted descendants of the task.
+func (s *PostgresStorage) GetSubtasks(taskId int64) ([]*models.Task, error) {
+	query := selectTasks + `
+	WHERE t.id IN (
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE parent_id=$1 AND deleted_at IS NULL
+			UNION
+			SELECT st.id FROM tasks st JOIN subtree s ON st.parent_id = s.id WHERE st.deleted_at IS NULL
+		)
+		SELECT id FROM subtree
+	)
+	ORDER BY t.created_at, t.id`
+
+	rows, err := s.db.Query(query, taskId)
 	if err != nil {
 		return nil, err
 	}
+	return scanTasks(rows)
+}
+
+// checkTaskParent makes sure a subtask can be created under the parent, a zero parent id means a top level task.
+func checkTaskParent(tx *sql.Tx, userId, parentId int64) error {
+	if parentId == 0 {
+		return nil
+	}
+
+	query := "SELECT author_id FROM tasks WHERE id=$1 AND deleted_at IS NULL FOR SHARE"
+
+	var authorId int64
+	err := tx.QueryRow(query, parentId).Scan(&authorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrParentNotFound
+	}
+	if err != nil {
+		return err
+	}
 
-	query := "DELETE FROM tasks WHERE id=$1"
-	_, err = s.db.Exec(query, taskId)
+	if authorId != userId {
+		return ErrParentNotFound
+	}
+	return nil
+}
+
+// queryIds runs the query returning a single id column.
+func queryIds(tx *sql.Tx, query string, args ...any) ([]int64, error) {
+	rows, err := tx.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	return task, nil
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func nullTaskId(taskId int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: taskId, Valid: taskId != 0}
 }
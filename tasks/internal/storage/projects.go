@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/lib/pq"
+)
+
+const selectProjects = `
+	SELECT id, user_id, name, color, archived, position, created_at, updated_at
+	FROM projects`
+
+func scanProject(row rowScanner) (*models.Project, error) {
+	var p models.Project
+	err := row.Scan(&p.Id, &p.UserId, &p.Name, &p.Color, &p.Archived, &p.Position, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func getProject(queryRow func(query string, args ...any) *sql.Row, userId, projectId int64, lock string) (*models.Project, error) {
+	project, err := scanProject(queryRow(selectProjects+" WHERE id=$1"+lock, projectId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userId != project.UserId {
+		return nil, ErrNotProjectOwner
+	}
+
+	return project, nil
+}
+
+// checkTaskProject makes sure the task can be put into the project, a zero project id means the inbox.
+// The project row stays locked until the transaction ends, so it can't be removed meanwhile.
+func checkTaskProject(tx *sql.Tx, userId, projectId int64) error {
+	if projectId == 0 {
+		return nil
+	}
+
+	project, err := getProject(tx.QueryRow, userId, projectId, " FOR SHARE")
+	if err != nil {
+		return err
+	}
+
+	if project.Archived {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+func nullProjectId(projectId int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: projectId, Valid: projectId != 0}
+}
+
+// CreateProject puts the new project after the user's other projects.
+func (s *PostgresStorage) CreateProject(project *models.Project) (*models.Project, error) {
+	query := `
+	INSERT INTO projects (user_id, name, color, position)
+	SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0) FROM projects WHERE user_id=$1
+	RETURNING id, position, created_at, updated_at`
+
+	created := *project
+	err := s.db.QueryRow(query, project.UserId, project.Name, project.Color).
+		Scan(&created.Id, &created.Position, &created.CreatedAt, &created.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (s *PostgresStorage) GetProject(userId, projectId int64) (*models.Project, error) {
+	return getProject(s.db.QueryRow, userId, projectId, "")
+}
+
+func (s *PostgresStorage) ListProjects(userId int64, includeArchived bool) ([]*models.Project, error) {
+	query := selectProjects + " WHERE user_id=$1 AND ($2 OR NOT archived) ORDER BY position, id"
+
+	rows, err := s.db.Query(query, userId, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*models.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject changes the project fields, moving the project to a new position shifts the projects in between.
+func (s *PostgresStorage) UpdateProject(userId, projectId int64, upd *models.ProjectUpdate) (*models.Project, error) {
+	var project *models.Project
+	err := s.withTx(func(tx *sql.Tx) error {
+		old, err := getProject(tx.QueryRow, userId, projectId, " FOR UPDATE")
+		if err != nil {
+			return err
+		}
+
+		p := *old
+		if upd.Name != nil {
+			p.Name = *upd.Name
+		}
+		if upd.Color != nil {
+			p.Color = *upd.Color
+		}
+		if upd.Archived != nil {
+			p.Archived = *upd.Archived
+		}
+		if upd.Position != nil && *upd.Position != old.Position {
+			if p.Position, err = moveProject(tx, old, *upd.Position); err != nil {
+				return err
+			}
+		}
+
+		query := `
+		UPDATE projects
+		SET name=$1, color=$2, archived=$3, position=$4, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$5
+		RETURNING updated_at`
+
+		if err := tx.QueryRow(query, p.Name, p.Color, p.Archived, p.Position, p.Id).Scan(&p.UpdatedAt); err != nil {
+			return err
+		}
+
+		project = &p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// moveProject frees the requested position for the project and returns it clamped to the user's projects range.
+func moveProject(tx *sql.Tx, project *models.Project, position int) (int, error) {
+	var last int
+	if err := tx.QueryRow("SELECT COALESCE(MAX(position), 0) FROM projects WHERE user_id=$1", project.UserId).Scan(&last); err != nil {
+		return 0, err
+	}
+	position = max(0, min(position, last))
+
+	var query string
+	switch {
+	case position < project.Position:
+		query = "UPDATE projects SET position=position+1 WHERE user_id=$1 AND position>=$2 AND position<$3"
+	case position > project.Position:
+		query = "UPDATE projects SET position=position-1 WHERE user_id=$1 AND position<=$2 AND position>$3"
+	default:
+		return position, nil
+	}
+
+	if _, err := tx.Exec(query, project.UserId, position, project.Position); err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// DeleteProject removes the project and either moves its tasks to the inbox or to the trash.
+// The affected tasks are returned in their new state.
+func (s *PostgresStorage) DeleteProject(userId, projectId int64, deleteTasks bool) (*models.Project, []*models.Task, error) {
+	var (
+		project *models.Project
+		tasks   []*models.Task
+	)
+	err := s.withTx(func(tx *sql.Tx) error {
+		var err error
+		project, err = getProject(tx.QueryRow, userId, projectId, " FOR UPDATE")
+		if err != nil {
+			return err
+		}
+
+		query := `
+		UPDATE tasks SET project_id=NULL, version=version+1, updated_at=CURRENT_TIMESTAMP
+		WHERE project_id=$1 AND deleted_at IS NULL
+		RETURNING id`
+		if deleteTasks {
+			// subtasks go to the trash with their parents even when they are in other projects
+			query = `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM tasks WHERE project_id=$1 AND deleted_at IS NULL
+				UNION
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+			)
+			UPDATE tasks
+			SET project_id=CASE WHEN project_id=$1 THEN NULL ELSE project_id END,
+				deleted_at=CURRENT_TIMESTAMP, version=version+1, updated_at=CURRENT_TIMESTAMP
+			WHERE id IN (SELECT id FROM subtree)
+			RETURNING id`
+		}
+
+		ids, err := queryIds(tx, query, projectId)
+		if err != nil {
+			return err
+		}
+
+		if len(ids) > 0 {
+			rows, err := tx.Query(selectTasks+" WHERE t.id = ANY($1) ORDER BY t.id", pq.Array(ids))
+			if err != nil {
+				return err
+			}
+			if tasks, err = scanTasks(rows); err != nil {
+				return err
+			}
+			if err := enqueueIndex(tx, ids...); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec("DELETE FROM projects WHERE id=$1", projectId); err != nil {
+			return err
+		}
+
+		query = "UPDATE projects SET position=position-1 WHERE user_id=$1 AND position>$2"
+		_, err = tx.Exec(query, project.UserId, project.Position)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return project, tasks, nil
+}
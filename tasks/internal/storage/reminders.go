@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/lib/pq"
+)
+
+// AddReminder adds a reminder of the task for the user, any member of the task can set own reminders.
+func (s *PostgresStorage) AddReminder(userId, taskId int64, remindAt time.Time, maxReminders int) (reminder *models.Reminder, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		// the task lock keeps the pending reminders count consistent
+		if _, err := getTaskForUpdate(tx, userId, taskId, models.RoleViewer); err != nil {
+			return err
+		}
+
+		var count int
+		query := "SELECT COUNT(*) FROM reminders WHERE task_id=$1 AND user_id=$2 AND sent_at IS NULL"
+		if err := tx.QueryRow(query, taskId, userId).Scan(&count); err != nil {
+			return err
+		}
+		if count >= maxReminders {
+			return ErrTooManyReminders
+		}
+
+		reminder = &models.Reminder{TaskId: taskId, UserId: userId, RemindAt: remindAt.UTC()}
+		query = `
+		INSERT INTO reminders (task_id, user_id, remind_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+		return tx.QueryRow(query, taskId, userId, reminder.RemindAt).Scan(&reminder.Id, &reminder.CreatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// ListReminders returns the user's reminders of the task, the sent ones included.
+func (s *PostgresStorage) ListReminders(userId, taskId int64) ([]*models.Reminder, error) {
+	if _, err := s.GetTaskById(userId, taskId); err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT id, task_id, user_id, remind_at, sent_at, created_at
+	FROM reminders
+	WHERE task_id=$1 AND user_id=$2
+	ORDER BY remind_at, id`
+
+	rows, err := s.db.Query(query, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []*models.Reminder
+	for rows.Next() {
+		var (
+			reminder models.Reminder
+			sentAt   sql.NullTime
+		)
+		err := rows.Scan(&reminder.Id, &reminder.TaskId, &reminder.UserId, &reminder.RemindAt, &sentAt, &reminder.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if sentAt.Valid {
+			reminder.SentAt = &sentAt.Time
+		}
+		reminders = append(reminders, &reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (s *PostgresStorage) RemoveReminder(userId, taskId, reminderId int64) error {
+	if _, err := s.GetTaskById(userId, taskId); err != nil {
+		return err
+	}
+
+	query := "DELETE FROM reminders WHERE id=$1 AND task_id=$2 AND user_id=$3"
+	res, err := s.db.Exec(query, reminderId, taskId, userId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// ProcessDueReminders marks up to limit pending reminders due by now as sent and stores their events,
+// returned by event, in the outbox within the same transaction, so outbox.Relay publishes them after
+// the commit and no lock is held while Kafka is called. A nil event marks the reminder without sending it.
+// The reminders are locked with SKIP LOCKED, so several schedulers never get the same reminder.
+func (s *PostgresStorage) ProcessDueReminders(now time.Time, limit int, event func(reminder *models.DueReminder) *models.TaskEvent) (sent int, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		query := `
+		SELECT r.id, r.task_id, r.user_id, r.remind_at, r.created_at
+		FROM reminders r
+		JOIN tasks t ON r.task_id = t.id
+		WHERE r.sent_at IS NULL AND r.remind_at <= $1 AND t.deleted_at IS NULL
+		ORDER BY r.remind_at, r.id
+		LIMIT $2
+		FOR UPDATE OF r SKIP LOCKED`
+
+		rows, err := tx.Query(query, now.UTC(), limit)
+		if err != nil {
+			return err
+		}
+
+		var due []*models.DueReminder
+		for rows.Next() {
+			var r models.DueReminder
+			err := rows.Scan(&r.Id, &r.TaskId, &r.UserId, &r.RemindAt, &r.CreatedAt)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			due = append(due, &r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(due))
+		for _, r := range due {
+			if r.Task, err = scanTask(tx.QueryRow(selectTasks+" WHERE t.id=$1", r.TaskId)); err != nil {
+				return err
+			}
+			if e := event(r); e != nil {
+				if err := enqueueEvent(tx, e); err != nil {
+					return err
+				}
+			}
+			ids = append(ids, r.Id)
+		}
+
+		query = "UPDATE reminders SET sent_at=$1 WHERE id = ANY($2)"
+		if _, err := tx.Exec(query, now.UTC(), pq.Array(ids)); err != nil {
+			return err
+		}
+		sent = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, nil
+}
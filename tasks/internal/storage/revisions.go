@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+)
+
+const selectRevisions = `
+	SELECT r.task_id, r.revision, r.text, COALESCE(r.editor_id, 0), COALESCE(u.username, ''), r.created_at
+	FROM task_revisions r
+	LEFT JOIN users u ON r.editor_id = u.id`
+
+func scanRevision(row rowScanner) (*models.TaskRevision, error) {
+	var rev models.TaskRevision
+	err := row.Scan(&rev.TaskId, &rev.Revision, &rev.Text, &rev.EditorId, &rev.EditorName, &rev.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// insertRevision records the task text as the next revision of the task.
+// The task row must be locked by the caller so revision numbers don't race.
+func insertRevision(tx *sql.Tx, taskId int64, text string, editorId int64) error {
+	query := `
+	INSERT INTO task_revisions (task_id, revision, text, editor_id)
+	SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3 FROM task_revisions WHERE task_id=$1`
+
+	_, err := tx.Exec(query, taskId, text, editorId)
+	return err
+}
+
+func (s *PostgresStorage) GetTaskRevisions(userId, taskId int64) ([]*models.TaskRevision, error) {
+	if _, err := s.GetTaskById(userId, taskId); err != nil {
+		return nil, err
+	}
+
+	query := selectRevisions + " WHERE r.task_id=$1 ORDER BY r.revision DESC"
+
+	rows, err := s.db.Query(query, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*models.TaskRevision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetTaskRevision returns the given revision of the task, revision 0 means the latest one.
+func (s *PostgresStorage) GetTaskRevision(userId, taskId int64, revision int) (*models.TaskRevision, error) {
+	if _, err := s.GetTaskById(userId, taskId); err != nil {
+		return nil, err
+	}
+
+	return getRevision(s.db.QueryRow, taskId, revision)
+}
+
+func getRevision(queryRow func(query string, args ...any) *sql.Row, taskId int64, revision int) (*models.TaskRevision, error) {
+	query := selectRevisions + " WHERE r.task_id=$1 AND ($2 = 0 OR r.revision=$2) ORDER BY r.revision DESC LIMIT 1"
+
+	rev, err := scanRevision(queryRow(query, taskId, revision))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// RevertTask sets the task text back to the given revision, which is recorded as a new revision.
+func (s *PostgresStorage) RevertTask(userId, taskId int64, revision int, expectedVersion int64) (oldTask, newTask *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		oldTask, err = getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && expectedVersion != oldTask.Version {
+			return ErrVersionMismatch
+		}
+
+		rev, err := getRevision(tx.QueryRow, taskId, revision)
+		if err != nil {
+			return err
+		}
+
+		newTask, err = applyTaskUpdate(tx, userId, oldTask, &models.TaskUpdate{Text: &rev.Text})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oldTask, newTask, nil
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/lib/pq"
+)
+
+// AddTags attaches the tags to the task, creating the author's tags which don't exist yet.
+// ErrTooManyTags is returned when the task would end up with more than maxTags tags.
+func (s *PostgresStorage) AddTags(userId, taskId int64, tags []string, maxTags int) (*models.Task, error) {
+	var task *models.Task
+	err := s.withTx(func(tx *sql.Tx) error {
+		oldTask, err := getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		query := `
+		INSERT INTO tags (user_id, name)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (user_id, name) DO NOTHING`
+		if _, err := tx.Exec(query, oldTask.AuthorId, pq.Array(tags)); err != nil {
+			return err
+		}
+
+		query = `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT $1, id FROM tags WHERE user_id=$2 AND name = ANY($3)
+		ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(query, taskId, oldTask.AuthorId, pq.Array(tags)); err != nil {
+			return err
+		}
+
+		task, err = touchTask(tx, userId, taskId)
+		if err != nil {
+			return err
+		}
+
+		if len(task.Tags) > maxTags {
+			return ErrTooManyTags
+		}
+		return enqueueIndex(tx, taskId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RemoveTags detaches the tags from the task and drops the tags which are no longer used.
+func (s *PostgresStorage) RemoveTags(userId, taskId int64, tags []string) (*models.Task, error) {
+	var task *models.Task
+	err := s.withTx(func(tx *sql.Tx) error {
+		oldTask, err := getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		query := `
+		DELETE FROM task_tags tt USING tags tg
+		WHERE tt.tag_id = tg.id AND tt.task_id=$1 AND tg.user_id=$2 AND tg.name = ANY($3)`
+		if _, err := tx.Exec(query, taskId, oldTask.AuthorId, pq.Array(tags)); err != nil {
+			return err
+		}
+
+		query = `
+		DELETE FROM tags tg
+		WHERE tg.user_id=$1 AND tg.name = ANY($2)
+			AND NOT EXISTS (SELECT 1 FROM task_tags tt WHERE tt.tag_id = tg.id)`
+		if _, err := tx.Exec(query, oldTask.AuthorId, pq.Array(tags)); err != nil {
+			return err
+		}
+
+		task, err = touchTask(tx, userId, taskId)
+		if err != nil {
+			return err
+		}
+		return enqueueIndex(tx, taskId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// ListTags returns the user's tags with the number of not deleted tasks using them.
+func (s *PostgresStorage) ListTags(userId int64) ([]*models.TagCount, error) {
+	query := `
+	SELECT tg.name, COUNT(t.id)
+	FROM tags tg
+	LEFT JOIN task_tags tt ON tt.tag_id = tg.id
+	LEFT JOIN tasks t ON t.id = tt.task_id AND t.deleted_at IS NULL
+	WHERE tg.user_id=$1
+	GROUP BY tg.name
+	ORDER BY tg.name`
+
+	rows, err := s.db.Query(query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*models.TagCount
+	for rows.Next() {
+		var tag models.TagCount
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// touchTask bumps the task version after a change of its related rows and returns the fresh task.
+func touchTask(tx *sql.Tx, userId, taskId int64) (*models.Task, error) {
+	query := "UPDATE tasks SET version=version+1, updated_at=CURRENT_TIMESTAMP WHERE id=$1"
+	if _, err := tx.Exec(query, taskId); err != nil {
+		return nil, err
+	}
+
+	return getTaskForUpdate(tx, userId, taskId, models.RoleEditor)
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/models"
+	"github.com/lib/pq"
+)
+
+func getDeletedTask(queryRow func(query string, args ...any) *sql.Row, userId, taskId int64, lock string) (*models.Task, error) {
+	query := selectTasks + " WHERE t.id=$1 AND t.deleted_at IS NOT NULL" + lock
+
+	task, err := scanTask(queryRow(query, taskId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userId != task.AuthorId {
+		return nil, ErrNotTaskAuthor
+	}
+
+	return task, nil
+}
+
+func (s *PostgresStorage) GetDeletedUserTasks(userId int64) ([]*models.Task, error) {
+	query := selectTasks + `
+	WHERE t.author_id=$1 AND t.deleted_at IS NOT NULL
+	ORDER BY t.deleted_at DESC, t.id DESC`
+
+	rows, err := s.db.Query(query, userId)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// RestoreTask brings the task back from the trash together with the subtasks deleted along with it.
+// A subtask can't be restored while its parent is in the trash.
+func (s *PostgresStorage) RestoreTask(userId, taskId int64) (task *models.Task, subtasks []*models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		deleted, err := getDeletedTask(tx.QueryRow, userId, taskId, " FOR UPDATE OF t")
+		if err != nil {
+			return err
+		}
+
+		if deleted.ParentId != 0 {
+			var parentDeleted bool
+			query := "SELECT deleted_at IS NOT NULL FROM tasks WHERE id=$1"
+			if err := tx.QueryRow(query, deleted.ParentId).Scan(&parentDeleted); err != nil {
+				return err
+			}
+			if parentDeleted {
+				return ErrParentDeleted
+			}
+		}
+
+		query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id=$1
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at=$2
+		)
+		UPDATE tasks
+		SET deleted_at=NULL, version=version+1, updated_at=CURRENT_TIMESTAMP
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING id`
+
+		ids, err := queryIds(tx, query, taskId, *deleted.DeletedAt)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(selectTasks+" WHERE t.id = ANY($1) ORDER BY t.created_at, t.id", pq.Array(ids))
+		if err != nil {
+			return err
+		}
+		restored, err := scanTasks(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range restored {
+			if t.Id == taskId {
+				task = t
+			} else {
+				subtasks = append(subtasks, t)
+			}
+		}
+
+		if err := enqueueIndex(tx, ids...); err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventRestored, nil, task)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, subtasks, nil
+}
+
+// PurgeTask permanently removes a task from the trash.
+func (s *PostgresStorage) PurgeTask(userId, taskId int64) (task *models.Task, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		task, err = getDeletedTask(tx.QueryRow, userId, taskId, " FOR UPDATE OF t")
+		if err != nil {
+			return err
+		}
+
+		// the subtasks in the trash are removed by the parent_id cascade
+		query := "DELETE FROM tasks WHERE id=$1 AND deleted_at IS NOT NULL"
+		if _, err := tx.Exec(query, taskId); err != nil {
+			return err
+		}
+
+		if err := enqueueIndex(tx, taskId); err != nil {
+			return err
+		}
+		return enqueueTaskEvent(tx, userId, models.TaskEventPurged, nil, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// PurgeDeletedTasks permanently removes the tasks which stayed in the trash longer than the retention.
+// Like PurgeTask, it removes them from the index and publishes their purge events, on behalf of their authors.
+// The deletion time is compared with the database clock which wrote it, so the time zones
+// of the database session and the process don't matter.
+func (s *PostgresStorage) PurgeDeletedTasks(retention time.Duration) (count int64, err error) {
+	err = s.withTx(func(tx *sql.Tx) error {
+		query := selectTasks + `
+		WHERE t.deleted_at IS NOT NULL AND t.deleted_at < CURRENT_TIMESTAMP - $1 * INTERVAL '1 microsecond'
+		FOR UPDATE OF t`
+		rows, err := tx.Query(query, retention.Microseconds())
+		if err != nil {
+			return err
+		}
+		expired, err := scanTasks(rows)
+		if err != nil || len(expired) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(expired))
+		for _, task := range expired {
+			ids = append(ids, task.Id)
+		}
+
+		res, err := tx.Exec("DELETE FROM tasks WHERE id = ANY($1)", pq.Array(ids))
+		if err != nil {
+			return err
+		}
+		if count, err = res.RowsAffected(); err != nil {
+			return err
+		}
+
+		if err := enqueueIndex(tx, ids...); err != nil {
+			return err
+		}
+		for _, task := range expired {
+			if err := enqueueTaskEvent(tx, task.AuthorId, models.TaskEventPurged, nil, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,61 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Novip1906/tasks-grpc/tasks/internal/config"
+	"github.com/Novip1906/tasks-grpc/tasks/pkg/logging"
+)
+
+type PurgeStorage interface {
+	PurgeDeletedTasks(retention time.Duration) (int64, error)
+}
+
+// Purger periodically removes the tasks which stayed in the trash longer than the retention period.
+type Purger struct {
+	db        PurgeStorage
+	retention time.Duration
+	interval  time.Duration
+	log       *slog.Logger
+}
+
+func NewPurger(db PurgeStorage, cfg *config.Trash, log *slog.Logger) *Purger {
+	return &Purger{
+		db:        db,
+		retention: cfg.Retention,
+		interval:  cfg.PurgeInterval,
+		log:       log.With(slog.String("job", "trash_purger")),
+	}
+}
+
+func (p *Purger) Run(ctx context.Context) {
+	p.log.Info("trash purger started", "retention", p.retention, "interval", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+
+		select {
+		case <-ctx.Done():
+			p.log.Info("trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge() {
+	count, err := p.db.PurgeDeletedTasks(p.retention)
+	if err != nil {
+		p.log.Error("db error", logging.DbErr("PurgeDeletedTasks", err))
+		return
+	}
+
+	if count > 0 {
+		p.log.Info("tasks purged from trash", "count", count)
+	}
+}